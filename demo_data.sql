@@ -20,421 +20,421 @@ SET row_security = off;
 -- Data for Name: tracks; Type: TABLE DATA; Schema: public; Owner: postgres
 --
 
-INSERT INTO public.tracks VALUES ('Walking On Sunshine', 'Katrina & The Waves', '{}', 238, '1985', false, 0, '');
-INSERT INTO public.tracks VALUES ('Dreams - 2004 Remaster', 'Fleetwood Mac', '{"classic rock","yacht rock","soft rock"}', 257, '1977', false, 0, '');
-INSERT INTO public.tracks VALUES ('Pink Pony Club', 'Chappell Roan', '{}', 258, '2023', false, 0, '');
-INSERT INTO public.tracks VALUES ('Ain''t It Fun', 'Paramore', '{"pop punk",emo}', 296, '2013', false, 0, '');
-INSERT INTO public.tracks VALUES ('The Middle', 'Jimmy Eat World', '{emo,"pop punk"}', 165, '2001', false, 0, '');
-INSERT INTO public.tracks VALUES ('Proud Mary', 'Tina Turner', '{}', 327, '1993', false, 0, '');
-INSERT INTO public.tracks VALUES ('Blinding Lights', 'The Weeknd', '{}', 200, '2020', false, 0, '');
-INSERT INTO public.tracks VALUES ('Crazy In Love (feat. JAY-Z)', 'Beyoncé', '{}', 236, '2003', false, 0, '');
-INSERT INTO public.tracks VALUES ('Uptown Funk (feat. Bruno Mars)', 'Mark Ronson', '{}', 269, '2015', false, 0, '');
-INSERT INTO public.tracks VALUES ('Shut Up and Dance', 'WALK THE MOON', '{}', 199, '2014', false, 0, '');
-INSERT INTO public.tracks VALUES ('You Are the Best Thing', 'Ray LaMontagne', '{}', 231, '2008', false, 0, '');
-INSERT INTO public.tracks VALUES ('You Shook Me All Night Long', 'AC/DC', '{rock,"hard rock","classic rock","rock and roll"}', 210, '1980', false, 0, '');
-INSERT INTO public.tracks VALUES ('Beat It', 'Michael Jackson', '{}', 258, '2008', false, 0, '');
-INSERT INTO public.tracks VALUES ('Ex''s & Oh''s', 'Elle King', '{}', 202, '2015', false, 0, '');
-INSERT INTO public.tracks VALUES ('I''m Gonna Be (500 Miles)', 'The Proclaimers', '{}', 219, '2003', false, 0, '');
-INSERT INTO public.tracks VALUES ('I Love Rock ''N Roll', 'Joan Jett & the Blackhearts', '{rock}', 175, '1981', false, 0, '');
-INSERT INTO public.tracks VALUES ('Isn''t She Lovely', 'Stevie Wonder', '{motown,"classic soul",soul}', 394, '1976', false, 0, '');
-INSERT INTO public.tracks VALUES ('Valerie (feat. Amy Winehouse) - Version Revisited', 'Mark Ronson', '{}', 219, '2007', false, 0, '');
-INSERT INTO public.tracks VALUES ('Smells Like Teen Spirit', 'Nirvana', '{grunge,rock}', 301, '1991', false, 0, '');
-INSERT INTO public.tracks VALUES ('Sugar, We''re Goin Down', 'Fall Out Boy', '{emo,"pop punk"}', 229, '2005', false, 0, '');
-INSERT INTO public.tracks VALUES ('Life is a Highway', 'Rascal Flatts', '{country}', 275, '2006', false, 0, '');
-INSERT INTO public.tracks VALUES ('Wagon Wheel', 'Darius Rucker', '{country}', 298, '2013', false, 0, '');
-INSERT INTO public.tracks VALUES ('Don''t Stop Believin''', 'Journey', '{aor,"classic rock"}', 250, '1981', false, 0, '');
-INSERT INTO public.tracks VALUES ('Take Me Home, Country Roads', 'John Denver', '{folk}', 197, '1997', false, 0, '');
-INSERT INTO public.tracks VALUES ('All The Small Things', 'blink-182', '{"pop punk",punk,rock,"skate punk",emo}', 167, '1999', false, 0, '');
-INSERT INTO public.tracks VALUES ('All Star', 'Smash Mouth', '{}', 200, '1999', false, 0, '');
-INSERT INTO public.tracks VALUES ('Stacy''s Mom', 'Fountains Of Wayne', '{"power pop"}', 197, '2003', false, 0, '');
-INSERT INTO public.tracks VALUES ('Basket Case', 'Green Day', '{punk,"pop punk"}', 181, '1994', false, 0, '');
-INSERT INTO public.tracks VALUES ('Highway to Hell', 'AC/DC', '{rock,"hard rock","classic rock","rock and roll"}', 208, '1979', false, 0, '');
-INSERT INTO public.tracks VALUES ('Rock and Roll - Remaster', 'Led Zeppelin', '{"classic rock",rock,"hard rock","rock and roll"}', 220, '1971', false, 0, '');
-INSERT INTO public.tracks VALUES ('My Own Worst Enemy', 'Lit', '{"pop punk"}', 169, '1999', true, 0, '');
-INSERT INTO public.tracks VALUES ('Are You Gonna Be My Girl', 'Jet', '{}', 213, '2003', false, 0, '');
-INSERT INTO public.tracks VALUES ('If I Ain''t Got You', 'Alicia Keys', '{r&b,"neo soul"}', 228, '2003', false, 0, '');
-INSERT INTO public.tracks VALUES ('The Weight - Remastered 2000', 'The Band', '{"folk rock","roots rock","southern rock",americana}', 274, '1968', false, 0, '');
-INSERT INTO public.tracks VALUES ('Tennessee Whiskey', 'Chris Stapleton', '{country,"outlaw country"}', 293, '2015', false, 0, '');
-INSERT INTO public.tracks VALUES ('Black Horse And The Cherry Tree', 'KT Tunstall', '{}', 172, '2005', false, 0, '');
-INSERT INTO public.tracks VALUES ('Forget You', 'CeeLo Green', '{}', 222, '2010', false, 0, '');
-INSERT INTO public.tracks VALUES ('Treasure', 'Bruno Mars', '{}', 178, '2012', true, 0, '');
-INSERT INTO public.tracks VALUES ('You Make My Dreams (Come True)', 'Daryl Hall & John Oates', '{"yacht rock","soft rock"}', 190, '1980', false, 0, '');
-INSERT INTO public.tracks VALUES ('Sweet Home Alabama', 'Lynyrd Skynyrd', '{"southern rock","classic rock",rock}', 283, '1974', false, 0, '');
-INSERT INTO public.tracks VALUES ('I''m a Believer', 'The Monkees', '{}', 165, '2008', false, 0, '');
-INSERT INTO public.tracks VALUES ('Sweet Child O'' Mine', 'Guns N'' Roses', '{rock,"glam metal","hard rock","classic rock"}', 356, '1987', false, 0, '');
-INSERT INTO public.tracks VALUES ('Signed, Sealed, Delivered (I''m Yours)', 'Stevie Wonder', '{motown,"classic soul",soul}', 161, '1970', false, 0, '');
-INSERT INTO public.tracks VALUES ('First Date', 'blink-182', '{"pop punk",punk,rock,"skate punk",emo}', 171, '2001', false, 0, '');
-INSERT INTO public.tracks VALUES ('Footloose - From "Footloose" Soundtrack', 'Kenny Loggins', '{"yacht rock"}', 226, '1984', false, 0, '');
-INSERT INTO public.tracks VALUES ('American Girl', 'Tom Petty and the Heartbreakers', '{"classic rock"}', 214, '1976', false, 0, '');
-INSERT INTO public.tracks VALUES ('Bad Moon Rising', 'Creedence Clearwater Revival', '{"classic rock","southern rock","country rock"}', 141, '1969', false, 0, '');
-INSERT INTO public.tracks VALUES ('Superstition - Single Version', 'Stevie Wonder', '{motown,"classic soul",soul}', 245, '2002', false, 0, '');
-INSERT INTO public.tracks VALUES ('Old Time Rock & Roll', 'Bob Seger', '{"classic rock"}', 194, '1978', false, 0, '');
-INSERT INTO public.tracks VALUES ('Give Me One Reason', 'Tracy Chapman', '{}', 268, '1995', false, 0, '');
-INSERT INTO public.tracks VALUES ('...Baby One More Time', 'Britney Spears', '{pop}', 211, '1999', false, 0, '');
-INSERT INTO public.tracks VALUES ('Gimme! Gimme! Gimme! (A Man After Midnight)', 'ABBA', '{}', 292, '1979', false, 0, '');
-INSERT INTO public.tracks VALUES ('Locked out of Heaven', 'Bruno Mars', '{}', 233, '2012', false, 0, '');
-INSERT INTO public.tracks VALUES ('Everybody Talks', 'Neon Trees', '{}', 177, '2012', true, 0, '');
-INSERT INTO public.tracks VALUES ('Play That Funky Music', 'Wild Cherry', '{"funk rock"}', 300, '1976', false, 0, '');
-INSERT INTO public.tracks VALUES ('Starships', 'Nicki Minaj', '{}', 210, '2011', true, 0, '');
-INSERT INTO public.tracks VALUES ('867-5309 / Jenny', 'Tommy Tutone', '{}', 226, '1981', false, 0, '');
-INSERT INTO public.tracks VALUES ('Free Fallin''', 'Tom Petty', '{"classic rock"}', 256, '1989', false, 0, '');
-INSERT INTO public.tracks VALUES ('I Believe in a Thing Called Love', 'The Darkness', '{"glam metal"}', 216, '2003', false, 0, '');
-INSERT INTO public.tracks VALUES ('Go Your Own Way - 2004 Remaster', 'Fleetwood Mac', '{"classic rock","yacht rock","soft rock"}', 223, '1977', false, 0, '');
-INSERT INTO public.tracks VALUES ('Rebel Yell', 'Billy Idol', '{}', 288, '1983', false, 0, '');
-INSERT INTO public.tracks VALUES ('You Belong With Me', 'Taylor Swift', '{}', 231, '2008', false, 0, '');
-INSERT INTO public.tracks VALUES ('Shake It Off', 'Taylor Swift', '{}', 219, '2014', false, 0, '');
-INSERT INTO public.tracks VALUES ('Seven Nation Army', 'The White Stripes', '{"garage rock","blues rock",rock,"alternative rock"}', 231, '2003', false, 0, '');
-INSERT INTO public.tracks VALUES ('Before He Cheats', 'Carrie Underwood', '{country}', 199, '2005', false, 0, '');
-INSERT INTO public.tracks VALUES ('Sk8er Boi', 'Avril Lavigne', '{}', 204, '2002', false, 0, '');
-INSERT INTO public.tracks VALUES ('Learning To Fly', 'Tom Petty and the Heartbreakers', '{"classic rock"}', 242, '1991', false, 0, '');
-INSERT INTO public.tracks VALUES ('Billie Jean', 'Michael Jackson', '{}', 293, '2008', false, 0, '');
-INSERT INTO public.tracks VALUES ('Two Princes', 'Spin Doctors', '{}', 256, '1991', false, 0, '');
-INSERT INTO public.tracks VALUES ('I Won''t Back Down', 'Tom Petty', '{"classic rock"}', 178, '1989', false, 0, '');
-INSERT INTO public.tracks VALUES ('Have You Ever Seen The Rain', 'Creedence Clearwater Revival', '{"classic rock","southern rock","country rock"}', 160, '1970', false, 0, '');
-INSERT INTO public.tracks VALUES ('Heartbreaker', 'Pat Benatar', '{aor}', 209, '1979', false, 0, '');
-INSERT INTO public.tracks VALUES ('Turn The Page - Live', 'Bob Seger', '{"classic rock"}', 302, '1994', false, 0, '');
-INSERT INTO public.tracks VALUES ('Landslide', 'Fleetwood Mac', '{"classic rock","yacht rock","soft rock"}', 199, '1975', false, 0, '');
-INSERT INTO public.tracks VALUES ('Barracuda', 'Heart', '{"classic rock",aor,rock}', 261, '1977', false, 0, '');
-INSERT INTO public.tracks VALUES ('Sharp Dressed Man (2008 Remaster)', 'ZZ Top', '{"southern rock","classic rock","blues rock",rock}', 258, '1983', false, 0, '');
-INSERT INTO public.tracks VALUES ('Since U Been Gone', 'Kelly Clarkson', '{christmas}', 188, '2004', false, 0, '');
-INSERT INTO public.tracks VALUES ('Straight Up', 'Paula Abdul', '{}', 251, '1988', false, 0, '');
-INSERT INTO public.tracks VALUES ('Dream On', 'Aerosmith', '{"classic rock",rock}', 267, '1973', false, 0, '');
-INSERT INTO public.tracks VALUES ('Jolene', 'Dolly Parton', '{country,"classic country"}', 161, '1974', false, 0, '');
-INSERT INTO public.tracks VALUES ('You Oughta Know - 2015 Remaster', 'Alanis Morissette', '{}', 249, '1995', true, 0, '');
-INSERT INTO public.tracks VALUES ('Man in the Box', 'Alice In Chains', '{grunge,post-grunge}', 285, '1990', true, 0, '');
-INSERT INTO public.tracks VALUES ('Any Way You Want It', 'Journey', '{aor,"classic rock"}', 201, '1980', false, 0, '');
-INSERT INTO public.tracks VALUES ('Wanted Dead Or Alive', 'Bon Jovi', '{"glam metal",rock}', 308, '1986', false, 0, '');
-INSERT INTO public.tracks VALUES ('Thunderstruck', 'AC/DC', '{rock,"hard rock","classic rock","rock and roll"}', 292, '1990', false, 0, '');
-INSERT INTO public.tracks VALUES ('Brown Eyed Girl', 'Van Morrison', '{}', 183, '1967', false, 0, '');
-INSERT INTO public.tracks VALUES ('I Kissed A Girl', 'Katy Perry', '{pop}', 179, '2008', false, 0, '');
-INSERT INTO public.tracks VALUES ('Juke Box Hero', 'Foreigner', '{aor,"classic rock"}', 259, '1981', false, 0, '');
-INSERT INTO public.tracks VALUES ('Rock You Like A Hurricane', 'Scorpions', '{"hard rock","glam metal",rock}', 252, '1984', false, 0, '');
-INSERT INTO public.tracks VALUES ('Bad Reputation', 'Joan Jett & the Blackhearts', '{rock}', 169, '1981', false, 0, '');
-INSERT INTO public.tracks VALUES ('Poker Face', 'Lady Gaga', '{"art pop",pop}', 237, '2008', true, 0, '');
-INSERT INTO public.tracks VALUES ('Fast Car', 'Tracy Chapman', '{}', 296, '1988', false, 0, '');
-INSERT INTO public.tracks VALUES ('Hella Good', 'No Doubt', '{}', 242, '2001', false, 0, '');
-INSERT INTO public.tracks VALUES ('Everlong', 'Foo Fighters', '{rock,post-grunge,"alternative rock",grunge}', 250, '1997', false, 0, '');
-INSERT INTO public.tracks VALUES ('Peace of Mind', 'Boston', '{"classic rock",aor}', 303, '1976', false, 0, '');
-INSERT INTO public.tracks VALUES ('Dani California', 'Red Hot Chili Peppers', '{"funk rock","alternative rock",rock}', 282, '2006', false, 0, '');
-INSERT INTO public.tracks VALUES ('Still into You', 'Paramore', '{"pop punk",emo}', 216, '2013', false, 0, '');
-INSERT INTO public.tracks VALUES ('White Wedding', 'Billy Idol', '{}', 252, '2017', false, 0, '');
-INSERT INTO public.tracks VALUES ('Hurts So Good', 'John Mellencamp', '{"classic rock"}', 218, '1982', false, 0, '');
-INSERT INTO public.tracks VALUES ('Crazy Love', 'Van Morrison', '{}', 155, '2022', false, 0, '');
-INSERT INTO public.tracks VALUES ('Heads Carolina, Tails California', 'Jo Dee Messina', '{country,"classic country"}', 208, '1996', false, 0, '');
-INSERT INTO public.tracks VALUES ('Bye-Bye', 'Jo Dee Messina', '{country,"classic country"}', 199, '1998', false, 0, '');
-INSERT INTO public.tracks VALUES ('Hand in My Pocket - 2015 Remaster', 'Alanis Morissette', '{}', 222, '1995', false, 0, '');
-INSERT INTO public.tracks VALUES ('Free Bird', 'Lynyrd Skynyrd', '{"southern rock","classic rock",rock}', 547, '1973', false, 0, '');
-INSERT INTO public.tracks VALUES ('Cowboy Casanova', 'Carrie Underwood', '{country}', 236, '2009', false, 0, '');
-INSERT INTO public.tracks VALUES ('You''re Still The One', 'Shania Twain', '{country}', 212, '1997', false, 0, '');
-INSERT INTO public.tracks VALUES ('Man! I Feel Like A Woman!', 'Shania Twain', '{country}', 233, '1997', false, 0, '');
-INSERT INTO public.tracks VALUES ('Mama''s Broken Heart', 'Miranda Lambert', '{country}', 177, '2011', false, 0, '');
-INSERT INTO public.tracks VALUES ('Georgia Peaches', 'Lauren Alaina', '{country}', 187, '2011', false, 0, '');
-INSERT INTO public.tracks VALUES ('Welcome to Paradise', 'Green Day', '{punk,"pop punk"}', 224, '1994', false, 0, '');
-INSERT INTO public.tracks VALUES ('Fastest Girl in Town', 'Miranda Lambert', '{country}', 197, '2011', false, 0, '');
-INSERT INTO public.tracks VALUES ('Beyond', 'Leon Bridges', '{"retro soul"}', 240, '2018', false, 0, '');
-INSERT INTO public.tracks VALUES ('Runnin'' Down A Dream', 'Tom Petty', '{"classic rock"}', 292, '1989', false, 0, '');
-INSERT INTO public.tracks VALUES ('Remedy', 'The Black Crowes', '{"southern rock","jam band",rock}', 322, '1992', false, 0, '');
-INSERT INTO public.tracks VALUES ('Lonely Boy', 'The Black Keys', '{"blues rock","garage rock","modern blues",rock}', 193, '2011', false, 0, '');
-INSERT INTO public.tracks VALUES ('Mary Jane''s Last Dance', 'Tom Petty and the Heartbreakers', '{"classic rock"}', 273, '2008', false, 0, '');
-INSERT INTO public.tracks VALUES ('Take It Easy - 2013 Remaster', 'Eagles', '{"classic rock","yacht rock","soft rock"}', 211, '1972', false, 0, '');
-INSERT INTO public.tracks VALUES ('I Will Buy You A New Life', 'Everclear', '{post-grunge,"alternative rock"}', 238, '1997', false, 0, '');
-INSERT INTO public.tracks VALUES ('Beer Never Broke My Heart', 'Luke Combs', '{country}', 186, '2019', false, 0, '');
-INSERT INTO public.tracks VALUES ('1, 2 Many', 'Luke Combs', '{country}', 180, '2019', false, 0, '');
-INSERT INTO public.tracks VALUES ('When It Rains It Pours', 'Luke Combs', '{country}', 240, '2017', false, 0, '');
-INSERT INTO public.tracks VALUES ('Whiskey Glasses', 'Morgan Wallen', '{country}', 234, '2018', false, 0, '');
-INSERT INTO public.tracks VALUES ('Chicken Fried', 'Zac Brown Band', '{country,"acoustic country"}', 238, '2008', false, 0, '');
-INSERT INTO public.tracks VALUES ('Folsom Prison Blues', 'Johnny Cash', '{"classic country","outlaw country",country}', 155, '1964', false, 0, '');
-INSERT INTO public.tracks VALUES ('I Like It, I Love It', 'Tim McGraw', '{country,"classic country"}', 205, '1995', false, 0, '');
-INSERT INTO public.tracks VALUES ('Need A Favor', 'Jelly Roll', '{"country hip hop",country}', 197, '2023', false, 0, '');
-INSERT INTO public.tracks VALUES ('Closing Time', 'Semisonic', '{}', 274, '2003', false, 0, '');
-INSERT INTO public.tracks VALUES ('The Anthem', 'Good Charlotte', '{"pop punk",punk,emo}', 175, '2002', false, 0, '');
-INSERT INTO public.tracks VALUES ('Friends In Low Places - Live', 'Garth Brooks', '{"classic country",country}', 362, '2024', false, 0, '');
-INSERT INTO public.tracks VALUES ('good 4 u', 'Olivia Rodrigo', '{}', 178, '2021', false, 0, '');
-INSERT INTO public.tracks VALUES ('Glory Days', 'Bruce Springsteen', '{}', 254, '1984', false, 0, '');
-INSERT INTO public.tracks VALUES ('Wild Night', 'Van Morrison', '{}', 213, '2015', false, 0, '');
-INSERT INTO public.tracks VALUES ('Days Like This', 'Van Morrison', '{}', 197, '1995', false, 0, '');
-INSERT INTO public.tracks VALUES ('This Love', 'Maroon 5', '{pop}', 206, '2002', false, 0, '');
-INSERT INTO public.tracks VALUES ('Santeria', 'Sublime', '{"reggae rock","ska punk",ska}', 182, '1996', false, 0, '');
-INSERT INTO public.tracks VALUES ('Absolutely (Story of a Girl) - Radio Mix', 'Nine Days', '{}', 189, '2000', false, 0, '');
-INSERT INTO public.tracks VALUES ('What I Got', 'Sublime', '{"reggae rock","ska punk",ska}', 170, '1996', true, 0, '');
-INSERT INTO public.tracks VALUES ('(I Can''t Get No) Satisfaction - Mono', 'The Rolling Stones', '{"classic rock",rock}', 222, '1965', false, 0, '');
-INSERT INTO public.tracks VALUES ('Beverly Hills', 'Weezer', '{"alternative rock"}', 196, '2005', false, 0, '');
-INSERT INTO public.tracks VALUES ('Semi-Charmed Life', 'Third Eye Blind', '{}', 268, '1997', false, 0, '');
-INSERT INTO public.tracks VALUES ('The Joker', 'Steve Miller Band', '{"classic rock"}', 264, '1973', false, 0, '');
-INSERT INTO public.tracks VALUES ('Build Me Up Buttercup - Mono', 'The Foundations', '{}', 180, '1967', false, 0, '');
-INSERT INTO public.tracks VALUES ('Hard To Handle', 'The Black Crowes', '{"southern rock","jam band",rock}', 188, '1990', false, 0, '');
-INSERT INTO public.tracks VALUES ('Beast Of Burden - Remastered 1994', 'The Rolling Stones', '{"classic rock",rock}', 265, '1978', false, 0, '');
-INSERT INTO public.tracks VALUES ('How Sweet It Is (To Be Loved by You)', 'James Taylor', '{"folk rock",singer-songwriter,"soft rock"}', 215, '1976', false, 0, '');
-INSERT INTO public.tracks VALUES ('Should I Stay or Should I Go - Remastered', 'The Clash', '{punk}', 188, '1982', false, 0, '');
-INSERT INTO public.tracks VALUES ('This Is How We Do It', 'Montell Jordan', '{"new jack swing"}', 238, '1995', false, 0, '');
-INSERT INTO public.tracks VALUES ('Jumper - 1998 Edit', 'Third Eye Blind', '{}', 272, '1997', false, 0, '');
-INSERT INTO public.tracks VALUES ('Dancing with Myself', 'Generation X', '{}', 228, '1981', false, 0, '');
-INSERT INTO public.tracks VALUES ('No Diggity', 'Blackstreet', '{"new jack swing"}', 304, '1996', false, 0, '');
-INSERT INTO public.tracks VALUES ('Ain''t No Rest for the Wicked', 'Cage The Elephant', '{}', 175, '2009', false, 0, '');
-INSERT INTO public.tracks VALUES ('Rock And Roll All Nite', 'KISS', '{"glam metal","glam rock","hard rock",rock,"classic rock"}', 168, '1975', false, 0, '');
-INSERT INTO public.tracks VALUES ('Centerfold', 'The J. Geils Band', '{"classic rock"}', 216, '1981', false, 0, '');
-INSERT INTO public.tracks VALUES ('What''s My Age Again?', 'blink-182', '{"pop punk",punk,rock,"skate punk",emo}', 148, '1999', true, 0, '');
-INSERT INTO public.tracks VALUES ('Good Riddance (Time of Your Life)', 'Green Day', '{punk,"pop punk"}', 153, '1997', true, 0, '');
-INSERT INTO public.tracks VALUES ('Blitzkrieg Bop - 2016 Remaster', 'Ramones', '{punk,proto-punk}', 134, '1976', false, 0, '');
-INSERT INTO public.tracks VALUES ('Can''t Help Falling in Love', 'Elvis Presley', '{rockabilly,"rock and roll"}', 182, '1961', false, 0, '');
-INSERT INTO public.tracks VALUES ('Saturday Night’s Alright (For Fighting) - Remastered 2014', 'Elton John', '{}', 295, '1973', false, 0, '');
-INSERT INTO public.tracks VALUES ('Let''s Get It Started', 'Black Eyed Peas', '{}', 218, '2020', false, 0, '');
-INSERT INTO public.tracks VALUES ('Shout, Pts. 1 & 2', 'The Isley Brothers', '{motown,"quiet storm",soul,"classic soul","northern soul"}', 268, '1959', false, 0, '');
-INSERT INTO public.tracks VALUES ('Get Down On It', 'Kool & The Gang', '{disco,funk}', 293, '1981', false, 0, '');
-INSERT INTO public.tracks VALUES ('Good Times Bad Times - 1993 Remaster', 'Led Zeppelin', '{"classic rock",rock,"hard rock","rock and roll"}', 166, '1969', false, 0, '');
-INSERT INTO public.tracks VALUES ('You Get What You Give', 'New Radicals', '{}', 300, '1998', false, 0, '');
-INSERT INTO public.tracks VALUES ('Fortunate Son', 'Creedence Clearwater Revival', '{"classic rock","southern rock","country rock"}', 140, '1969', false, 0, '');
-INSERT INTO public.tracks VALUES ('Enter Sandman (Remastered)', 'Metallica', '{metal,"thrash metal",rock,"heavy metal","hard rock"}', 331, '1991', false, 0, '');
-INSERT INTO public.tracks VALUES ('I Saw Her Standing There - Remastered 2009', 'The Beatles', '{"classic rock","psychedelic rock"}', 173, '1963', false, 0, '');
-INSERT INTO public.tracks VALUES ('Learn to Fly', 'Foo Fighters', '{rock,post-grunge,"alternative rock",grunge}', 235, '1999', false, 0, '');
-INSERT INTO public.tracks VALUES ('T.N.T.', 'AC/DC', '{rock,"hard rock","classic rock","rock and roll"}', 214, '1976', false, 0, '');
-INSERT INTO public.tracks VALUES ('Train Kept a Rollin''', 'Aerosmith', '{"classic rock",rock}', 333, '1974', false, 0, '');
-INSERT INTO public.tracks VALUES ('Fight For Your Right', 'Beastie Boys', '{"rap rock","old school hip hop","east coast hip hop","hip hop"}', 208, '1986', false, 0, '');
-INSERT INTO public.tracks VALUES ('Crazy Little Thing Called Love - Remastered 2011', 'Queen', '{"classic rock",rock,"glam rock"}', 163, '1980', false, 0, '');
-INSERT INTO public.tracks VALUES ('Don''t You (Forget About Me)', 'Simple Minds', '{"new wave"}', 263, '1985', false, 0, '');
-INSERT INTO public.tracks VALUES ('Psycho Killer - 2005 Remaster', 'Talking Heads', '{"new wave",post-punk}', 261, '1977', false, 0, '');
-INSERT INTO public.tracks VALUES ('I Found A Way', 'Drake Bell', '{}', 179, '2005', false, 0, '');
-INSERT INTO public.tracks VALUES ('Dirty Water', 'The Standells', '{proto-punk,"garage rock"}', 167, '1966', false, 0, '');
-INSERT INTO public.tracks VALUES ('Paralyzer', 'Finger Eleven', '{}', 208, '2007', false, 0, '');
-INSERT INTO public.tracks VALUES ('She Hates Me', 'Puddle Of Mudd', '{post-grunge}', 216, '2001', true, 0, '');
-INSERT INTO public.tracks VALUES ('Save a Horse (Ride a Cowboy)', 'Big & Rich', '{country}', 200, '2004', false, 0, '');
-INSERT INTO public.tracks VALUES ('When I Come Around', 'Green Day', '{punk,"pop punk"}', 178, '1994', false, 0, '');
-INSERT INTO public.tracks VALUES ('Rockin'' in the Free World', 'Neil Young', '{"classic rock","folk rock",singer-songwriter,"roots rock"}', 281, '2004', false, 0, '');
-INSERT INTO public.tracks VALUES ('Drift Away', 'Uncle Kracker', '{}', 255, '2002', false, 0, '');
-INSERT INTO public.tracks VALUES ('Take Me Home Tonight', 'Eddie Money', '{"classic rock","yacht rock"}', 211, '1986', false, 0, '');
-INSERT INTO public.tracks VALUES ('Somebody Told Me', 'The Killers', '{"alternative rock"}', 197, '2004', false, 0, '');
-INSERT INTO public.tracks VALUES ('Champagne Supernova', 'Oasis', '{britpop,madchester,rock}', 450, '1995', false, 0, '');
-INSERT INTO public.tracks VALUES ('Lifestyles of the Rich & Famous', 'Good Charlotte', '{"pop punk",punk,emo}', 190, '2002', false, 0, '');
-INSERT INTO public.tracks VALUES ('1999 - 2019 Remaster', 'Prince', '{"funk rock"}', 373, '1982', false, 0, '');
-INSERT INTO public.tracks VALUES ('Gimme Shelter', 'The Rolling Stones', '{"classic rock",rock}', 270, '1969', false, 0, '');
-INSERT INTO public.tracks VALUES ('In Too Deep', 'Sum 41', '{"pop punk",punk,"skate punk"}', 207, '2001', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Walking On Sunshine', 'Katrina & The Waves', '{}', 238, '1985', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Dreams - 2004 Remaster', 'Fleetwood Mac', '{"classic rock","yacht rock","soft rock"}', 257, '1977', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Pink Pony Club', 'Chappell Roan', '{}', 258, '2023', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Ain''t It Fun', 'Paramore', '{"pop punk",emo}', 296, '2013', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('The Middle', 'Jimmy Eat World', '{emo,"pop punk"}', 165, '2001', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Proud Mary', 'Tina Turner', '{}', 327, '1993', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Blinding Lights', 'The Weeknd', '{}', 200, '2020', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Crazy In Love (feat. JAY-Z)', 'Beyoncé', '{}', 236, '2003', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Uptown Funk (feat. Bruno Mars)', 'Mark Ronson', '{}', 269, '2015', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Shut Up and Dance', 'WALK THE MOON', '{}', 199, '2014', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('You Are the Best Thing', 'Ray LaMontagne', '{}', 231, '2008', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('You Shook Me All Night Long', 'AC/DC', '{rock,"hard rock","classic rock","rock and roll"}', 210, '1980', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Beat It', 'Michael Jackson', '{}', 258, '2008', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Ex''s & Oh''s', 'Elle King', '{}', 202, '2015', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('I''m Gonna Be (500 Miles)', 'The Proclaimers', '{}', 219, '2003', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('I Love Rock ''N Roll', 'Joan Jett & the Blackhearts', '{rock}', 175, '1981', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Isn''t She Lovely', 'Stevie Wonder', '{motown,"classic soul",soul}', 394, '1976', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Valerie (feat. Amy Winehouse) - Version Revisited', 'Mark Ronson', '{}', 219, '2007', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Smells Like Teen Spirit', 'Nirvana', '{grunge,rock}', 301, '1991', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Sugar, We''re Goin Down', 'Fall Out Boy', '{emo,"pop punk"}', 229, '2005', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Life is a Highway', 'Rascal Flatts', '{country}', 275, '2006', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Wagon Wheel', 'Darius Rucker', '{country}', 298, '2013', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Don''t Stop Believin''', 'Journey', '{aor,"classic rock"}', 250, '1981', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Take Me Home, Country Roads', 'John Denver', '{folk}', 197, '1997', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('All The Small Things', 'blink-182', '{"pop punk",punk,rock,"skate punk",emo}', 167, '1999', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('All Star', 'Smash Mouth', '{}', 200, '1999', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Stacy''s Mom', 'Fountains Of Wayne', '{"power pop"}', 197, '2003', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Basket Case', 'Green Day', '{punk,"pop punk"}', 181, '1994', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Highway to Hell', 'AC/DC', '{rock,"hard rock","classic rock","rock and roll"}', 208, '1979', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Rock and Roll - Remaster', 'Led Zeppelin', '{"classic rock",rock,"hard rock","rock and roll"}', 220, '1971', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('My Own Worst Enemy', 'Lit', '{"pop punk"}', 169, '1999', true, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Are You Gonna Be My Girl', 'Jet', '{}', 213, '2003', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('If I Ain''t Got You', 'Alicia Keys', '{r&b,"neo soul"}', 228, '2003', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('The Weight - Remastered 2000', 'The Band', '{"folk rock","roots rock","southern rock",americana}', 274, '1968', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Tennessee Whiskey', 'Chris Stapleton', '{country,"outlaw country"}', 293, '2015', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Black Horse And The Cherry Tree', 'KT Tunstall', '{}', 172, '2005', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Forget You', 'CeeLo Green', '{}', 222, '2010', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Treasure', 'Bruno Mars', '{}', 178, '2012', true, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('You Make My Dreams (Come True)', 'Daryl Hall & John Oates', '{"yacht rock","soft rock"}', 190, '1980', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Sweet Home Alabama', 'Lynyrd Skynyrd', '{"southern rock","classic rock",rock}', 283, '1974', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('I''m a Believer', 'The Monkees', '{}', 165, '2008', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Sweet Child O'' Mine', 'Guns N'' Roses', '{rock,"glam metal","hard rock","classic rock"}', 356, '1987', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Signed, Sealed, Delivered (I''m Yours)', 'Stevie Wonder', '{motown,"classic soul",soul}', 161, '1970', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('First Date', 'blink-182', '{"pop punk",punk,rock,"skate punk",emo}', 171, '2001', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Footloose - From "Footloose" Soundtrack', 'Kenny Loggins', '{"yacht rock"}', 226, '1984', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('American Girl', 'Tom Petty and the Heartbreakers', '{"classic rock"}', 214, '1976', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Bad Moon Rising', 'Creedence Clearwater Revival', '{"classic rock","southern rock","country rock"}', 141, '1969', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Superstition - Single Version', 'Stevie Wonder', '{motown,"classic soul",soul}', 245, '2002', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Old Time Rock & Roll', 'Bob Seger', '{"classic rock"}', 194, '1978', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Give Me One Reason', 'Tracy Chapman', '{}', 268, '1995', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('...Baby One More Time', 'Britney Spears', '{pop}', 211, '1999', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Gimme! Gimme! Gimme! (A Man After Midnight)', 'ABBA', '{}', 292, '1979', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Locked out of Heaven', 'Bruno Mars', '{}', 233, '2012', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Everybody Talks', 'Neon Trees', '{}', 177, '2012', true, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Play That Funky Music', 'Wild Cherry', '{"funk rock"}', 300, '1976', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Starships', 'Nicki Minaj', '{}', 210, '2011', true, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('867-5309 / Jenny', 'Tommy Tutone', '{}', 226, '1981', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Free Fallin''', 'Tom Petty', '{"classic rock"}', 256, '1989', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('I Believe in a Thing Called Love', 'The Darkness', '{"glam metal"}', 216, '2003', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Go Your Own Way - 2004 Remaster', 'Fleetwood Mac', '{"classic rock","yacht rock","soft rock"}', 223, '1977', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Rebel Yell', 'Billy Idol', '{}', 288, '1983', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('You Belong With Me', 'Taylor Swift', '{}', 231, '2008', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Shake It Off', 'Taylor Swift', '{}', 219, '2014', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Seven Nation Army', 'The White Stripes', '{"garage rock","blues rock",rock,"alternative rock"}', 231, '2003', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Before He Cheats', 'Carrie Underwood', '{country}', 199, '2005', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Sk8er Boi', 'Avril Lavigne', '{}', 204, '2002', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Learning To Fly', 'Tom Petty and the Heartbreakers', '{"classic rock"}', 242, '1991', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Billie Jean', 'Michael Jackson', '{}', 293, '2008', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Two Princes', 'Spin Doctors', '{}', 256, '1991', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('I Won''t Back Down', 'Tom Petty', '{"classic rock"}', 178, '1989', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Have You Ever Seen The Rain', 'Creedence Clearwater Revival', '{"classic rock","southern rock","country rock"}', 160, '1970', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Heartbreaker', 'Pat Benatar', '{aor}', 209, '1979', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Turn The Page - Live', 'Bob Seger', '{"classic rock"}', 302, '1994', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Landslide', 'Fleetwood Mac', '{"classic rock","yacht rock","soft rock"}', 199, '1975', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Barracuda', 'Heart', '{"classic rock",aor,rock}', 261, '1977', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Sharp Dressed Man (2008 Remaster)', 'ZZ Top', '{"southern rock","classic rock","blues rock",rock}', 258, '1983', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Since U Been Gone', 'Kelly Clarkson', '{christmas}', 188, '2004', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Straight Up', 'Paula Abdul', '{}', 251, '1988', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Dream On', 'Aerosmith', '{"classic rock",rock}', 267, '1973', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Jolene', 'Dolly Parton', '{country,"classic country"}', 161, '1974', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('You Oughta Know - 2015 Remaster', 'Alanis Morissette', '{}', 249, '1995', true, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Man in the Box', 'Alice In Chains', '{grunge,post-grunge}', 285, '1990', true, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Any Way You Want It', 'Journey', '{aor,"classic rock"}', 201, '1980', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Wanted Dead Or Alive', 'Bon Jovi', '{"glam metal",rock}', 308, '1986', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Thunderstruck', 'AC/DC', '{rock,"hard rock","classic rock","rock and roll"}', 292, '1990', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Brown Eyed Girl', 'Van Morrison', '{}', 183, '1967', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('I Kissed A Girl', 'Katy Perry', '{pop}', 179, '2008', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Juke Box Hero', 'Foreigner', '{aor,"classic rock"}', 259, '1981', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Rock You Like A Hurricane', 'Scorpions', '{"hard rock","glam metal",rock}', 252, '1984', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Bad Reputation', 'Joan Jett & the Blackhearts', '{rock}', 169, '1981', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Poker Face', 'Lady Gaga', '{"art pop",pop}', 237, '2008', true, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Fast Car', 'Tracy Chapman', '{}', 296, '1988', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Hella Good', 'No Doubt', '{}', 242, '2001', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Everlong', 'Foo Fighters', '{rock,post-grunge,"alternative rock",grunge}', 250, '1997', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Peace of Mind', 'Boston', '{"classic rock",aor}', 303, '1976', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Dani California', 'Red Hot Chili Peppers', '{"funk rock","alternative rock",rock}', 282, '2006', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Still into You', 'Paramore', '{"pop punk",emo}', 216, '2013', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('White Wedding', 'Billy Idol', '{}', 252, '2017', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Hurts So Good', 'John Mellencamp', '{"classic rock"}', 218, '1982', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Crazy Love', 'Van Morrison', '{}', 155, '2022', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Heads Carolina, Tails California', 'Jo Dee Messina', '{country,"classic country"}', 208, '1996', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Bye-Bye', 'Jo Dee Messina', '{country,"classic country"}', 199, '1998', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Hand in My Pocket - 2015 Remaster', 'Alanis Morissette', '{}', 222, '1995', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Free Bird', 'Lynyrd Skynyrd', '{"southern rock","classic rock",rock}', 547, '1973', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Cowboy Casanova', 'Carrie Underwood', '{country}', 236, '2009', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('You''re Still The One', 'Shania Twain', '{country}', 212, '1997', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Man! I Feel Like A Woman!', 'Shania Twain', '{country}', 233, '1997', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Mama''s Broken Heart', 'Miranda Lambert', '{country}', 177, '2011', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Georgia Peaches', 'Lauren Alaina', '{country}', 187, '2011', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Welcome to Paradise', 'Green Day', '{punk,"pop punk"}', 224, '1994', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Fastest Girl in Town', 'Miranda Lambert', '{country}', 197, '2011', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Beyond', 'Leon Bridges', '{"retro soul"}', 240, '2018', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Runnin'' Down A Dream', 'Tom Petty', '{"classic rock"}', 292, '1989', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Remedy', 'The Black Crowes', '{"southern rock","jam band",rock}', 322, '1992', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Lonely Boy', 'The Black Keys', '{"blues rock","garage rock","modern blues",rock}', 193, '2011', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Mary Jane''s Last Dance', 'Tom Petty and the Heartbreakers', '{"classic rock"}', 273, '2008', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Take It Easy - 2013 Remaster', 'Eagles', '{"classic rock","yacht rock","soft rock"}', 211, '1972', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('I Will Buy You A New Life', 'Everclear', '{post-grunge,"alternative rock"}', 238, '1997', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Beer Never Broke My Heart', 'Luke Combs', '{country}', 186, '2019', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('1, 2 Many', 'Luke Combs', '{country}', 180, '2019', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('When It Rains It Pours', 'Luke Combs', '{country}', 240, '2017', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Whiskey Glasses', 'Morgan Wallen', '{country}', 234, '2018', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Chicken Fried', 'Zac Brown Band', '{country,"acoustic country"}', 238, '2008', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Folsom Prison Blues', 'Johnny Cash', '{"classic country","outlaw country",country}', 155, '1964', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('I Like It, I Love It', 'Tim McGraw', '{country,"classic country"}', 205, '1995', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Need A Favor', 'Jelly Roll', '{"country hip hop",country}', 197, '2023', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Closing Time', 'Semisonic', '{}', 274, '2003', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('The Anthem', 'Good Charlotte', '{"pop punk",punk,emo}', 175, '2002', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Friends In Low Places - Live', 'Garth Brooks', '{"classic country",country}', 362, '2024', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('good 4 u', 'Olivia Rodrigo', '{}', 178, '2021', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Glory Days', 'Bruce Springsteen', '{}', 254, '1984', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Wild Night', 'Van Morrison', '{}', 213, '2015', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Days Like This', 'Van Morrison', '{}', 197, '1995', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('This Love', 'Maroon 5', '{pop}', 206, '2002', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Santeria', 'Sublime', '{"reggae rock","ska punk",ska}', 182, '1996', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Absolutely (Story of a Girl) - Radio Mix', 'Nine Days', '{}', 189, '2000', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('What I Got', 'Sublime', '{"reggae rock","ska punk",ska}', 170, '1996', true, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('(I Can''t Get No) Satisfaction - Mono', 'The Rolling Stones', '{"classic rock",rock}', 222, '1965', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Beverly Hills', 'Weezer', '{"alternative rock"}', 196, '2005', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Semi-Charmed Life', 'Third Eye Blind', '{}', 268, '1997', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('The Joker', 'Steve Miller Band', '{"classic rock"}', 264, '1973', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Build Me Up Buttercup - Mono', 'The Foundations', '{}', 180, '1967', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Hard To Handle', 'The Black Crowes', '{"southern rock","jam band",rock}', 188, '1990', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Beast Of Burden - Remastered 1994', 'The Rolling Stones', '{"classic rock",rock}', 265, '1978', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('How Sweet It Is (To Be Loved by You)', 'James Taylor', '{"folk rock",singer-songwriter,"soft rock"}', 215, '1976', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Should I Stay or Should I Go - Remastered', 'The Clash', '{punk}', 188, '1982', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('This Is How We Do It', 'Montell Jordan', '{"new jack swing"}', 238, '1995', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Jumper - 1998 Edit', 'Third Eye Blind', '{}', 272, '1997', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Dancing with Myself', 'Generation X', '{}', 228, '1981', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('No Diggity', 'Blackstreet', '{"new jack swing"}', 304, '1996', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Ain''t No Rest for the Wicked', 'Cage The Elephant', '{}', 175, '2009', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Rock And Roll All Nite', 'KISS', '{"glam metal","glam rock","hard rock",rock,"classic rock"}', 168, '1975', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Centerfold', 'The J. Geils Band', '{"classic rock"}', 216, '1981', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('What''s My Age Again?', 'blink-182', '{"pop punk",punk,rock,"skate punk",emo}', 148, '1999', true, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Good Riddance (Time of Your Life)', 'Green Day', '{punk,"pop punk"}', 153, '1997', true, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Blitzkrieg Bop - 2016 Remaster', 'Ramones', '{punk,proto-punk}', 134, '1976', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Can''t Help Falling in Love', 'Elvis Presley', '{rockabilly,"rock and roll"}', 182, '1961', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Saturday Night’s Alright (For Fighting) - Remastered 2014', 'Elton John', '{}', 295, '1973', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Let''s Get It Started', 'Black Eyed Peas', '{}', 218, '2020', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Shout, Pts. 1 & 2', 'The Isley Brothers', '{motown,"quiet storm",soul,"classic soul","northern soul"}', 268, '1959', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Get Down On It', 'Kool & The Gang', '{disco,funk}', 293, '1981', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Good Times Bad Times - 1993 Remaster', 'Led Zeppelin', '{"classic rock",rock,"hard rock","rock and roll"}', 166, '1969', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('You Get What You Give', 'New Radicals', '{}', 300, '1998', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Fortunate Son', 'Creedence Clearwater Revival', '{"classic rock","southern rock","country rock"}', 140, '1969', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Enter Sandman (Remastered)', 'Metallica', '{metal,"thrash metal",rock,"heavy metal","hard rock"}', 331, '1991', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('I Saw Her Standing There - Remastered 2009', 'The Beatles', '{"classic rock","psychedelic rock"}', 173, '1963', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Learn to Fly', 'Foo Fighters', '{rock,post-grunge,"alternative rock",grunge}', 235, '1999', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('T.N.T.', 'AC/DC', '{rock,"hard rock","classic rock","rock and roll"}', 214, '1976', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Train Kept a Rollin''', 'Aerosmith', '{"classic rock",rock}', 333, '1974', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Fight For Your Right', 'Beastie Boys', '{"rap rock","old school hip hop","east coast hip hop","hip hop"}', 208, '1986', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Crazy Little Thing Called Love - Remastered 2011', 'Queen', '{"classic rock",rock,"glam rock"}', 163, '1980', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Don''t You (Forget About Me)', 'Simple Minds', '{"new wave"}', 263, '1985', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Psycho Killer - 2005 Remaster', 'Talking Heads', '{"new wave",post-punk}', 261, '1977', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('I Found A Way', 'Drake Bell', '{}', 179, '2005', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Dirty Water', 'The Standells', '{proto-punk,"garage rock"}', 167, '1966', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Paralyzer', 'Finger Eleven', '{}', 208, '2007', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('She Hates Me', 'Puddle Of Mudd', '{post-grunge}', 216, '2001', true, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Save a Horse (Ride a Cowboy)', 'Big & Rich', '{country}', 200, '2004', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('When I Come Around', 'Green Day', '{punk,"pop punk"}', 178, '1994', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Rockin'' in the Free World', 'Neil Young', '{"classic rock","folk rock",singer-songwriter,"roots rock"}', 281, '2004', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Drift Away', 'Uncle Kracker', '{}', 255, '2002', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Take Me Home Tonight', 'Eddie Money', '{"classic rock","yacht rock"}', 211, '1986', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Somebody Told Me', 'The Killers', '{"alternative rock"}', 197, '2004', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Champagne Supernova', 'Oasis', '{britpop,madchester,rock}', 450, '1995', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Lifestyles of the Rich & Famous', 'Good Charlotte', '{"pop punk",punk,emo}', 190, '2002', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('1999 - 2019 Remaster', 'Prince', '{"funk rock"}', 373, '1982', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('Gimme Shelter', 'The Rolling Stones', '{"classic rock",rock}', 270, '1969', false, 0, '');
+INSERT INTO public.tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key) VALUES ('In Too Deep', 'Sum 41', '{"pop punk",punk,"skate punk"}', 207, '2001', false, 0, '');
 
 
 --
 -- Data for Name: singers; Type: TABLE DATA; Schema: public; Owner: postgres
 --
 
-INSERT INTO public.singers VALUES ('Walking On Sunshine', 'Katrina & The Waves', 'Bos', 'Bb');
-INSERT INTO public.singers VALUES ('Dreams - 2004 Remaster', 'Fleetwood Mac', 'Bos', 'F');
-INSERT INTO public.singers VALUES ('Pink Pony Club', 'Chappell Roan', 'Bos', 'F#');
-INSERT INTO public.singers VALUES ('Ain''t It Fun', 'Paramore', 'Bos', 'A');
-INSERT INTO public.singers VALUES ('The Middle', 'Jimmy Eat World', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('The Middle', 'Jimmy Eat World', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Proud Mary', 'Tina Turner', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Proud Mary', 'Tina Turner', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('Blinding Lights', 'The Weeknd', 'Bos', 'F');
-INSERT INTO public.singers VALUES ('Crazy In Love (feat. JAY-Z)', 'Beyoncé', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('Uptown Funk (feat. Bruno Mars)', 'Mark Ronson', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Uptown Funk (feat. Bruno Mars)', 'Mark Ronson', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('Shut Up and Dance', 'WALK THE MOON', 'Riley', 'C#');
-INSERT INTO public.singers VALUES ('Shut Up and Dance', 'WALK THE MOON', 'Bos', 'C#');
-INSERT INTO public.singers VALUES ('You Are the Best Thing', 'Ray LaMontagne', 'Riley', 'Bb');
-INSERT INTO public.singers VALUES ('You Shook Me All Night Long', 'AC/DC', 'Bos', 'G');
-INSERT INTO public.singers VALUES ('Beat It', 'Michael Jackson', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Ex''s & Oh''s', 'Elle King', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('I''m Gonna Be (500 Miles)', 'The Proclaimers', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('I''m Gonna Be (500 Miles)', 'The Proclaimers', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('I Love Rock ''N Roll', 'Joan Jett & the Blackhearts', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('I Love Rock ''N Roll', 'Joan Jett & the Blackhearts', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Isn''t She Lovely', 'Stevie Wonder', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Valerie (feat. Amy Winehouse) - Version Revisited', 'Mark Ronson', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Valerie (feat. Amy Winehouse) - Version Revisited', 'Mark Ronson', 'Bos', 'Eb');
-INSERT INTO public.singers VALUES ('Smells Like Teen Spirit', 'Nirvana', 'Bos', 'F');
-INSERT INTO public.singers VALUES ('Sugar, We''re Goin Down', 'Fall Out Boy', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Sugar, We''re Goin Down', 'Fall Out Boy', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('Life is a Highway', 'Rascal Flatts', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Life is a Highway', 'Rascal Flatts', 'Bos', 'F');
-INSERT INTO public.singers VALUES ('Wagon Wheel', 'Darius Rucker', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('Wagon Wheel', 'Darius Rucker', 'Jared', 'A');
-INSERT INTO public.singers VALUES ('Wagon Wheel', 'Darius Rucker', 'Bos', 'A');
-INSERT INTO public.singers VALUES ('Don''t Stop Believin''', 'Journey', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Take Me Home, Country Roads', 'John Denver', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('Take Me Home, Country Roads', 'John Denver', 'Jared', 'A');
-INSERT INTO public.singers VALUES ('Take Me Home, Country Roads', 'John Denver', 'Ty', 'A');
-INSERT INTO public.singers VALUES ('All The Small Things', 'blink-182', 'Ty', 'C');
-INSERT INTO public.singers VALUES ('All The Small Things', 'blink-182', 'Riley', 'C');
-INSERT INTO public.singers VALUES ('All The Small Things', 'blink-182', 'Bos', 'C');
-INSERT INTO public.singers VALUES ('All Star', 'Smash Mouth', 'Riley', 'F#');
-INSERT INTO public.singers VALUES ('Stacy''s Mom', 'Fountains Of Wayne', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Stacy''s Mom', 'Fountains Of Wayne', 'Ty', 'E');
-INSERT INTO public.singers VALUES ('Basket Case', 'Green Day', 'Riley', 'Eb');
-INSERT INTO public.singers VALUES ('Basket Case', 'Green Day', 'Bos', 'Eb');
-INSERT INTO public.singers VALUES ('Highway to Hell', 'AC/DC', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('Rock and Roll - Remaster', 'Led Zeppelin', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('My Own Worst Enemy', 'Lit', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('My Own Worst Enemy', 'Lit', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Are You Gonna Be My Girl', 'Jet', 'Riley', 'F#');
-INSERT INTO public.singers VALUES ('Are You Gonna Be My Girl', 'Jet', 'Bos', 'A');
-INSERT INTO public.singers VALUES ('If I Ain''t Got You', 'Alicia Keys', 'Bos', 'G');
-INSERT INTO public.singers VALUES ('The Weight - Remastered 2000', 'The Band', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('Tennessee Whiskey', 'Chris Stapleton', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('Tennessee Whiskey', 'Chris Stapleton', 'Jared', 'A');
-INSERT INTO public.singers VALUES ('Black Horse And The Cherry Tree', 'KT Tunstall', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Forget You', 'CeeLo Green', 'Bos', 'C');
-INSERT INTO public.singers VALUES ('Treasure', 'Bruno Mars', 'Bos', 'C');
-INSERT INTO public.singers VALUES ('You Make My Dreams (Come True)', 'Daryl Hall & John Oates', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('You Make My Dreams (Come True)', 'Daryl Hall & John Oates', 'Bos', 'F');
-INSERT INTO public.singers VALUES ('Sweet Home Alabama', 'Lynyrd Skynyrd', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Sweet Home Alabama', 'Lynyrd Skynyrd', 'Ty', 'D');
-INSERT INTO public.singers VALUES ('Sweet Home Alabama', 'Lynyrd Skynyrd', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('I''m a Believer', 'The Monkees', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('Sweet Child O'' Mine', 'Guns N'' Roses', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('Signed, Sealed, Delivered (I''m Yours)', 'Stevie Wonder', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Signed, Sealed, Delivered (I''m Yours)', 'Stevie Wonder', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('First Date', 'blink-182', 'Riley', 'C');
-INSERT INTO public.singers VALUES ('Footloose - From "Footloose" Soundtrack', 'Kenny Loggins', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('American Girl', 'Tom Petty and the Heartbreakers', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('American Girl', 'Tom Petty and the Heartbreakers', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('American Girl', 'Tom Petty and the Heartbreakers', 'Ty', 'D');
-INSERT INTO public.singers VALUES ('Bad Moon Rising', 'Creedence Clearwater Revival', 'Riley', 'C');
-INSERT INTO public.singers VALUES ('Superstition - Single Version', 'Stevie Wonder', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Superstition - Single Version', 'Stevie Wonder', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Old Time Rock & Roll', 'Bob Seger', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Give Me One Reason', 'Tracy Chapman', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('...Baby One More Time', 'Britney Spears', 'Bos', 'C');
-INSERT INTO public.singers VALUES ('Gimme! Gimme! Gimme! (A Man After Midnight)', 'ABBA', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('Locked out of Heaven', 'Bruno Mars', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('Everybody Talks', 'Neon Trees', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Play That Funky Music', 'Wild Cherry', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Play That Funky Music', 'Wild Cherry', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Starships', 'Nicki Minaj', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('867-5309 / Jenny', 'Tommy Tutone', 'Riley', 'F#');
-INSERT INTO public.singers VALUES ('Free Fallin''', 'Tom Petty', 'Bos', 'G');
-INSERT INTO public.singers VALUES ('Free Fallin''', 'Tom Petty', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('I Believe in a Thing Called Love', 'The Darkness', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Go Your Own Way - 2004 Remaster', 'Fleetwood Mac', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Rebel Yell', 'Billy Idol', 'Riley', 'B');
-INSERT INTO public.singers VALUES ('You Belong With Me', 'Taylor Swift', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('You Belong With Me', 'Taylor Swift', 'Bos', 'F#');
-INSERT INTO public.singers VALUES ('Shake It Off', 'Taylor Swift', 'Bos', 'G');
-INSERT INTO public.singers VALUES ('Seven Nation Army', 'The White Stripes', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Before He Cheats', 'Carrie Underwood', 'Bos', 'F#');
-INSERT INTO public.singers VALUES ('Sk8er Boi', 'Avril Lavigne', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('Learning To Fly', 'Tom Petty and the Heartbreakers', 'Bos', 'C');
-INSERT INTO public.singers VALUES ('Billie Jean', 'Michael Jackson', 'Bos', 'G');
-INSERT INTO public.singers VALUES ('Billie Jean', 'Michael Jackson', 'Riley', 'F#');
-INSERT INTO public.singers VALUES ('Two Princes', 'Spin Doctors', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('I Won''t Back Down', 'Tom Petty', 'Bos', 'G');
-INSERT INTO public.singers VALUES ('Have You Ever Seen The Rain', 'Creedence Clearwater Revival', 'Bos', 'C');
-INSERT INTO public.singers VALUES ('Heartbreaker', 'Pat Benatar', 'Bos', 'F');
-INSERT INTO public.singers VALUES ('Turn The Page - Live', 'Bob Seger', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Landslide', 'Fleetwood Mac', 'Bos', 'Eb');
-INSERT INTO public.singers VALUES ('Barracuda', 'Heart', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Sharp Dressed Man (2008 Remaster)', 'ZZ Top', 'Bos', 'A');
-INSERT INTO public.singers VALUES ('Since U Been Gone', 'Kelly Clarkson', 'Bos', 'G');
-INSERT INTO public.singers VALUES ('Straight Up', 'Paula Abdul', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('Dream On', 'Aerosmith', 'Bos', 'F');
-INSERT INTO public.singers VALUES ('Jolene', 'Dolly Parton', 'Bos', 'C#');
-INSERT INTO public.singers VALUES ('You Oughta Know - 2015 Remaster', 'Alanis Morissette', 'Bos', 'A');
-INSERT INTO public.singers VALUES ('Man in the Box', 'Alice In Chains', 'Bos', 'Eb');
-INSERT INTO public.singers VALUES ('Any Way You Want It', 'Journey', 'Bos', 'G');
-INSERT INTO public.singers VALUES ('Wanted Dead Or Alive', 'Bon Jovi', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('Thunderstruck', 'AC/DC', 'Ty', 'B');
-INSERT INTO public.singers VALUES ('Brown Eyed Girl', 'Van Morrison', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('Brown Eyed Girl', 'Van Morrison', 'Bos', 'G');
-INSERT INTO public.singers VALUES ('I Kissed A Girl', 'Katy Perry', 'Bos', 'A');
-INSERT INTO public.singers VALUES ('Juke Box Hero', 'Foreigner', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Rock You Like A Hurricane', 'Scorpions', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Bad Reputation', 'Joan Jett & the Blackhearts', 'Bos', 'B');
-INSERT INTO public.singers VALUES ('Poker Face', 'Lady Gaga', 'Bos', 'G#');
-INSERT INTO public.singers VALUES ('Fast Car', 'Tracy Chapman', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('Hella Good', 'No Doubt', 'Bos', 'G');
-INSERT INTO public.singers VALUES ('Everlong', 'Foo Fighters', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Peace of Mind', 'Boston', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Dani California', 'Red Hot Chili Peppers', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('Still into You', 'Paramore', 'Bos', 'F');
-INSERT INTO public.singers VALUES ('White Wedding', 'Billy Idol', 'Bos', 'B');
-INSERT INTO public.singers VALUES ('Hurts So Good', 'John Mellencamp', 'Bos', 'A');
-INSERT INTO public.singers VALUES ('Crazy Love', 'Van Morrison', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('Heads Carolina, Tails California', 'Jo Dee Messina', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Bye-Bye', 'Jo Dee Messina', 'Bos', 'Bb');
-INSERT INTO public.singers VALUES ('Hand in My Pocket - 2015 Remaster', 'Alanis Morissette', 'Bos', 'G');
-INSERT INTO public.singers VALUES ('Free Bird', 'Lynyrd Skynyrd', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('Cowboy Casanova', 'Carrie Underwood', 'Bos', 'D');
-INSERT INTO public.singers VALUES ('You''re Still The One', 'Shania Twain', 'Bos', 'Eb');
-INSERT INTO public.singers VALUES ('Man! I Feel Like A Woman!', 'Shania Twain', 'Bos', 'Bb');
-INSERT INTO public.singers VALUES ('Mama''s Broken Heart', 'Miranda Lambert', 'Bos', 'E');
-INSERT INTO public.singers VALUES ('Georgia Peaches', 'Lauren Alaina', 'Bos', 'F#');
-INSERT INTO public.singers VALUES ('Welcome to Paradise', 'Green Day', 'Riley', 'Eb');
-INSERT INTO public.singers VALUES ('Fastest Girl in Town', 'Miranda Lambert', 'Bos', 'F#');
-INSERT INTO public.singers VALUES ('Beyond', 'Leon Bridges', 'Bos', 'B');
-INSERT INTO public.singers VALUES ('Runnin'' Down A Dream', 'Tom Petty', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Remedy', 'The Black Crowes', 'Bos', 'C');
-INSERT INTO public.singers VALUES ('Lonely Boy', 'The Black Keys', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Mary Jane''s Last Dance', 'Tom Petty and the Heartbreakers', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('Take It Easy - 2013 Remaster', 'Eagles', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('I Will Buy You A New Life', 'Everclear', 'Bos', 'G');
-INSERT INTO public.singers VALUES ('Beer Never Broke My Heart', 'Luke Combs', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Beer Never Broke My Heart', 'Luke Combs', 'Jared', 'D');
-INSERT INTO public.singers VALUES ('1, 2 Many', 'Luke Combs', 'Jared', 'F#');
-INSERT INTO public.singers VALUES ('When It Rains It Pours', 'Luke Combs', 'Jared', 'F#');
-INSERT INTO public.singers VALUES ('Whiskey Glasses', 'Morgan Wallen', 'Jared', 'G');
-INSERT INTO public.singers VALUES ('Chicken Fried', 'Zac Brown Band', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('Folsom Prison Blues', 'Johnny Cash', 'Jared', 'F');
-INSERT INTO public.singers VALUES ('I Like It, I Love It', 'Tim McGraw', 'Jared', 'C');
-INSERT INTO public.singers VALUES ('Need A Favor', 'Jelly Roll', 'Bos', 'G#');
-INSERT INTO public.singers VALUES ('Closing Time', 'Semisonic', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('The Anthem', 'Good Charlotte', 'Riley', 'C#');
-INSERT INTO public.singers VALUES ('Friends In Low Places - Live', 'Garth Brooks', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('good 4 u', 'Olivia Rodrigo', 'Bos', 'F#');
-INSERT INTO public.singers VALUES ('Glory Days', 'Bruce Springsteen', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('Wild Night', 'Van Morrison', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('Days Like This', 'Van Morrison', 'Riley', 'Eb');
-INSERT INTO public.singers VALUES ('This Love', 'Maroon 5', 'Riley', 'B');
-INSERT INTO public.singers VALUES ('Santeria', 'Sublime', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Absolutely (Story of a Girl) - Radio Mix', 'Nine Days', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('What I Got', 'Sublime', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('(I Can''t Get No) Satisfaction - Mono', 'The Rolling Stones', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Beverly Hills', 'Weezer', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Semi-Charmed Life', 'Third Eye Blind', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('The Joker', 'Steve Miller Band', 'Riley', 'F');
-INSERT INTO public.singers VALUES ('Build Me Up Buttercup - Mono', 'The Foundations', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Hard To Handle', 'The Black Crowes', 'Riley', 'B');
-INSERT INTO public.singers VALUES ('Beast Of Burden - Remastered 1994', 'The Rolling Stones', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('How Sweet It Is (To Be Loved by You)', 'James Taylor', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('Should I Stay or Should I Go - Remastered', 'The Clash', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('This Is How We Do It', 'Montell Jordan', 'Riley', 'F');
-INSERT INTO public.singers VALUES ('Jumper - 1998 Edit', 'Third Eye Blind', 'Riley', 'F');
-INSERT INTO public.singers VALUES ('Dancing with Myself', 'Generation X', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('No Diggity', 'Blackstreet', 'Riley', 'F#');
-INSERT INTO public.singers VALUES ('Ain''t No Rest for the Wicked', 'Cage The Elephant', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('Rock And Roll All Nite', 'KISS', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('Centerfold', 'The J. Geils Band', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('What''s My Age Again?', 'blink-182', 'Riley', 'B');
-INSERT INTO public.singers VALUES ('Good Riddance (Time of Your Life)', 'Green Day', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('Blitzkrieg Bop - 2016 Remaster', 'Ramones', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('Can''t Help Falling in Love', 'Elvis Presley', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Saturday Night’s Alright (For Fighting) - Remastered 2014', 'Elton John', 'Riley', 'C');
-INSERT INTO public.singers VALUES ('Let''s Get It Started', 'Black Eyed Peas', 'Riley', 'F#');
-INSERT INTO public.singers VALUES ('Shout, Pts. 1 & 2', 'The Isley Brothers', 'Riley', 'F');
-INSERT INTO public.singers VALUES ('Get Down On It', 'Kool & The Gang', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Good Times Bad Times - 1993 Remaster', 'Led Zeppelin', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('You Get What You Give', 'New Radicals', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Fortunate Son', 'Creedence Clearwater Revival', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('Enter Sandman (Remastered)', 'Metallica', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('I Saw Her Standing There - Remastered 2009', 'The Beatles', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Learn to Fly', 'Foo Fighters', 'Riley', 'B');
-INSERT INTO public.singers VALUES ('T.N.T.', 'AC/DC', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Train Kept a Rollin''', 'Aerosmith', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('Fight For Your Right', 'Beastie Boys', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('Crazy Little Thing Called Love - Remastered 2011', 'Queen', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Don''t You (Forget About Me)', 'Simple Minds', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Psycho Killer - 2005 Remaster', 'Talking Heads', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('I Found A Way', 'Drake Bell', 'Riley', 'D');
-INSERT INTO public.singers VALUES ('Dirty Water', 'The Standells', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Paralyzer', 'Finger Eleven', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('She Hates Me', 'Puddle Of Mudd', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('Save a Horse (Ride a Cowboy)', 'Big & Rich', 'Riley', 'E');
-INSERT INTO public.singers VALUES ('When I Come Around', 'Green Day', 'Riley', 'F#');
-INSERT INTO public.singers VALUES ('Rockin'' in the Free World', 'Neil Young', 'Riley', 'G');
-INSERT INTO public.singers VALUES ('Drift Away', 'Uncle Kracker', 'Riley', 'B');
-INSERT INTO public.singers VALUES ('Take Me Home Tonight', 'Eddie Money', 'Riley', 'Bb');
-INSERT INTO public.singers VALUES ('Somebody Told Me', 'The Killers', 'Riley', 'Bb');
-INSERT INTO public.singers VALUES ('Champagne Supernova', 'Oasis', 'Riley', 'A');
-INSERT INTO public.singers VALUES ('Lifestyles of the Rich & Famous', 'Good Charlotte', 'Riley', 'C#');
-INSERT INTO public.singers VALUES ('1999 - 2019 Remaster', 'Prince', 'Riley', 'F');
-INSERT INTO public.singers VALUES ('Gimme Shelter', 'The Rolling Stones', 'Riley', 'C#');
-INSERT INTO public.singers VALUES ('In Too Deep', 'Sum 41', 'Riley', 'D');
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'Bb' FROM public.tracks WHERE name = 'Walking On Sunshine' AND artist = 'Katrina & The Waves';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F' FROM public.tracks WHERE name = 'Dreams - 2004 Remaster' AND artist = 'Fleetwood Mac';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F#' FROM public.tracks WHERE name = 'Pink Pony Club' AND artist = 'Chappell Roan';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'A' FROM public.tracks WHERE name = 'Ain''t It Fun' AND artist = 'Paramore';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'The Middle' AND artist = 'Jimmy Eat World';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'The Middle' AND artist = 'Jimmy Eat World';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Proud Mary' AND artist = 'Tina Turner';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'Proud Mary' AND artist = 'Tina Turner';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F' FROM public.tracks WHERE name = 'Blinding Lights' AND artist = 'The Weeknd';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'Crazy In Love (feat. JAY-Z)' AND artist = 'Beyoncé';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Uptown Funk (feat. Bruno Mars)' AND artist = 'Mark Ronson';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'Uptown Funk (feat. Bruno Mars)' AND artist = 'Mark Ronson';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'C#' FROM public.tracks WHERE name = 'Shut Up and Dance' AND artist = 'WALK THE MOON';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'C#' FROM public.tracks WHERE name = 'Shut Up and Dance' AND artist = 'WALK THE MOON';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'Bb' FROM public.tracks WHERE name = 'You Are the Best Thing' AND artist = 'Ray LaMontagne';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G' FROM public.tracks WHERE name = 'You Shook Me All Night Long' AND artist = 'AC/DC';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Beat It' AND artist = 'Michael Jackson';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Ex''s & Oh''s' AND artist = 'Elle King';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'I''m Gonna Be (500 Miles)' AND artist = 'The Proclaimers';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'I''m Gonna Be (500 Miles)' AND artist = 'The Proclaimers';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'I Love Rock ''N Roll' AND artist = 'Joan Jett & the Blackhearts';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'I Love Rock ''N Roll' AND artist = 'Joan Jett & the Blackhearts';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Isn''t She Lovely' AND artist = 'Stevie Wonder';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Valerie (feat. Amy Winehouse) - Version Revisited' AND artist = 'Mark Ronson';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'Eb' FROM public.tracks WHERE name = 'Valerie (feat. Amy Winehouse) - Version Revisited' AND artist = 'Mark Ronson';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F' FROM public.tracks WHERE name = 'Smells Like Teen Spirit' AND artist = 'Nirvana';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Sugar, We''re Goin Down' AND artist = 'Fall Out Boy';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'Sugar, We''re Goin Down' AND artist = 'Fall Out Boy';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Life is a Highway' AND artist = 'Rascal Flatts';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F' FROM public.tracks WHERE name = 'Life is a Highway' AND artist = 'Rascal Flatts';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Wagon Wheel' AND artist = 'Darius Rucker';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Jared', 'A' FROM public.tracks WHERE name = 'Wagon Wheel' AND artist = 'Darius Rucker';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'A' FROM public.tracks WHERE name = 'Wagon Wheel' AND artist = 'Darius Rucker';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Don''t Stop Believin''' AND artist = 'Journey';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Take Me Home, Country Roads' AND artist = 'John Denver';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Jared', 'A' FROM public.tracks WHERE name = 'Take Me Home, Country Roads' AND artist = 'John Denver';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Ty', 'A' FROM public.tracks WHERE name = 'Take Me Home, Country Roads' AND artist = 'John Denver';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Ty', 'C' FROM public.tracks WHERE name = 'All The Small Things' AND artist = 'blink-182';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'C' FROM public.tracks WHERE name = 'All The Small Things' AND artist = 'blink-182';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'C' FROM public.tracks WHERE name = 'All The Small Things' AND artist = 'blink-182';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'F#' FROM public.tracks WHERE name = 'All Star' AND artist = 'Smash Mouth';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Stacy''s Mom' AND artist = 'Fountains Of Wayne';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Ty', 'E' FROM public.tracks WHERE name = 'Stacy''s Mom' AND artist = 'Fountains Of Wayne';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'Eb' FROM public.tracks WHERE name = 'Basket Case' AND artist = 'Green Day';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'Eb' FROM public.tracks WHERE name = 'Basket Case' AND artist = 'Green Day';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Highway to Hell' AND artist = 'AC/DC';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Rock and Roll - Remaster' AND artist = 'Led Zeppelin';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'My Own Worst Enemy' AND artist = 'Lit';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'My Own Worst Enemy' AND artist = 'Lit';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'F#' FROM public.tracks WHERE name = 'Are You Gonna Be My Girl' AND artist = 'Jet';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'A' FROM public.tracks WHERE name = 'Are You Gonna Be My Girl' AND artist = 'Jet';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G' FROM public.tracks WHERE name = 'If I Ain''t Got You' AND artist = 'Alicia Keys';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'The Weight - Remastered 2000' AND artist = 'The Band';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Tennessee Whiskey' AND artist = 'Chris Stapleton';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Jared', 'A' FROM public.tracks WHERE name = 'Tennessee Whiskey' AND artist = 'Chris Stapleton';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Black Horse And The Cherry Tree' AND artist = 'KT Tunstall';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'C' FROM public.tracks WHERE name = 'Forget You' AND artist = 'CeeLo Green';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'C' FROM public.tracks WHERE name = 'Treasure' AND artist = 'Bruno Mars';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'You Make My Dreams (Come True)' AND artist = 'Daryl Hall & John Oates';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F' FROM public.tracks WHERE name = 'You Make My Dreams (Come True)' AND artist = 'Daryl Hall & John Oates';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Sweet Home Alabama' AND artist = 'Lynyrd Skynyrd';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Ty', 'D' FROM public.tracks WHERE name = 'Sweet Home Alabama' AND artist = 'Lynyrd Skynyrd';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'Sweet Home Alabama' AND artist = 'Lynyrd Skynyrd';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'I''m a Believer' AND artist = 'The Monkees';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'Sweet Child O'' Mine' AND artist = 'Guns N'' Roses';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Signed, Sealed, Delivered (I''m Yours)' AND artist = 'Stevie Wonder';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Signed, Sealed, Delivered (I''m Yours)' AND artist = 'Stevie Wonder';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'C' FROM public.tracks WHERE name = 'First Date' AND artist = 'blink-182';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Footloose - From "Footloose" Soundtrack' AND artist = 'Kenny Loggins';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'American Girl' AND artist = 'Tom Petty and the Heartbreakers';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'American Girl' AND artist = 'Tom Petty and the Heartbreakers';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Ty', 'D' FROM public.tracks WHERE name = 'American Girl' AND artist = 'Tom Petty and the Heartbreakers';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'C' FROM public.tracks WHERE name = 'Bad Moon Rising' AND artist = 'Creedence Clearwater Revival';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Superstition - Single Version' AND artist = 'Stevie Wonder';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Superstition - Single Version' AND artist = 'Stevie Wonder';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Old Time Rock & Roll' AND artist = 'Bob Seger';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Give Me One Reason' AND artist = 'Tracy Chapman';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'C' FROM public.tracks WHERE name = '...Baby One More Time' AND artist = 'Britney Spears';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'Gimme! Gimme! Gimme! (A Man After Midnight)' AND artist = 'ABBA';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'Locked out of Heaven' AND artist = 'Bruno Mars';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Everybody Talks' AND artist = 'Neon Trees';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Play That Funky Music' AND artist = 'Wild Cherry';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Play That Funky Music' AND artist = 'Wild Cherry';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'Starships' AND artist = 'Nicki Minaj';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'F#' FROM public.tracks WHERE name = '867-5309 / Jenny' AND artist = 'Tommy Tutone';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G' FROM public.tracks WHERE name = 'Free Fallin''' AND artist = 'Tom Petty';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Free Fallin''' AND artist = 'Tom Petty';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'I Believe in a Thing Called Love' AND artist = 'The Darkness';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Go Your Own Way - 2004 Remaster' AND artist = 'Fleetwood Mac';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'B' FROM public.tracks WHERE name = 'Rebel Yell' AND artist = 'Billy Idol';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'You Belong With Me' AND artist = 'Taylor Swift';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F#' FROM public.tracks WHERE name = 'You Belong With Me' AND artist = 'Taylor Swift';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G' FROM public.tracks WHERE name = 'Shake It Off' AND artist = 'Taylor Swift';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Seven Nation Army' AND artist = 'The White Stripes';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F#' FROM public.tracks WHERE name = 'Before He Cheats' AND artist = 'Carrie Underwood';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'Sk8er Boi' AND artist = 'Avril Lavigne';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'C' FROM public.tracks WHERE name = 'Learning To Fly' AND artist = 'Tom Petty and the Heartbreakers';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G' FROM public.tracks WHERE name = 'Billie Jean' AND artist = 'Michael Jackson';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'F#' FROM public.tracks WHERE name = 'Billie Jean' AND artist = 'Michael Jackson';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Two Princes' AND artist = 'Spin Doctors';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G' FROM public.tracks WHERE name = 'I Won''t Back Down' AND artist = 'Tom Petty';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'C' FROM public.tracks WHERE name = 'Have You Ever Seen The Rain' AND artist = 'Creedence Clearwater Revival';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F' FROM public.tracks WHERE name = 'Heartbreaker' AND artist = 'Pat Benatar';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Turn The Page - Live' AND artist = 'Bob Seger';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'Eb' FROM public.tracks WHERE name = 'Landslide' AND artist = 'Fleetwood Mac';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Barracuda' AND artist = 'Heart';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'A' FROM public.tracks WHERE name = 'Sharp Dressed Man (2008 Remaster)' AND artist = 'ZZ Top';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G' FROM public.tracks WHERE name = 'Since U Been Gone' AND artist = 'Kelly Clarkson';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'Straight Up' AND artist = 'Paula Abdul';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F' FROM public.tracks WHERE name = 'Dream On' AND artist = 'Aerosmith';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'C#' FROM public.tracks WHERE name = 'Jolene' AND artist = 'Dolly Parton';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'A' FROM public.tracks WHERE name = 'You Oughta Know - 2015 Remaster' AND artist = 'Alanis Morissette';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'Eb' FROM public.tracks WHERE name = 'Man in the Box' AND artist = 'Alice In Chains';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G' FROM public.tracks WHERE name = 'Any Way You Want It' AND artist = 'Journey';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'Wanted Dead Or Alive' AND artist = 'Bon Jovi';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Ty', 'B' FROM public.tracks WHERE name = 'Thunderstruck' AND artist = 'AC/DC';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Brown Eyed Girl' AND artist = 'Van Morrison';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G' FROM public.tracks WHERE name = 'Brown Eyed Girl' AND artist = 'Van Morrison';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'A' FROM public.tracks WHERE name = 'I Kissed A Girl' AND artist = 'Katy Perry';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Juke Box Hero' AND artist = 'Foreigner';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Rock You Like A Hurricane' AND artist = 'Scorpions';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'B' FROM public.tracks WHERE name = 'Bad Reputation' AND artist = 'Joan Jett & the Blackhearts';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G#' FROM public.tracks WHERE name = 'Poker Face' AND artist = 'Lady Gaga';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Fast Car' AND artist = 'Tracy Chapman';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G' FROM public.tracks WHERE name = 'Hella Good' AND artist = 'No Doubt';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Everlong' AND artist = 'Foo Fighters';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Peace of Mind' AND artist = 'Boston';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Dani California' AND artist = 'Red Hot Chili Peppers';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F' FROM public.tracks WHERE name = 'Still into You' AND artist = 'Paramore';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'B' FROM public.tracks WHERE name = 'White Wedding' AND artist = 'Billy Idol';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'A' FROM public.tracks WHERE name = 'Hurts So Good' AND artist = 'John Mellencamp';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Crazy Love' AND artist = 'Van Morrison';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Heads Carolina, Tails California' AND artist = 'Jo Dee Messina';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'Bb' FROM public.tracks WHERE name = 'Bye-Bye' AND artist = 'Jo Dee Messina';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G' FROM public.tracks WHERE name = 'Hand in My Pocket - 2015 Remaster' AND artist = 'Alanis Morissette';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Free Bird' AND artist = 'Lynyrd Skynyrd';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'D' FROM public.tracks WHERE name = 'Cowboy Casanova' AND artist = 'Carrie Underwood';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'Eb' FROM public.tracks WHERE name = 'You''re Still The One' AND artist = 'Shania Twain';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'Bb' FROM public.tracks WHERE name = 'Man! I Feel Like A Woman!' AND artist = 'Shania Twain';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'E' FROM public.tracks WHERE name = 'Mama''s Broken Heart' AND artist = 'Miranda Lambert';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F#' FROM public.tracks WHERE name = 'Georgia Peaches' AND artist = 'Lauren Alaina';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'Eb' FROM public.tracks WHERE name = 'Welcome to Paradise' AND artist = 'Green Day';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F#' FROM public.tracks WHERE name = 'Fastest Girl in Town' AND artist = 'Miranda Lambert';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'B' FROM public.tracks WHERE name = 'Beyond' AND artist = 'Leon Bridges';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Runnin'' Down A Dream' AND artist = 'Tom Petty';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'C' FROM public.tracks WHERE name = 'Remedy' AND artist = 'The Black Crowes';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Lonely Boy' AND artist = 'The Black Keys';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Mary Jane''s Last Dance' AND artist = 'Tom Petty and the Heartbreakers';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Take It Easy - 2013 Remaster' AND artist = 'Eagles';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G' FROM public.tracks WHERE name = 'I Will Buy You A New Life' AND artist = 'Everclear';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Beer Never Broke My Heart' AND artist = 'Luke Combs';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Jared', 'D' FROM public.tracks WHERE name = 'Beer Never Broke My Heart' AND artist = 'Luke Combs';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Jared', 'F#' FROM public.tracks WHERE name = '1, 2 Many' AND artist = 'Luke Combs';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Jared', 'F#' FROM public.tracks WHERE name = 'When It Rains It Pours' AND artist = 'Luke Combs';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Jared', 'G' FROM public.tracks WHERE name = 'Whiskey Glasses' AND artist = 'Morgan Wallen';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Chicken Fried' AND artist = 'Zac Brown Band';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Jared', 'F' FROM public.tracks WHERE name = 'Folsom Prison Blues' AND artist = 'Johnny Cash';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Jared', 'C' FROM public.tracks WHERE name = 'I Like It, I Love It' AND artist = 'Tim McGraw';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'G#' FROM public.tracks WHERE name = 'Need A Favor' AND artist = 'Jelly Roll';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Closing Time' AND artist = 'Semisonic';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'C#' FROM public.tracks WHERE name = 'The Anthem' AND artist = 'Good Charlotte';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Friends In Low Places - Live' AND artist = 'Garth Brooks';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Bos', 'F#' FROM public.tracks WHERE name = 'good 4 u' AND artist = 'Olivia Rodrigo';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Glory Days' AND artist = 'Bruce Springsteen';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Wild Night' AND artist = 'Van Morrison';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'Eb' FROM public.tracks WHERE name = 'Days Like This' AND artist = 'Van Morrison';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'B' FROM public.tracks WHERE name = 'This Love' AND artist = 'Maroon 5';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Santeria' AND artist = 'Sublime';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Absolutely (Story of a Girl) - Radio Mix' AND artist = 'Nine Days';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'What I Got' AND artist = 'Sublime';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = '(I Can''t Get No) Satisfaction - Mono' AND artist = 'The Rolling Stones';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Beverly Hills' AND artist = 'Weezer';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Semi-Charmed Life' AND artist = 'Third Eye Blind';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'F' FROM public.tracks WHERE name = 'The Joker' AND artist = 'Steve Miller Band';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Build Me Up Buttercup - Mono' AND artist = 'The Foundations';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'B' FROM public.tracks WHERE name = 'Hard To Handle' AND artist = 'The Black Crowes';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Beast Of Burden - Remastered 1994' AND artist = 'The Rolling Stones';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'How Sweet It Is (To Be Loved by You)' AND artist = 'James Taylor';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Should I Stay or Should I Go - Remastered' AND artist = 'The Clash';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'F' FROM public.tracks WHERE name = 'This Is How We Do It' AND artist = 'Montell Jordan';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'F' FROM public.tracks WHERE name = 'Jumper - 1998 Edit' AND artist = 'Third Eye Blind';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Dancing with Myself' AND artist = 'Generation X';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'F#' FROM public.tracks WHERE name = 'No Diggity' AND artist = 'Blackstreet';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Ain''t No Rest for the Wicked' AND artist = 'Cage The Elephant';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Rock And Roll All Nite' AND artist = 'KISS';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Centerfold' AND artist = 'The J. Geils Band';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'B' FROM public.tracks WHERE name = 'What''s My Age Again?' AND artist = 'blink-182';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Good Riddance (Time of Your Life)' AND artist = 'Green Day';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Blitzkrieg Bop - 2016 Remaster' AND artist = 'Ramones';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Can''t Help Falling in Love' AND artist = 'Elvis Presley';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'C' FROM public.tracks WHERE name = 'Saturday Night’s Alright (For Fighting) - Remastered 2014' AND artist = 'Elton John';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'F#' FROM public.tracks WHERE name = 'Let''s Get It Started' AND artist = 'Black Eyed Peas';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'F' FROM public.tracks WHERE name = 'Shout, Pts. 1 & 2' AND artist = 'The Isley Brothers';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Get Down On It' AND artist = 'Kool & The Gang';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Good Times Bad Times - 1993 Remaster' AND artist = 'Led Zeppelin';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'You Get What You Give' AND artist = 'New Radicals';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Fortunate Son' AND artist = 'Creedence Clearwater Revival';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Enter Sandman (Remastered)' AND artist = 'Metallica';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'I Saw Her Standing There - Remastered 2009' AND artist = 'The Beatles';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'B' FROM public.tracks WHERE name = 'Learn to Fly' AND artist = 'Foo Fighters';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'T.N.T.' AND artist = 'AC/DC';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Train Kept a Rollin''' AND artist = 'Aerosmith';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Fight For Your Right' AND artist = 'Beastie Boys';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'Crazy Little Thing Called Love - Remastered 2011' AND artist = 'Queen';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Don''t You (Forget About Me)' AND artist = 'Simple Minds';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Psycho Killer - 2005 Remaster' AND artist = 'Talking Heads';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'I Found A Way' AND artist = 'Drake Bell';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Dirty Water' AND artist = 'The Standells';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Paralyzer' AND artist = 'Finger Eleven';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'She Hates Me' AND artist = 'Puddle Of Mudd';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'E' FROM public.tracks WHERE name = 'Save a Horse (Ride a Cowboy)' AND artist = 'Big & Rich';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'F#' FROM public.tracks WHERE name = 'When I Come Around' AND artist = 'Green Day';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'G' FROM public.tracks WHERE name = 'Rockin'' in the Free World' AND artist = 'Neil Young';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'B' FROM public.tracks WHERE name = 'Drift Away' AND artist = 'Uncle Kracker';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'Bb' FROM public.tracks WHERE name = 'Take Me Home Tonight' AND artist = 'Eddie Money';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'Bb' FROM public.tracks WHERE name = 'Somebody Told Me' AND artist = 'The Killers';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'A' FROM public.tracks WHERE name = 'Champagne Supernova' AND artist = 'Oasis';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'C#' FROM public.tracks WHERE name = 'Lifestyles of the Rich & Famous' AND artist = 'Good Charlotte';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'F' FROM public.tracks WHERE name = '1999 - 2019 Remaster' AND artist = 'Prince';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'C#' FROM public.tracks WHERE name = 'Gimme Shelter' AND artist = 'The Rolling Stones';
+INSERT INTO public.singers (track_id, singer, key) SELECT id, 'Riley', 'D' FROM public.tracks WHERE name = 'In Too Deep' AND artist = 'Sum 41';
 
 
 --
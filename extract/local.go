@@ -0,0 +1,95 @@
+package extract
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dhowden/tag"
+)
+
+var AudioExtensions = []string{".mp3", ".flac", ".wav"}
+
+type LocalAudioData struct {
+	Path   string
+	Name   string
+	Artist string
+	Genres []string
+	Year   string
+}
+
+func IsAudioFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, audioExt := range AudioExtensions {
+		if ext == audioExt {
+			return true
+		}
+	}
+	return false
+}
+
+func FindAudioFiles(dir string) ([]string, error) {
+	var files []string
+	walkErr := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !IsAudioFile(path) {
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	if walkErr != nil {
+		return nil, fmt.Errorf("unable to walk directory %s: %v", dir, walkErr)
+	}
+	return files, nil
+}
+
+// ReadLocalAudioTags reads ID3/Vorbis tags from an audio file. Files without tags (most WAVs)
+// fall back to an "Artist - Title" file name.
+func ReadLocalAudioTags(path string) (LocalAudioData, error) {
+	data := LocalAudioData{Path: path}
+	file, openErr := os.Open(path)
+	if openErr != nil {
+		return data, openErr
+	}
+	defer file.Close()
+
+	metadata, tagErr := tag.ReadFrom(file)
+	if tagErr != nil && !errors.Is(tagErr, tag.ErrNoTagsFound) {
+		return data, fmt.Errorf("unable to read tags: %v", tagErr)
+	}
+	if tagErr == nil {
+		data.Name = strings.TrimSpace(metadata.Title())
+		data.Artist = strings.TrimSpace(metadata.Artist())
+		if metadata.Year() != 0 {
+			data.Year = strconv.Itoa(metadata.Year())
+		}
+		for _, genre := range strings.Split(metadata.Genre(), ",") {
+			genre = strings.TrimSpace(strings.ToLower(genre))
+			if genre != "" {
+				data.Genres = append(data.Genres, genre)
+			}
+		}
+	}
+
+	if data.Name == "" || data.Artist == "" {
+		base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		artist, name, found := strings.Cut(base, " - ")
+		if !found {
+			return data, fmt.Errorf("no title/artist tags and file name is not in 'Artist - Title' format")
+		}
+		if data.Artist == "" {
+			data.Artist = strings.TrimSpace(artist)
+		}
+		if data.Name == "" {
+			data.Name = strings.TrimSpace(name)
+		}
+	}
+	return data, nil
+}
@@ -31,6 +31,8 @@ type Extractor struct {
 }
 
 type SpotdlData struct {
+	SongID            string   `json:"song_id"`
+	ISRC              string   `json:"isrc"`
 	Name              string   `json:"name"`
 	Artist            string   `json:"artist"`
 	Genres            []string `json:"genres"`
@@ -117,11 +119,15 @@ func DownloadAllTracks(e *Extractor, tracks *[]SpotdlData) error {
 	downloadFail := 0
 	keyFail := 0
 	for _, track := range *tracks {
-		params := database.GetTrackParams{
-			Name:   track.Name,
-			Artist: track.Artist,
+		// a song renamed in the library is still the same Spotify track
+		_, getErr := e.Config.DB.GetTrackBySpotifyID(context.Background(), nullString(track.SongID))
+		if getErr == sql.ErrNoRows {
+			params := database.GetTrackParams{
+				Name:   track.Name,
+				Artist: track.Artist,
+			}
+			_, getErr = e.Config.DB.GetTrack(context.Background(), params)
 		}
-		_, getErr := e.Config.DB.GetTrack(context.Background(), params)
 		if getErr == sql.ErrNoRows {
 			wg.Add(1)
 
@@ -143,6 +149,8 @@ func DownloadAllTracks(e *Extractor, tracks *[]SpotdlData) error {
 					keyFail += 1
 				}
 				trackParams := database.CreateTrackParams{
+					SpotifyID:         nullString(track.SongID),
+					Isrc:              nullString(track.ISRC),
 					Name:              track.Name,
 					Artist:            track.Artist,
 					Genre:             track.Genres,
@@ -154,15 +162,7 @@ func DownloadAllTracks(e *Extractor, tracks *[]SpotdlData) error {
 				}
 				createErr := e.Config.DB.CreateTrack(context.Background(), trackParams)
 				if createErr != nil {
-					deleteparams := database.DeleteTrackParams{
-						Name:   track.Name,
-						Artist: track.Artist,
-					}
-					fmt.Printf("error saving track to database, deleting track info...: %v\n", createErr)
-					deleteErr := e.Config.DB.DeleteTrack(context.Background(), deleteparams)
-					if deleteErr != nil {
-						fmt.Printf("unable to delete track from database: %v\n", deleteErr)
-					}
+					fmt.Printf("error saving track %s - %s to database: %v\n", track.Artist, track.Name, createErr)
 				}
 			}(track)
 		} else if getErr == nil {
@@ -245,3 +245,7 @@ func (e *Extractor) ReadSpotdlData() (*[]SpotdlData, error) {
 	}
 	return &Tracks, nil
 }
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
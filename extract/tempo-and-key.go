@@ -7,19 +7,25 @@ import (
 )
 
 func ExtractTempoAndKey(filename string) (string, int, error) {
+	key, bpm, _, err := ExtractTempoKeyAndDuration(filename)
+	return key, bpm, err
+}
+
+func ExtractTempoKeyAndDuration(filename string) (string, int, int, error) {
 	cmd := exec.Command("python3", "extract/tempo-and-key.py", filename)
 	cmd.Stderr = nil
 	output, err := cmd.Output()
 	if err != nil {
-		return "", 0, fmt.Errorf("error running Python script: %w - output: %s", err, string(output))
+		return "", 0, 0, fmt.Errorf("error running Python script: %w - output: %s", err, string(output))
 	}
 	var result struct {
-		Key string `json:"key"`
-		BPM int    `json:"bpm"`
+		Key      string `json:"key"`
+		BPM      int    `json:"bpm"`
+		Duration int    `json:"duration"`
 	}
 	if err := json.Unmarshal(output, &result); err != nil {
-		return "", 0, fmt.Errorf("error parsing JSON: %w", err)
+		return "", 0, 0, fmt.Errorf("error parsing JSON: %w", err)
 	}
 
-	return result.Key, result.BPM, nil
+	return result.Key, result.BPM, result.Duration, nil
 }
@@ -31,6 +31,7 @@ bpm, _, _, _, _ = rhythm_extractor(audio_segment)
 
 result = {
     "key": key,
-    "bpm": round(bpm)
+    "bpm": round(bpm),
+    "duration": round(len(audio) / sample_rate)
 }
 print(json.dumps(result))
\ No newline at end of file
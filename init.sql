@@ -1,4 +1,7 @@
 CREATE TABLE tracks (
+    id SERIAL PRIMARY KEY,
+    spotify_id TEXT,
+    isrc TEXT,
     name TEXT NOT NULL,
     artist TEXT NOT NULL,
     genre TEXT[],
@@ -7,10 +10,16 @@ CREATE TABLE tracks (
     explicit BOOL NOT NULL DEFAULT false,
     bpm INT NOT NULL DEFAULT 0,
     original_key TEXT NOT NULL DEFAULT '',
-    CONSTRAINT PK_name_artist PRIMARY KEY(name,artist)
+    melody_low TEXT NOT NULL DEFAULT '',
+    melody_high TEXT NOT NULL DEFAULT '',
+    clean_version BOOL NOT NULL DEFAULT false,
+    CONSTRAINT UQ_tracks_name_artist UNIQUE(name, artist),
+    CONSTRAINT UQ_tracks_spotify_id UNIQUE(spotify_id),
+    CONSTRAINT UQ_tracks_isrc UNIQUE(isrc)
 );
 
 CREATE TABLE working (
+    track_id INT NOT NULL,
     name TEXT NOT NULL,
     artist TEXT NOT NULL,
     genre TEXT[],
@@ -21,16 +30,126 @@ CREATE TABLE working (
     original_key TEXT NOT NULL,
     singer TEXT,
     singer_key TEXT,
-    CONSTRAINT PK_working PRIMARY KEY(name,artist)
+    clean_version BOOL NOT NULL DEFAULT false,
+    CONSTRAINT PK_working PRIMARY KEY(track_id),
+    CONSTRAINT FK_working_tracks FOREIGN KEY (track_id)
+        REFERENCES tracks(id)
+        ON DELETE CASCADE
 );
 
 CREATE TABLE singers (
-    song TEXT NOT NULL,
-    artist TEXT NOT NULL,
+    track_id INT NOT NULL,
     singer TEXT NOT NULL,
     key TEXT NOT NULL,
-    CONSTRAINT PK_singers PRIMARY KEY(song, artist, singer),
-    CONSTRAINT FK_singers_tracks FOREIGN KEY (song, artist)
-        REFERENCES tracks(name, artist)
+    CONSTRAINT PK_singers PRIMARY KEY(track_id, singer),
+    CONSTRAINT FK_singers_tracks FOREIGN KEY (track_id)
+        REFERENCES tracks(id)
+        ON DELETE CASCADE
+);
+
+CREATE TABLE singer_parts (
+    track_id INT NOT NULL,
+    lead TEXT NOT NULL,
+    singer TEXT NOT NULL,
+    role TEXT NOT NULL DEFAULT 'duet',
+    CONSTRAINT PK_singer_parts PRIMARY KEY(track_id, lead, singer),
+    CONSTRAINT FK_singer_parts_singers FOREIGN KEY (track_id, lead)
+        REFERENCES singers(track_id, singer)
         ON DELETE CASCADE
-);
\ No newline at end of file
+);
+
+CREATE TABLE track_requirements (
+    track_id INT NOT NULL,
+    instrument TEXT NOT NULL,
+    CONSTRAINT PK_track_requirements PRIMARY KEY(track_id, instrument),
+    CONSTRAINT FK_track_requirements_tracks FOREIGN KEY (track_id)
+        REFERENCES tracks(id)
+        ON DELETE CASCADE
+);
+
+CREATE TABLE singer_ranges (
+    singer TEXT NOT NULL,
+    low_note TEXT NOT NULL,
+    high_note TEXT NOT NULL,
+    CONSTRAINT PK_singer_ranges PRIMARY KEY(singer)
+);
+
+CREATE TABLE setlists (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    data JSONB NOT NULL
+);
+
+CREATE TABLE build_traces (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    setlist JSONB NOT NULL,
+    trace JSONB NOT NULL
+);
+
+CREATE TABLE build_history (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    setlist JSONB NOT NULL
+);
+
+CREATE TABLE genre_buckets (
+    genre TEXT PRIMARY KEY,
+    bucket TEXT NOT NULL
+);
+
+INSERT INTO genre_buckets (genre, bucket) VALUES
+    ('pop', 'pop'),
+    ('dance pop', 'pop'),
+    ('post-teen pop', 'pop'),
+    ('rock', 'rock'),
+    ('metal', 'rock'),
+    ('punk', 'rock'),
+    ('country', 'country'),
+    ('hip hop', 'hip hop'),
+    ('rap', 'hip hop'),
+    ('r&b', 'r&b'),
+    ('soul', 'r&b'),
+    ('motown', 'r&b'),
+    ('funk', 'funk'),
+    ('disco', 'disco'),
+    ('dance', 'dance'),
+    ('edm', 'dance'),
+    ('house', 'dance'),
+    ('latin', 'latin'),
+    ('reggaeton', 'latin'),
+    ('folk', 'folk'),
+    ('jazz', 'jazz'),
+    ('blues', 'blues');
+
+CREATE TABLE profiles (
+    name TEXT PRIMARY KEY,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    settings JSONB NOT NULL
+);
+
+INSERT INTO profiles (name, settings) VALUES
+    ('bar', '{"description": "Bar gigs: explicit lyrics allowed, in fewer and longer sets", "allow_explicit": true, "sets": 2}'),
+    ('corporate', '{"description": "Corporate events: always clean", "allow_explicit": false}'),
+    ('wedding', '{"description": "Weddings: clean, with requests every other song and no genre twice in a row", "allow_explicit": false, "request_spacing": 1, "mix": {"no_repeat_genre": true}}');
+
+-- keeps ./setlist migrate in step with this file, add a row here with every new migration
+CREATE TABLE schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO schema_migrations (version, name) VALUES
+    (1, 'tracks'),
+    (2, 'vocal_ranges'),
+    (3, 'setlists'),
+    (4, 'build_traces'),
+    (5, 'track_ids'),
+    (6, 'build_history'),
+    (7, 'singer_parts'),
+    (8, 'track_requirements'),
+    (9, 'genre_buckets'),
+    (10, 'profiles'),
+    (11, 'clean_versions');
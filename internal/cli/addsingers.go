@@ -8,7 +8,6 @@ import (
 	"log"
 	"os"
 	"strings"
-	"unicode"
 
 	"github.com/rjfeeney/setlist_builder/internal/constants"
 	"github.com/rjfeeney/setlist_builder/internal/database"
@@ -33,12 +32,7 @@ func ValidateKey(keyInput string) bool {
 }
 
 func Capitalize(s string) string {
-	if s == "" {
-		return s
-	}
-	runes := []rune(s)
-	runes[0] = unicode.ToUpper(runes[0])
-	return string(runes)
+	return constants.Capitalize(s)
 }
 func InvalidSingerMessage() {
 	fmt.Println("")
@@ -61,11 +55,7 @@ func RunAddSingers(db *sql.DB) error {
 	fmt.Println("")
 	numberofTracks := len(tracks)
 	for i, track := range tracks {
-		checkParams := database.CheckSingersParams{
-			Song:   track.Name,
-			Artist: track.Artist,
-		}
-		check, _ := dbQueries.CheckSingers(context.Background(), checkParams)
+		check, _ := dbQueries.CheckSingers(context.Background(), track.ID)
 		if !check {
 			continue
 		}
@@ -94,13 +84,26 @@ func RunAddSingers(db *sql.DB) error {
 					fmt.Println("")
 					continue
 				}
+				singerInput = Capitalize(singerInput)
+				suggestion, suggested := suggestKeyForSinger(dbQueries, track, singerInput)
+				if suggested {
+					fmt.Println("")
+					printSuggestion(singerInput, suggestion)
+				}
 				for {
-					singerInput = Capitalize(singerInput)
 					fmt.Println("")
-					fmt.Printf("Please enter the key that %s sings %s by %s in (leaving blank will keep the song in its original key of %s):", singerInput, track.Name, track.Artist, track.OriginalKey)
+					if suggested {
+						fmt.Printf("Please enter the key that %s sings %s by %s in (leaving blank will use the suggested key of %s):", singerInput, track.Name, track.Artist, suggestion.Key)
+					} else {
+						fmt.Printf("Please enter the key that %s sings %s by %s in (leaving blank will keep the song in its original key of %s):", singerInput, track.Name, track.Artist, track.OriginalKey)
+					}
 					keyInput, _ = reader.ReadString('\n')
 					keyInput = strings.TrimSpace(strings.ToLower(keyInput))
-					if keyInput == "" {
+					if keyInput == "" && suggested {
+						fmt.Println("")
+						fmt.Printf("No key specified, using suggested key of %s", suggestion.Key)
+						keyInput = suggestion.Key
+					} else if keyInput == "" {
 						fmt.Println("")
 						fmt.Printf("No key specified, defaulting to original key of %s", track.OriginalKey)
 						keyInput = track.OriginalKey
@@ -125,10 +128,9 @@ func RunAddSingers(db *sql.DB) error {
 				fmt.Printf("Singer - %s\n", singerInput)
 				fmt.Printf("Key - %s\n", keyInput)
 				params := database.AddToSingersParams{
-					Song:   track.Name,
-					Artist: track.Artist,
-					Singer: singerInput,
-					Key:    keyInput,
+					TrackID: track.ID,
+					Singer:  singerInput,
+					Key:     keyInput,
 				}
 				addSingerErr := dbQueries.AddToSingers(context.Background(), params)
 				if addSingerErr != nil {
@@ -4,18 +4,23 @@ import (
 	"bufio"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
-	"math/rand"
 	"os"
 	"strconv"
 	"strings"
 
-	"github.com/rjfeeney/setlist_builder/extract"
 	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+	"github.com/rjfeeney/setlist_builder/internal/sources"
 )
 
-func RunBuildQuestions(db *sql.DB) (requestsList, dnpList, singers []string, duration, requestNum int32, explicit bool, err error) {
+// RunBuildQuestions asks for everything a build needs and returns it as build parameters.
+// Questions the profile answers are skipped and the rest of it is applied to the parameters;
+// profile can be nil.
+func RunBuildQuestions(db *sql.DB, profile *service.Profile) (service.BuildParams, error) {
 	clearErr := RunClear(db, "working")
 	fmt.Println("")
 	if clearErr != nil {
@@ -23,19 +28,23 @@ func RunBuildQuestions(db *sql.DB) (requestsList, dnpList, singers []string, dur
 	}
 
 	dbQueries := database.New(db)
-	var requestsTempDir string
-	var dnpTempDir string
-	var explicitOffBool bool
-	doNotPlays := []string{}
-	requests := []string{}
+	var explicitPolicy string
+	var duration int32
+	params := service.BuildParams{Requests: []string{}, DoNotPlays: []string{}}
 	singerList := []string{}
 	reader := bufio.NewReader(os.Stdin)
 
 	//Duration
 	for {
-		fmt.Print("Enter set duration in minutes: ")
-		durationInput, _ := reader.ReadString('\n')
-		durationInput = strings.TrimSpace(durationInput)
+		var durationInput string
+		if profile != nil && profile.Duration > 0 {
+			durationInput = strconv.Itoa(int(profile.Duration))
+			fmt.Printf("Using the %d minute duration from the %s profile\n", profile.Duration, profile.Name)
+		} else {
+			fmt.Print("Enter set duration in minutes: ")
+			durationInput, _ = reader.ReadString('\n')
+			durationInput = strings.TrimSpace(durationInput)
+		}
 
 		d, err := strconv.Atoi(durationInput)
 		if err != nil {
@@ -43,20 +52,16 @@ func RunBuildQuestions(db *sql.DB) (requestsList, dnpList, singers []string, dur
 			continue
 		}
 		duration = int32(d)
-		tracks, tracksErr := dbQueries.GetAllTracks(context.Background())
-		if tracksErr != nil {
-			log.Fatalf("failed to get all tracks: %v", tracksErr)
-		}
-		maxDuration := 0
-		for _, track := range tracks {
-			maxDuration += int(track.DurationInSeconds)
+		maxDuration, libraryErr := service.LibraryDurationSeconds(context.Background(), dbQueries)
+		if libraryErr != nil {
+			log.Fatal(libraryErr)
 		}
-		if duration > 180 {
-			fmt.Println("Maximum duration per the band's contract is 3 hours (180) minutes including breaks.")
-			fmt.Println("Duration will be set to the 180 minutes for this setlist.")
-			duration = 180
+		if duration > service.MaxDuration {
+			fmt.Printf("Maximum duration per the band's contract is 3 hours (%d) minutes including breaks.\n", service.MaxDuration)
+			fmt.Printf("Duration will be set to the %d minutes for this setlist.\n", service.MaxDuration)
+			duration = service.MaxDuration
 		}
-		if maxDuration < int(duration) {
+		if maxDuration < int(duration)*60 {
 			log.Fatalf("Warning: set duration exceeds total duration of all songs in database, please add more songs before attempting to build a setlist this long")
 		}
 		fmt.Printf("Duration set to %d minutes\n", duration)
@@ -70,9 +75,14 @@ func RunBuildQuestions(db *sql.DB) (requestsList, dnpList, singers []string, dur
 		fmt.Printf("error counting singers: %v\n", countErr)
 		fmt.Println("Proceeding...")
 	}
-	if singerCount == 0 && countErr == nil {
+	if profile != nil && len(profile.Singers) > 0 {
+		for _, singer := range profile.Singers {
+			singerList = append(singerList, strings.ToLower(singer))
+		}
+		fmt.Printf("Using singers from the %s profile: %s\n", profile.Name, strings.Join(profile.Singers, ", "))
+	} else if singerCount == 0 && countErr == nil {
 		fmt.Println("No singers in database, please use the 'singers' command to add singers to the database")
-		return nil, nil, nil, 0, 0, false, nil
+		return params, nil
 	} else if singerCount == 1 && countErr == nil {
 		singerList, _ = dbQueries.GetSingers(context.Background())
 		fmt.Println("Only one singer in database, repeat singer rule will be ignored")
@@ -124,170 +134,75 @@ func RunBuildQuestions(db *sql.DB) (requestsList, dnpList, singers []string, dur
 	}
 
 	//Explicit
-	for {
+	if profile != nil && profile.Explicit() != "" {
+		explicitPolicy = profile.Explicit()
+		switch explicitPolicy {
+		case service.ExplicitAllow:
+			fmt.Printf("The %s profile allows explicit lyrics\n", profile.Name)
+		case service.ExplicitClean:
+			fmt.Printf("The %s profile is clean, explicit songs will only be included as clean edits\n", profile.Name)
+		default:
+			fmt.Printf("The %s profile is clean, explicit lyrics won't be included\n", profile.Name)
+		}
+		fmt.Println("")
+	}
+	for explicitPolicy == "" {
 		fmt.Println("")
-		fmt.Print("Are you okay including songs that may have explicit lyrics? (Y/N): ")
+		fmt.Print("Are you okay including songs that may have explicit lyrics? (Y/N, or C for clean edits only): ")
 		explicitRsp, _ := reader.ReadString('\n')
 		explicitRsp = strings.TrimSpace(explicitRsp)
 		explicitRsp = strings.ToLower(explicitRsp)
 		if explicitRsp == "y" {
 			fmt.Println("Allowing explicit lyrics...")
-			explicitOffBool = false
+			explicitPolicy = service.ExplicitAllow
+		} else if explicitRsp == "c" {
+			fmt.Println("Note: explicit songs will only be included if the band has a clean version, and will be marked to be performed with the clean edit")
+			explicitPolicy = service.ExplicitClean
 		} else if explicitRsp == "n" {
 			fmt.Println("Note: songs in your request list will not be added to the setlist if they have explicit lyrics")
-			explicitOffBool = true
+			explicitPolicy = service.ExplicitExclude
 		} else {
 			fmt.Println("Invalid response, please try again")
 			continue
 		}
 		fmt.Println("")
-		break
 	}
 
 	//Requests
-	for {
-		fmt.Print("If you have a Spotify requests playlist made, please paste the link here, otherwise just hit enter:\n")
-		requestsInput, _ := reader.ReadString('\n')
-		requestsInput = strings.TrimSpace(requestsInput)
-		if requestsInput == "" {
-			fmt.Println("No 'Requests' playlist specified, continuing...")
-			fmt.Println("")
-			break
-		} else if !strings.Contains(requestsInput, "open.spotify.com/playlist") {
-			fmt.Println("Invalid, please enter a valid Spotify playlist")
-			continue
-		} else {
-			wd, _ := os.Getwd()
-			requestsTempDir, _ = os.MkdirTemp(wd, "requests")
-			defer os.RemoveAll(requestsTempDir)
-			config := extract.SpotifyConfig{
-				ClientID:     os.Getenv("SPOTIFY_ID"),
-				ClientSecret: os.Getenv("SPOTIFY_SECRET"),
-				TempDir:      requestsTempDir,
-				PlaylistURL:  strings.Split(requestsInput, "?")[0],
-				DB:           dbQueries,
-			}
-
-			extractor := extract.NewExtractor(config)
-
-			if err := extractor.ExtractMetaDataSpotdl(); err != nil {
-				return nil, nil, nil, 0, 0, false, err
-			}
-
-			tracks, err := extractor.ReadSpotdlData()
-			if err != nil {
-				return nil, nil, nil, 0, 0, false, err
-			}
-			for _, track := range *tracks {
-				requestAlreadyAdded := false
-				for _, request := range requests {
-					if track.Name == request {
-						fmt.Printf("Request %s has already been added to the requests list, skipping to next request...\n", track.Name)
-						fmt.Println("")
-						requestAlreadyAdded = true
-						break
-					}
-				}
-				if requestAlreadyAdded {
-					continue
-				}
-				params := database.GetTrackParams{
-					Name:   track.Name,
-					Artist: track.Artist,
-				}
-				_, requestCheckErr := dbQueries.GetTrack(context.Background(), params)
-				if requestCheckErr == sql.ErrNoRows {
-					fmt.Printf("Song %s was not found in the database, meaning it is not one of the songs that the band is able to perform.\nSkipping to next request...\n", track.Name)
-					fmt.Println("")
-					continue
-				} else if requestCheckErr != nil {
-					fmt.Println("Unable to find track due to error, skipping to next request...")
-					fmt.Println("")
-					continue
-				}
-				if explicitOffBool && track.Explicit {
-					fmt.Printf("Request %s has explicit lyrics, and the 'No Explicit Lyrics' rule has been turned on, skipping to next request...\n", track.Name)
-					fmt.Println("")
-					continue
-				}
-				comboParams := database.GetSingerCombosParams{
-					Song:    track.Name,
-					Artist:  track.Artist,
-					Column3: capitalizedSingerList,
-				}
-				combos, combosErr := dbQueries.GetSingerCombos(context.Background(), comboParams)
-				if combosErr != nil {
-					fmt.Printf("unable to get singer/key combo for %s: %v, skipping to next request...\n", track.Name, combosErr)
-					fmt.Println("")
-					continue
-				}
-				atLeastOneSinger := false
-				for _, combo := range combos {
-					for _, singer := range capitalizedSingerList {
-						if combo.Singer == singer {
-							atLeastOneSinger = true
-							break
-						}
-					}
-					if atLeastOneSinger {
-						break
-					}
-				}
-				if !atLeastOneSinger {
-					fmt.Printf("No valid singers found for track %s, skipping...\n", track.Name)
-					fmt.Println("")
-					continue
-				}
-				requests = append(requests, track.Name)
-			}
-		}
-		break
+	requestCandidates, requestsErr := readRequestSource(reader, "request")
+	if requestsErr != nil {
+		return params, requestsErr
+	}
+	if requestCandidates == nil {
+		fmt.Println("No 'Requests' list specified, continuing...")
+		fmt.Println("")
+	}
+	resolvedRequests, skippedRequests := service.ResolveRequests(context.Background(), dbQueries, requestCandidates, capitalizedSingerList, explicitPolicy)
+	for _, skipped := range skippedRequests {
+		fmt.Printf("%s, skipping to next request...\n", skipped)
+		fmt.Println("")
+	}
+	for _, track := range resolvedRequests {
+		params.AddRequest(track.Name, track.ID)
 	}
 
 	//Do Not Plays
-	for {
-		fmt.Print("If you have a Spotify 'Do Not Play' playlist made, please paste the link here, otherwise just hit enter:\n")
-		dnpInput, _ := reader.ReadString('\n')
-		dnpInput = strings.TrimSpace(dnpInput)
-		if dnpInput == "" {
-			fmt.Println("No 'Do Not Play' playlist specified")
-			fmt.Println("")
-			break
-		} else if !strings.Contains(dnpInput, "open.spotify.com/playlist") {
-			fmt.Println("Invalid, please enter a valid Spotify playlist")
-			continue
-		} else {
-			wd, _ := os.Getwd()
-			dnpTempDir, _ = os.MkdirTemp(wd, "donotplays")
-			defer os.RemoveAll(dnpTempDir)
-			config := extract.SpotifyConfig{
-				ClientID:     os.Getenv("SPOTIFY_ID"),
-				ClientSecret: os.Getenv("SPOTIFY_SECRET"),
-				TempDir:      dnpTempDir,
-				PlaylistURL:  strings.Split(dnpInput, "?")[0],
-				DB:           nil,
-			}
-
-			extractor := extract.NewExtractor(config)
-
-			if err := extractor.ExtractMetaDataSpotdl(); err != nil {
-				return nil, nil, nil, 0, 0, false, err
-			}
-
-			tracks, err := extractor.ReadSpotdlData()
-			if err != nil {
-				return nil, nil, nil, 0, 0, false, err
-			}
-			for _, track := range *tracks {
-				doNotPlays = append(doNotPlays, track.Name)
-			}
-		}
-		break
+	dnpCandidates, dnpErr := readRequestSource(reader, "'Do Not Play'")
+	if dnpErr != nil {
+		return params, dnpErr
+	}
+	if dnpCandidates == nil {
+		fmt.Println("No 'Do Not Play' list specified")
+		fmt.Println("")
+	}
+	doNotPlays, doNotPlayIDs := service.ResolveDoNotPlays(context.Background(), dbQueries, dnpCandidates)
+	for i, dnp := range doNotPlays {
+		params.AddDoNotPlay(dnp, doNotPlayIDs[i])
 	}
 
 	//Crosscheck Requests and DNPs
 	fmt.Println("Checking Requests and DNPs for contradictions...")
-	contradictions := compareLists(requests, doNotPlays)
+	contradictions := params.Contradictions()
 	contradictionsLength := len(contradictions)
 	fmt.Printf("Contradicitons found: %d\n", contradictionsLength)
 	for i, contradiction := range contradictions {
@@ -300,12 +215,12 @@ func RunBuildQuestions(db *sql.DB) (requestsList, dnpList, singers []string, dur
 			if includeRsp == "y" {
 				fmt.Println("Song will be included in 'Requests' list")
 				fmt.Println("")
-				removeFromList(contradiction, &doNotPlays)
+				params.RemoveDoNotPlay(contradiction)
 				break
 			} else if includeRsp == "n" {
 				fmt.Println("Song will be included in 'Do Not Play' list")
 				fmt.Println("")
-				removeFromList(contradiction, &requests)
+				params.RemoveRequest(contradiction)
 				break
 			} else {
 				fmt.Println("Invalid response, please try again")
@@ -313,10 +228,12 @@ func RunBuildQuestions(db *sql.DB) (requestsList, dnpList, singers []string, dur
 			}
 		}
 	}
-	numRequests := len(requests)
 
 	//Confirmation
 	fmt.Println("You have selected the following parameters:")
+	if profile != nil {
+		fmt.Printf("Profile: %s\n", profile.Name)
+	}
 	fmt.Printf("Duration: %d minutes\n", duration)
 	fmt.Println("")
 	fmt.Println("Singers:")
@@ -325,30 +242,33 @@ func RunBuildQuestions(db *sql.DB) (requestsList, dnpList, singers []string, dur
 	}
 	fmt.Println("")
 	fmt.Print("Requests: ")
-	if len(requests) == 0 {
+	if len(params.Requests) == 0 {
 		fmt.Println("None")
 		fmt.Println("")
 	} else {
 		fmt.Println("")
-		for i, song := range requests {
+		for i, song := range params.Requests {
 			fmt.Printf("%d - %s\n", i+1, song)
 		}
 		fmt.Println("")
 	}
 	fmt.Print("Do Not Plays: ")
-	if len(doNotPlays) == 0 {
+	if len(params.DoNotPlays) == 0 {
 		fmt.Println("None")
 	} else {
 		fmt.Println("")
-		for i, dnp := range doNotPlays {
+		for i, dnp := range params.DoNotPlays {
 			fmt.Printf("%d - %s\n", i+1, dnp)
 		}
 	}
 	fmt.Println("")
 	fmt.Print("Explicit lyrics allowed?: ")
-	if !explicitOffBool {
+	switch explicitPolicy {
+	case service.ExplicitAllow:
 		fmt.Println("Yes")
-	} else {
+	case service.ExplicitClean:
+		fmt.Println("Clean edits only")
+	default:
 		fmt.Println("No")
 	}
 	fmt.Println("")
@@ -362,12 +282,16 @@ func RunBuildQuestions(db *sql.DB) (requestsList, dnpList, singers []string, dur
 		if confirmation == "y" {
 			//check if 2 singers can have balanced setlist or not
 			fmt.Println("Beginning build...")
-			return requests, doNotPlays, capitalizedSingerList, duration, int32(numRequests), explicitOffBool, nil
+			params.Singers = capitalizedSingerList
+			params.Duration = duration
+			params.SetExplicitPolicy(explicitPolicy)
+			if profile != nil {
+				profile.Apply(&params)
+			}
+			return params, nil
 		} else if confirmation == "restart" {
 			fmt.Println("Restarting...")
-			os.RemoveAll(requestsTempDir)
-			os.RemoveAll(dnpTempDir)
-			return RunBuildQuestions(db)
+			return RunBuildQuestions(db, profile)
 		} else {
 			fmt.Println("Invalid response, please try again.")
 			continue
@@ -375,315 +299,158 @@ func RunBuildQuestions(db *sql.DB) (requestsList, dnpList, singers []string, dur
 	}
 }
 
-func RunBuild(db *sql.DB, requestsList, dnpList, singers []string, duration, requestNum int32, explicit bool) error {
-	dbQueries := database.New(db)
-	requests := make([]string, len(requestsList))
-	copy(requests, requestsList)
-	setlist := [][]string{}
-	addedSongs := map[string]bool{}
-	setLengths := []int32{}
-	countTillRequest := 0
-	requestCount := 0
-	balanced := true
-	if duration > 90 && duration <= 150 {
-		setLengths = append(setLengths, duration/2-10, duration/2-10)
-	} else if duration > 150 && duration <= 180 {
-		setLengths = append(setLengths, duration/3-10, duration/3-10, duration/3-10)
-	} else {
-		setLengths = append(setLengths, duration)
-	}
-
-	durationChecks, durationChecksErr := dbQueries.SumDurationForSinger(context.Background(), singers)
-	if durationChecksErr != nil {
-		fmt.Printf("Unable to check total durations for singers: %v\n\n", durationChecksErr)
-	}
-	if len(durationChecks) == 2 && (durationChecks[1].TotalDuration)/60 < (int64(duration)/3) {
-		balanced = false
-		fmt.Println("")
-		fmt.Println("Singers chosen may not be able to complete entire set balanced, repeat singer rule will be ignored")
-		fmt.Println("")
-	}
-
-	fmt.Println("Set Lengths:")
-	for i, setLength := range setLengths {
-		fmt.Printf("Set %d - %d minutes\n", i+1, setLength)
-	}
-	fmt.Println("")
-	fmt.Println("Fetching tracks from DB...")
-	workingTracks, tracksErr := dbQueries.GetAllTracks(context.Background())
-	if tracksErr != nil {
-		return fmt.Errorf("unable to get tracks in database: %v", tracksErr)
-	}
-	fmt.Println("✅ Tracks fetched.")
-	for _, workingTrack := range workingTracks {
-		workingParams := database.AddTrackToWorkingParams{
-			Name:              workingTrack.Name,
-			Artist:            workingTrack.Artist,
-			Genre:             workingTrack.Genre,
-			DurationInSeconds: int32(workingTrack.DurationInSeconds),
-			Year:              workingTrack.Year,
-			Explicit:          workingTrack.Explicit,
-			Bpm:               int32(workingTrack.Bpm),
-			OriginalKey:       workingTrack.OriginalKey,
-		}
-		addErr := dbQueries.AddTrackToWorking(context.Background(), workingParams)
-		if addErr != nil {
-			err := RunClear(db, "working")
-			if err != nil {
-				return err
-			}
-			return fmt.Errorf("error adding track to working table: %v", addErr)
-		}
-	}
-	fmt.Println("✅ Tracks added to working table.")
-	fmt.Println("")
-	for _, dnp := range dnpList {
-		_, workingErr := dbQueries.GetWorking(context.Background(), dnp)
-		if workingErr == nil {
-			removeErr := dbQueries.RemoveFromWorking(context.Background(), dnp)
-			if removeErr != nil {
-				return fmt.Errorf("error removing dnp track from working table: %v", removeErr)
-			}
-		} else if workingErr == sql.ErrNoRows {
-			fmt.Printf("%s not found in database, skipping...\n", dnp)
-			fmt.Println("")
-		}
-	}
-	fmt.Println("✅ DNP's remove from working table.")
-	for _, set := range setLengths {
-		workTracks, workTracksErr := dbQueries.GetAllWorking(context.Background())
-		if workTracksErr != nil {
-			return fmt.Errorf("unable to load working table: %v", workTracksErr)
+// readRequestSource prompts for a requests or DNP list and returns its songs, or nil if the user
+// skipped the prompt.
+func readRequestSource(reader *bufio.Reader, listName string) ([]sources.Candidate, error) {
+	for {
+		fmt.Printf("If you have a %s list, paste a Spotify playlist link or the path to a CSV, M3U, XSPF or text file.\n", listName)
+		fmt.Print("You can also type 'paste' to enter 'Artist - Title' lines by hand, otherwise just hit enter:\n")
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if input == "" {
+			return nil, nil
 		}
-		singleSet := []string{}
-		lastKey := ""
-		secondToLastKey := ""
-		lastSinger := ""
-		secondToLastSinger := ""
-		usedArtists := map[string]bool{}
-		totalDuration := 0
-		margin := 180
-		target := int(set) * 60
-		maxStaleRounds := 5
-		staleRounds := 0
-		for totalDuration < target-margin && staleRounds < maxStaleRounds {
-			loopMadeProgress := false
-			if totalDuration >= target-margin {
-				fmt.Println("hit target margin")
-				break
-			}
-			if len(workTracks) == 0 {
-				log.Fatalf("working tracks is empty")
-			}
-			rand.Shuffle(len(workTracks), func(i, j int) {
-				workTracks[i], workTracks[j] = workTracks[j], workTracks[i]
-			})
-			if countTillRequest < 3 || len(requests) == 0 {
-				for i := 0; i < len(workTracks); i++ {
-					track := workTracks[i]
-					if tryAddTrackToSet(db, track, singers, &singleSet, addedSongs, usedArtists, &lastKey, &secondToLastKey, &totalDuration, int(set*60), explicit, &lastSinger, &secondToLastSinger, balanced) {
-						countTillRequest++
-						loopMadeProgress = true
-						for _, request := range requests {
-							if track.Name == request {
-								fmt.Println("✅ Request added")
-								requestCount++
-								countTillRequest = 0
-								break
-							}
-						}
-						break
-					}
-				}
-			} else {
-				requestAdded := false
-				for i := 0; i < len(requests); {
-					request := requests[i]
-					track, getErr := dbQueries.GetWorking(context.Background(), request)
-					if getErr != nil {
-						fmt.Printf("Request %s not found in DB (possibly due to being already added), removing from request list...\n", request)
-						requests = removeIndex(requests, i)
-						continue
-					}
-					if tryAddTrackToSet(db, track, singers, &singleSet, addedSongs, usedArtists, &lastKey, &secondToLastKey, &totalDuration, int(set*60), explicit, &lastSinger, &secondToLastSinger, balanced) {
-						requests = removeIndex(requests, i)
-						fmt.Println("✅ Request added")
-						countTillRequest = 0
-						loopMadeProgress = true
-						requestCount++
-						requestAdded = true
-						break
-					} else {
-						fmt.Printf("❌ Request %s failed validation at current position. Will try again later.\n", request)
-						i++
-					}
-				}
-				if !requestAdded && len(requests) > 0 {
-					fmt.Println("⚠️ No requests passed at this point. Keeping them for next opportunity.")
+		var source sources.RequestSource
+		if strings.ToLower(input) == "paste" {
+			fmt.Println("Enter one song per line as 'Artist - Title', then hit enter on an empty line to finish:")
+			lines := []string{}
+			for {
+				line, _ := reader.ReadString('\n')
+				line = strings.TrimSpace(line)
+				if line == "" {
+					break
 				}
+				lines = append(lines, line)
 			}
-			if loopMadeProgress {
-				staleRounds = 0
-			} else {
-				staleRounds++
-				countTillRequest = 0
-				fmt.Println("Failed to add any requests at this specific spot, will attempt in 3 more songs...")
+			source = &sources.TextListSource{Text: strings.Join(lines, "\n")}
+		} else {
+			wd, _ := os.Getwd()
+			spotify := sources.SpotifySource{
+				ClientID:     os.Getenv("SPOTIFY_ID"),
+				ClientSecret: os.Getenv("SPOTIFY_SECRET"),
+				Dir:          wd,
+			}
+			var sourceErr error
+			source, sourceErr = sources.FromInput(input, spotify)
+			if sourceErr != nil {
+				fmt.Printf("Invalid, %v\n", sourceErr)
+				continue
 			}
 		}
-		if totalDuration < target {
-			underfill := target - totalDuration
-			underfillMinute := underfill / 60
-			underfillSecond := underfill % 60
-			fmt.Println("")
-			fmt.Printf("Warning: Set %d is underfilled by %d minutes and %d seconds.\n", len(setlist)+1, underfillMinute, underfillSecond)
-			fmt.Println("")
+		candidates, candidatesErr := source.Candidates()
+		if candidatesErr != nil {
+			return nil, candidatesErr
 		}
-		setlist = append(setlist, singleSet)
+		fmt.Printf("Found %d songs in %s\n", len(candidates), source.Description())
+		fmt.Println("")
+		return candidates, nil
+	}
+}
+
+// RunBuild builds and prints a setlist. explain is "text" or "json" to print how each song was
+// picked in place of the usual progress output.
+func RunBuild(db *sql.DB, params service.BuildParams, explain string) error {
+	var out io.Writer = os.Stdout
+	if explain != "" {
+		out = io.Discard
+	}
+	builder := service.NewBuilder(db, out)
+	setlist, buildErr := builder.Build(context.Background(), params)
+	if buildErr != nil {
+		return buildErr
+	}
+	switch explain {
+	case "text":
+		printTrace(builder.Trace())
+	case "json":
+		data, marshalErr := json.MarshalIndent(builder.Trace(), "", "  ")
+		if marshalErr != nil {
+			return fmt.Errorf("unable to write build trace: %v", marshalErr)
+		}
+		fmt.Println(string(data))
 	}
 	fmt.Println("Setlist complete, printing...")
 	fmt.Println("")
-	for i, set := range setlist {
-		fmt.Printf("Set %d:\n", (i + 1))
-		for j, song := range set {
-			fmt.Printf("%d: %s\n", (j + 1), song)
+	printSetlist(setlist)
+	if len(setlist.LineupExcluded) > 0 {
+		fmt.Printf("Left out for the lineup (%s): %d song(s)\n", strings.Join(setlist.Params.Lineup, ", "), len(setlist.LineupExcluded))
+		for _, exclusion := range setlist.LineupExcluded {
+			fmt.Printf("   - %s - %s: needs %s\n", exclusion.Name, exclusion.Artist, strings.Join(exclusion.Missing, " & "))
 		}
 		fmt.Println("")
 	}
-	fmt.Printf("Requests Included: %d/%d", requestCount, requestNum)
-	fmt.Println("")
-	if len(setlist) > 1 {
-		fmt.Println("Breaks between sets:")
-		if len(setlist) == 2 {
-			fmt.Println("20 minutes")
-		} else if len(setlist) == 3 {
-			fmt.Println("15 minutes")
+	if len(setlist.GenreExcluded) > 0 {
+		fmt.Printf("Left out for their genre (%s): %d song(s)\n", strings.Join(setlist.Params.Mix.ExcludeGenres, ", "), len(setlist.GenreExcluded))
+		for _, exclusion := range setlist.GenreExcluded {
+			fmt.Printf("   - %s - %s: %s\n", exclusion.Name, exclusion.Artist, strings.Join(exclusion.Genres, " & "))
 		}
+		fmt.Println("")
 	}
-	fmt.Println("")
-	err := RunClear(db, "working")
-	if err != nil {
-		return err
+	if editErr := RunEditSetlist(db, setlist); editErr != nil {
+		return editErr
+	}
+	if recordErr := service.RecordBuild(context.Background(), database.New(db), setlist); recordErr != nil {
+		fmt.Printf("Unable to record this build for stats: %v\n", recordErr)
 	}
 	fmt.Println("")
 	fmt.Println("Setlist successfully built! Closing app...")
 	return nil
 }
 
-func tryAddTrackToSet(
-	db *sql.DB,
-	track database.Working,
-	singers []string,
-	singleSet *[]string,
-	addedSongs map[string]bool,
-	usedArtists map[string]bool,
-	lastKey *string,
-	secondToLastKey *string,
-	totalDuration *int,
-	maxDuration int,
-	explicit bool,
-	lastSinger *string,
-	secondToLastSinger *string,
-	balanced bool,
-) bool {
-	if usedArtists[track.Artist] {
-		fmt.Printf("Rejected %s: artist %s already used\n", track.Name, track.Artist)
-		return false
-	}
-	if addedSongs[track.Name] {
-		fmt.Printf("Rejected %s: song already added\n", track.Name)
-		return false
-	}
-
-	if *totalDuration+int(track.DurationInSeconds) > maxDuration+300 {
-		fmt.Printf("Rejected %s: would exceed maxDuration (%d + %d > %d)\n", track.Name, *totalDuration, track.DurationInSeconds, maxDuration+300)
-		return false
-	}
-	if explicit && track.Explicit {
-		fmt.Printf("Rejected %s: track has explicit lyrics\n", track.Name)
-		return false
-	}
-
-	dbQueries := database.New(db)
-	params := database.GetSingerCombosParams{
-		Song:    track.Name,
-		Artist:  track.Artist,
-		Column3: singers,
-	}
-	combos, combosErr := dbQueries.GetSingerCombos(context.Background(), params)
-	if combosErr != nil {
-		fmt.Printf("unable to get singer/key combo for %s: %v.", track.Name, combosErr)
-		return false
-	}
-	for _, combo := range combos {
-		for _, singer := range singers {
-			if combo.Singer == singer {
-				fmt.Printf("Matched singer: %s - %s\n", singer, combo.Key)
-				break
+// printAirtime prints each singer's minutes, song count and share, checking them against any
+// airtime targets.
+func printAirtime(airtime []service.SingerAirtime, tolerance float64) {
+	for _, singer := range airtime {
+		line := fmt.Sprintf("   %s: %s, %d songs, %.0f%%", singer.Singer, formatSeconds(singer.Seconds), singer.Songs, singer.Share)
+		if singer.HasTarget {
+			status := "✅"
+			if singer.OffTarget(tolerance) {
+				status = "⚠️"
 			}
-			continue
+			line = fmt.Sprintf("%s %s (target %.0f%%)", line, status, singer.Target)
 		}
-		track.Singer = sql.NullString{String: combo.Singer, Valid: true}
-		track.SingerKey = sql.NullString{String: combo.Key, Valid: true}
-		if *lastKey != "" && track.SingerKey.String == *lastKey && track.SingerKey.String == *secondToLastKey {
-			fmt.Printf("Rejected %s: same key (%s) as last two tracks\n", track.Name, track.SingerKey.String)
-			continue
-		}
-		if len(singers) != 1 && balanced && *lastSinger != "" && track.Singer.String == *lastSinger && track.Singer.String == *secondToLastSinger {
-			fmt.Printf("Rejected %s: same singer (%s) for last two tracks\n", track.Name, track.Singer.String)
-			continue
-		}
-		addSingerParams := database.AddSingerToWorkingParams{
-			Singer:    sql.NullString{String: track.Singer.String, Valid: true},
-			SingerKey: sql.NullString{String: track.SingerKey.String, Valid: true},
-			Name:      track.Name,
-			Artist:    track.Artist,
-		}
-		addErr := dbQueries.AddSingerToWorking(context.Background(), addSingerParams)
-		if addErr != nil {
-			fmt.Printf("unable to add singer/key combo to working table for %s: %v", track.Name, addErr)
-			continue
-		}
-		*secondToLastKey = *lastKey
-		*lastKey = track.SingerKey.String
-		*secondToLastSinger = *lastSinger
-		*lastSinger = track.Singer.String
-		*totalDuration += int(track.DurationInSeconds)
-		usedArtists[track.Artist] = true
-		addedSongs[track.Name] = true
-		trackInfo := track.Name + " - " + track.Singer.String + " - " + track.SingerKey.String
-		*singleSet = append(*singleSet, trackInfo)
-		fmt.Printf("✅ Added track: %s by %s [%s]\n", track.Name, track.Artist, track.SingerKey.String)
-		dbQueries.RemoveFromWorking(context.Background(), track.Name)
-		return true
-	}
-	fmt.Printf("Rejected: unable to find singer/key combo for %s that does not violate conditions.\n", track.Name)
-	return false
+		fmt.Println(line)
+	}
 }
 
-func removeIndex(s []string, index int) []string {
-	return append(s[:index], s[index+1:]...)
+// setClock returns " (20:00 - 20:52)" for a set with songs when the build has a start time.
+func setClock(setlist *service.Setlist, times []service.SongTime) string {
+	if setlist.Params.StartTime == "" || len(times) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%s - %s)", setlist.Params.Clock(times[0].Start), setlist.Params.Clock(times[len(times)-1].End))
 }
 
-func compareLists(a, b []string) []string {
-	m := make(map[string]struct{})
-	for _, item := range a {
-		m[item] = struct{}{}
-	}
-	var result []string
-	for _, item := range b {
-		if _, found := m[item]; found {
-			result = append(result, item)
-		}
+// songClock returns "[20:05] " for a song when the build has a start time.
+func songClock(setlist *service.Setlist, songTime service.SongTime) string {
+	if setlist.Params.StartTime == "" {
+		return ""
 	}
-	return result
+	return "[" + setlist.Params.Clock(songTime.Start) + "] "
 }
 
-func removeFromList(match string, list *[]string) {
-	newList := (*list)[:0]
-	for _, song := range *list {
-		if song != match {
-			newList = append(newList, song)
+func printSetlist(setlist *service.Setlist) {
+	tolerance := setlist.Params.Tolerance()
+	schedule := setlist.Schedule()
+	for i, set := range setlist.Sets {
+		fmt.Printf("Set %d%s:\n", (i + 1), setClock(setlist, schedule[i]))
+		for j, song := range set.Entries {
+			fmt.Printf("%d: %s%s\n", (j + 1), songClock(setlist, schedule[i][j]), song)
 		}
+		fmt.Println("Airtime:")
+		printAirtime(setlist.Airtime(i), tolerance)
+		fmt.Println("")
+	}
+	if len(setlist.Sets) > 1 {
+		fmt.Println("Airtime for the night:")
+		printAirtime(setlist.NightAirtime(), tolerance)
+		fmt.Println("")
 	}
-	*list = newList
+	fmt.Printf("Requests Included: %d/%d", setlist.RequestsIncluded, setlist.RequestsTotal)
+	fmt.Println("")
+	if len(setlist.Sets) > 1 {
+		fmt.Println("Breaks between sets:")
+		fmt.Printf("%d minutes\n", setlist.BreakMinutes)
+	}
+	fmt.Println("")
 }
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+)
+
+// doctorListLimit is how many songs each section of the report lists before summarizing.
+const doctorListLimit = 10
+
+func printDoctorTracks(title, fix string, tracks []database.Track) {
+	if len(tracks) == 0 {
+		fmt.Printf("✅ %s: none\n", title)
+		return
+	}
+	fmt.Printf("❌ %s: %d (%s)\n", title, len(tracks), fix)
+	for i, track := range tracks {
+		if i == doctorListLimit {
+			fmt.Printf("   ... and %d more\n", len(tracks)-doctorListLimit)
+			break
+		}
+		fmt.Printf("   - %s - %s\n", track.Name, track.Artist)
+	}
+}
+
+func printDoctorAssignments(title, fix string, assignments []database.GetAllSingersRow) {
+	if len(assignments) == 0 {
+		fmt.Printf("✅ %s: none\n", title)
+		return
+	}
+	fmt.Printf("❌ %s: %d (%s)\n", title, len(assignments), fix)
+	for i, assignment := range assignments {
+		if i == doctorListLimit {
+			fmt.Printf("   ... and %d more\n", len(assignments)-doctorListLimit)
+			break
+		}
+		fmt.Printf("   - %s - %s: %s (%s)\n", assignment.Name, assignment.Artist, assignment.Singer, assignment.Key)
+	}
+}
+
+// printCounts prints counts largest first, then by name.
+func printCounts(counts map[string]int) {
+	names := []string{}
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		fmt.Printf("   - %s: %d\n", name, counts[name])
+	}
+}
+
+func RunDoctor(db *sql.DB) error {
+	dbQueries := database.New(db)
+	ctx := context.Background()
+	tracks, tracksErr := dbQueries.GetAllTracks(ctx)
+	if tracksErr != nil {
+		return fmt.Errorf("failed to get all tracks: %v", tracksErr)
+	}
+	singers, singersErr := dbQueries.GetAllSingers(ctx)
+	if singersErr != nil {
+		return fmt.Errorf("failed to get singers: %v", singersErr)
+	}
+	singerNames := []string{}
+	for _, singer := range singers {
+		if !containsString(singerNames, singer.Singer) {
+			singerNames = append(singerNames, singer.Singer)
+		}
+	}
+	totals, totalsErr := dbQueries.SumDurationForSinger(ctx, singerNames)
+	if totalsErr != nil {
+		return fmt.Errorf("failed to total singer durations: %v", totalsErr)
+	}
+	working, workingErr := dbQueries.GetAllWorking(ctx)
+	if workingErr != nil {
+		return fmt.Errorf("failed to get working table: %v", workingErr)
+	}
+	report := service.Diagnose(tracks, singers, totals, len(working))
+
+	fmt.Printf("Library health report for %d tracks\n", report.Tracks)
+	fmt.Println("")
+	printDoctorTracks("Tracks missing an original key", "fix with ./setlist keys missing", report.MissingKey)
+	printDoctorTracks("Tracks missing a BPM", "fix with ./setlist tracks edit [song] --bpm", report.MissingBpm)
+	printDoctorTracks("Tracks with no singers", "fix with ./setlist singers", report.NoSingers)
+	printDoctorAssignments("Singer keys that aren't valid keys", "fix with ./setlist singers edit [song]", report.InvalidKeys)
+	printDoctorAssignments("Assignments for singers not in the band", "fix with ./setlist singers remove [song] [singer]", report.UnknownSingers)
+	if len(report.Duplicates) == 0 {
+		fmt.Println("✅ Suspected duplicates: none")
+	} else {
+		fmt.Printf("❌ Suspected duplicates: %d group(s) (fix with ./setlist tracks dedupe)\n", len(report.Duplicates))
+		for i, group := range report.Duplicates {
+			if i == doctorListLimit {
+				fmt.Printf("   ... and %d more\n", len(report.Duplicates)-doctorListLimit)
+				break
+			}
+			names := []string{}
+			for _, track := range group {
+				names = append(names, fmt.Sprintf("%s - %s", track.Name, track.Artist))
+			}
+			fmt.Printf("   - %s\n", strings.Join(names, " / "))
+		}
+	}
+	if report.OrphanedWorking == 0 {
+		fmt.Println("✅ Leftover working table rows: none")
+	} else {
+		fmt.Printf("❌ Leftover working table rows: %d from an interrupted build (fix with ./setlist clear working)\n", report.OrphanedWorking)
+	}
+
+	fmt.Println("")
+	fmt.Println("Material per singer:")
+	if len(report.SingerTotals) == 0 {
+		fmt.Println("   No singers have been assigned yet")
+	}
+	for _, total := range report.SingerTotals {
+		fmt.Printf("   - %s: %d songs, %d minutes (%d minutes clean)\n", total.Singer, total.SongCount, total.TotalDuration/60, total.CleanDuration/60)
+	}
+
+	fmt.Println("")
+	fmt.Println("Genres:")
+	printCounts(report.Genres)
+	if report.NoGenre > 0 {
+		fmt.Printf("   - no genre: %d\n", report.NoGenre)
+	}
+	fmt.Println("")
+	fmt.Println("Decades:")
+	printCounts(report.Decades)
+	if report.NoYear > 0 {
+		fmt.Printf("   - no year: %d\n", report.NoYear)
+	}
+
+	fmt.Println("")
+	fmt.Printf("Longest gig (%d minutes, %d minutes of music) by singers:\n", service.MaxDuration, report.LongestGigMinutes)
+	for _, combo := range report.Combos {
+		status := "✅"
+		notes := []string{}
+		if !combo.FitsGig(report.LongestGigMinutes, true) {
+			status = "❌"
+			notes = append(notes, fmt.Sprintf("%d minutes short", report.LongestGigMinutes-combo.DurationSeconds/60))
+		} else if !combo.FitsGig(report.LongestGigMinutes, false) {
+			notes = append(notes, "not enough without explicit songs")
+		}
+		if !combo.Balanced {
+			notes = append(notes, "unbalanced, the repeat singer rule will be ignored")
+		}
+		line := fmt.Sprintf("%s %s: %d songs, %d minutes (%d clean)", status, strings.Join(combo.Singers, " + "), combo.Songs, combo.DurationSeconds/60, combo.CleanSeconds/60)
+		if len(notes) > 0 {
+			line += ", " + strings.Join(notes, ", ")
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Println("")
+	if problems := report.Problems(); problems > 0 {
+		fmt.Printf("Found %d problem(s).\n", problems)
+	} else {
+		fmt.Println("✅ No problems found.")
+	}
+	return nil
+}
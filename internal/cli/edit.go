@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+)
+
+const editHelp = `Commands (sets and songs are numbered as printed):
+  lock [set] [song]          lock a song so regenerating keeps it, run again to unlock
+  replace [set] [song]       pick a replacement from songs that fit that spot
+  move [set] [song] [to set] {to song}   move a song, to the end of the set if no position is given
+  regenerate {set}           rebuild the unlocked songs of one set, or of every set
+  show                       print the setlist again
+  done                       finish editing (or just hit enter)`
+
+// RunEditSetlist lets a finished setlist be changed song by song until it's right.
+func RunEditSetlist(db *sql.DB, setlist *service.Setlist) error {
+	library, loadErr := service.LoadLibrary(context.Background(), database.New(db))
+	if loadErr != nil {
+		return loadErr
+	}
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Print("Would you like to make changes to the setlist? (Y/N): ")
+		rsp, _ := reader.ReadString('\n')
+		rsp = strings.TrimSpace(strings.ToLower(rsp))
+		if rsp == "n" {
+			return nil
+		} else if rsp == "y" {
+			break
+		}
+		fmt.Println("Invalid response, please try again")
+	}
+	fmt.Println("")
+	fmt.Println(editHelp)
+	fmt.Println("")
+	printEditableSetlist(setlist, library)
+	for {
+		fmt.Print("Enter a command ('help' to list them, or hit enter to finish): ")
+		line, _ := reader.ReadString('\n')
+		fields := strings.Fields(strings.ToLower(line))
+		if len(fields) == 0 || fields[0] == "done" {
+			break
+		}
+		nums, numsErr := parsePositions(fields[1:])
+		if numsErr != nil {
+			fmt.Println(numsErr)
+			continue
+		}
+		var editErr error
+		switch fields[0] {
+		case "help":
+			fmt.Println(editHelp)
+			continue
+		case "show":
+		case "lock":
+			editErr = lockSong(setlist, nums)
+		case "replace":
+			editErr = replaceSong(reader, setlist, library, nums)
+		case "move":
+			if len(nums) < 3 || len(nums) > 4 {
+				editErr = fmt.Errorf("usage: move [set] [song] [to set] {to song}")
+				break
+			}
+			// MoveSong puts positions past the end of a set at the end
+			toPosition := math.MaxInt
+			if len(nums) == 4 {
+				toPosition = nums[3]
+			}
+			editErr = service.MoveSong(setlist, nums[0], nums[1], nums[2], toPosition)
+		case "regenerate":
+			editErr = regenerateSets(setlist, library, nums)
+		default:
+			editErr = fmt.Errorf("unknown command %s, type 'help' to list commands", fields[0])
+		}
+		if editErr != nil {
+			fmt.Printf("Invalid, %v\n", editErr)
+			continue
+		}
+		fmt.Println("")
+		printEditableSetlist(setlist, library)
+	}
+	fmt.Println("✅ Finished editing setlist, final setlist:")
+	fmt.Println("")
+	printSetlist(setlist)
+	return nil
+}
+
+// parsePositions turns the numbers typed after a command into zero-based indexes.
+func parsePositions(args []string) ([]int, error) {
+	nums := []int{}
+	for _, arg := range args {
+		num, numErr := strconv.Atoi(arg)
+		if numErr != nil || num < 1 {
+			return nil, fmt.Errorf("invalid number %s, sets and songs are numbered from 1", arg)
+		}
+		nums = append(nums, num-1)
+	}
+	return nums, nil
+}
+
+func lockSong(setlist *service.Setlist, nums []int) error {
+	if len(nums) != 2 {
+		return fmt.Errorf("usage: lock [set] [song]")
+	}
+	if nums[0] >= len(setlist.Sets) || nums[1] >= len(setlist.Sets[nums[0]].Entries) {
+		return fmt.Errorf("set %d does not have a song %d", nums[0]+1, nums[1]+1)
+	}
+	entry := &setlist.Sets[nums[0]].Entries[nums[1]]
+	entry.Locked = !entry.Locked
+	if entry.Locked {
+		fmt.Printf("🔒 Locked %s\n", entry.Name)
+	} else {
+		fmt.Printf("Unlocked %s\n", entry.Name)
+	}
+	return nil
+}
+
+func replaceSong(reader *bufio.Reader, setlist *service.Setlist, library *service.Library, nums []int) error {
+	if len(nums) != 2 {
+		return fmt.Errorf("usage: replace [set] [song]")
+	}
+	alternatives, altErr := service.Alternatives(setlist, library, nums[0], nums[1], 5)
+	if altErr != nil {
+		return altErr
+	}
+	current := setlist.Sets[nums[0]].Entries[nums[1]]
+	if current.Locked {
+		return fmt.Errorf("%s is locked, unlock it before replacing it", current.Name)
+	}
+	if len(alternatives) == 0 {
+		return fmt.Errorf("no song in the library can replace %s without breaking a rule", current.Name)
+	}
+	fmt.Printf("Songs that fit in place of %s:\n", current)
+	for i, alternative := range alternatives {
+		fmt.Printf("%d. %s (%s, %s)\n", i+1, alternative, alternative.Artist, formatSeconds(int(alternative.DurationInSeconds)))
+	}
+	for {
+		fmt.Print("Choose a replacement by number, or hit enter to keep the current song: ")
+		choice, _ := reader.ReadString('\n')
+		choice = strings.TrimSpace(choice)
+		if choice == "" {
+			fmt.Println("Keeping current song")
+			return nil
+		}
+		num, numErr := strconv.Atoi(choice)
+		if numErr != nil || num < 1 || num > len(alternatives) {
+			fmt.Println("Invalid response, please try again")
+			continue
+		}
+		return service.ReplaceSong(setlist, nums[0], nums[1], alternatives[num-1])
+	}
+}
+
+func regenerateSets(setlist *service.Setlist, library *service.Library, nums []int) error {
+	if len(nums) > 1 {
+		return fmt.Errorf("usage: regenerate {set}")
+	}
+	sets := nums
+	if len(sets) == 0 {
+		for i := range setlist.Sets {
+			sets = append(sets, i)
+		}
+	}
+	warnings := len(setlist.Warnings)
+	for _, set := range sets {
+		if regenErr := service.RegenerateSet(setlist, library, set); regenErr != nil {
+			return regenErr
+		}
+	}
+	for _, warning := range setlist.Warnings[warnings:] {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+	return nil
+}
+
+func formatSeconds(seconds int) string {
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// printEditableSetlist prints the setlist with set durations, locked songs and broken rules.
+func printEditableSetlist(setlist *service.Setlist, library *service.Library) {
+	problems := map[[2]int][]string{}
+	for _, violation := range service.Validate(setlist, library) {
+		id := [2]int{violation.Set, violation.Position}
+		problems[id] = append(problems[id], violation.Message)
+	}
+	schedule := setlist.Schedule()
+	for i, set := range setlist.Sets {
+		fmt.Printf("Set %d (%s of %d minutes)%s:\n", i+1, formatSeconds(set.DurationInSeconds()), set.TargetMinutes, setClock(setlist, schedule[i]))
+		for _, message := range problems[[2]int{i, -1}] {
+			fmt.Printf("  ⚠️ %s\n", message)
+		}
+		for j, song := range set.Entries {
+			marker := ""
+			if song.Locked {
+				marker = " 🔒"
+			}
+			fmt.Printf("%d: %s%s%s\n", j+1, songClock(setlist, schedule[i][j]), song, marker)
+			for _, message := range problems[[2]int{i, j}] {
+				fmt.Printf("  ⚠️ %s\n", message)
+			}
+		}
+		fmt.Println("")
+	}
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+)
+
+func printTrace(trace *service.Trace) {
+	fmt.Println("Build trace:")
+	for _, slot := range trace.Slots {
+		label := ""
+		if slot.Requests {
+			label = " (request slot)"
+		}
+		fmt.Printf("Set %d, song %d%s:\n", slot.Set+1, slot.Position+1, label)
+		if len(slot.Considered) > 0 {
+			fmt.Printf("  Rejected %d:\n", len(slot.Considered))
+		}
+		for _, rejection := range slot.Considered {
+			if rejection.Artist == "" {
+				fmt.Printf("   - %s: %s\n", rejection.Name, rejection.Reason)
+				continue
+			}
+			fmt.Printf("   - %s - %s: %s\n", rejection.Name, rejection.Artist, rejection.Reason)
+		}
+		if slot.Chosen != nil {
+			fmt.Printf("  ✅ Chosen: %s - %s, sung by %s in %s\n", slot.Chosen.Name, slot.Chosen.Artist, strings.Join(slot.Chosen.Vocalists(), " & "), slot.Chosen.Key)
+		} else {
+			fmt.Println("  ❌ Nothing fit in this slot")
+		}
+	}
+	fmt.Println("")
+}
+
+func RunWhy(db *sql.DB, song string) error {
+	dbQueries := database.New(db)
+	setlist, trace, loadErr := service.LoadLastTrace(context.Background(), dbQueries)
+	if loadErr == sql.ErrNoRows {
+		return fmt.Errorf("no builds have been run yet, run build first")
+	} else if loadErr != nil {
+		return fmt.Errorf("unable to load the last build: %v", loadErr)
+	}
+	var track *database.Track
+	var singers []database.Singer
+	found, getErr := service.TrackFromName(context.Background(), dbQueries, song)
+	var ambiguous *service.AmbiguousTrackError
+	if getErr == nil {
+		track = &found
+		var singersErr error
+		singers, singersErr = dbQueries.GetSingersForTrack(context.Background(), found.ID)
+		if singersErr != nil {
+			return fmt.Errorf("unable to get singers for %s: %v", found.Name, singersErr)
+		}
+	} else if errors.As(getErr, &ambiguous) {
+		return getErr
+	} else if getErr != sql.ErrNoRows {
+		return fmt.Errorf("unable to look up %s: %v", song, getErr)
+	}
+	for _, line := range service.Why(setlist, trace, song, track, singers) {
+		fmt.Println(line)
+	}
+	return nil
+}
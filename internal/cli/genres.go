@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+)
+
+func RunListGenres(db *sql.DB) error {
+	dbQueries := database.New(db)
+	rows, getErr := dbQueries.GetGenreBuckets(context.Background())
+	if getErr != nil {
+		return fmt.Errorf("unable to get genre buckets: %v", getErr)
+	}
+	fmt.Println("Genre buckets:")
+	if len(rows) == 0 {
+		fmt.Println("None, use './setlist genres map [genre] [bucket]' to add one")
+	}
+	for _, row := range rows {
+		fmt.Printf(" - %s → %s\n", row.Genre, row.Bucket)
+	}
+	tracks, tracksErr := dbQueries.GetAllTracks(context.Background())
+	if tracksErr != nil {
+		return fmt.Errorf("failed to get all tracks: %v", tracksErr)
+	}
+	genres := service.NewGenreMap(rows)
+	bucketCounts := map[string]int{}
+	unmapped := map[string]int{}
+	for _, track := range tracks {
+		for _, bucket := range genres.Buckets(track.Genre) {
+			bucketCounts[bucket]++
+		}
+		for _, genre := range track.Genre {
+			if _, mapped := genres.Bucket(genre); !mapped {
+				unmapped[service.NormalizeGenre(genre)]++
+			}
+		}
+	}
+	fmt.Println("")
+	fmt.Println("Songs per bucket:")
+	for _, bucket := range sortedByCount(bucketCounts) {
+		fmt.Printf(" - %s: %d\n", bucket, bucketCounts[bucket])
+	}
+	if len(unmapped) > 0 {
+		fmt.Println("")
+		fmt.Println("⚠️  Genres without a bucket (each is its own bucket):")
+		for _, genre := range sortedByCount(unmapped) {
+			fmt.Printf(" - %s: %d song(s)\n", genre, unmapped[genre])
+		}
+	}
+	return nil
+}
+
+// sortedByCount returns the keys of counts, most common first.
+func sortedByCount(counts map[string]int) []string {
+	keys := []string{}
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func RunMapGenre(db *sql.DB, genre, bucket string) error {
+	genre, bucket = service.NormalizeGenre(genre), service.NormalizeGenre(bucket)
+	if genre == "" || bucket == "" {
+		return fmt.Errorf("genre and bucket can't be blank")
+	}
+	dbQueries := database.New(db)
+	params := database.SetGenreBucketParams{
+		Genre:  genre,
+		Bucket: bucket,
+	}
+	if setErr := dbQueries.SetGenreBucket(context.Background(), params); setErr != nil {
+		return fmt.Errorf("unable to save genre bucket: %v", setErr)
+	}
+	fmt.Printf("✅ Genres matching %s now count as %s\n", genre, bucket)
+	return nil
+}
+
+func RunUnmapGenre(db *sql.DB, genre string) error {
+	genre = service.NormalizeGenre(genre)
+	dbQueries := database.New(db)
+	removed, removeErr := dbQueries.RemoveGenreBucket(context.Background(), genre)
+	if removeErr != nil {
+		return fmt.Errorf("unable to remove genre bucket: %v", removeErr)
+	}
+	if removed == 0 {
+		return fmt.Errorf("%s has no bucket", genre)
+	}
+	fmt.Printf("✅ Removed the bucket for %s\n", genre)
+	return nil
+}
@@ -15,12 +15,89 @@ func RunHelp() {
 	fmt.Println("- Extracts metadata from all tracks in a Spotify playlist and stores it in the database. Songs already in the database will be skipped over.")
 	fmt.Println("- If songs fail to be added for whatever reason, try running the clean function (detailed below) and then rerunning the extract command.\n- Note that analysis of metadata is not guaranteed to be 100% accurate.")
 	fmt.Println("")
-	fmt.Println("list")
-	fmt.Println("- Lists all songs currently in the tracks table in the database.")
+	fmt.Println("import-audio [directory]")
+	fmt.Println("- Walks a folder of MP3, FLAC and WAV files, reads title/artist/year/genre tags, analyzes key, BPM and duration, and stores them in the database.")
+	fmt.Println("- Files without tags must be named 'Artist - Title'. Songs already in the database will be skipped over, and imported songs are marked as not explicit.")
+	fmt.Println("")
+	fmt.Println("list {search} {filters} {--sort column} {--desc} {--columns a,b} {--format table|csv|json}")
+	fmt.Println("- Lists the songs in the tracks table. Any text given searches song names and artists.")
+	fmt.Println("- Filters: --singer name, --key Eb, --genre rock, --bpm 100-130, --year 1980-1989, --explicit (or --explicit=false), --missing-key, --no-singer.")
+	fmt.Println("- Sort by id, name, artist, duration, year, bpm or key. Columns: id, name, artist, genre, duration, year, explicit, bpm, key, melody, singers, spotify_id, isrc.")
+	fmt.Println("")
+	fmt.Println("library export {--format csv|json} {--output file}")
+	fmt.Println("- Exports every track along with its singers and keys, so the library can be edited in a spreadsheet. CSV is the default format.")
+	fmt.Println("- Genres are separated by ';' and singers are written as 'Singer:Key' pairs separated by ';'.")
+	fmt.Println("")
+	fmt.Println("library import [file] {--dry-run}")
+	fmt.Println("- Imports a CSV or JSON file in the export format. Tracks are matched by name and artist, new tracks are added and existing tracks are updated.")
+	fmt.Println("- Every row is validated before anything is saved. Use --dry-run to see the changes without saving them.")
+	fmt.Println("")
+	fmt.Println("tracks show [song]")
+	fmt.Println("- Shows all of a track's metadata along with its singers and keys. Tracks can be given by exact name or ID.")
+	fmt.Println("")
+	fmt.Println("tracks edit [song] {--duration 4:05} {--year 1985} {--genre a,b} {--explicit=false} {--clean} {--bpm 124} {--key Eb}")
+	fmt.Println("- Changes a track's duration, year, genres, explicit flag, BPM or original key. --clean marks an explicit song the band can perform as a clean edit.")
+	fmt.Println("- With no flags each field is prompted for, leave it blank to keep the current value. With flags only those fields change.")
+	fmt.Println("")
+	fmt.Println("tracks dedupe")
+	fmt.Println("- Finds likely duplicate tracks by title, artist and length, and lets you pick which one to keep for each group. The rest are merged into it.")
+	fmt.Println("")
+	fmt.Println("tracks merge [duplicate] [keep]")
+	fmt.Println("- Merges one track into another, given by ID or exact name. Singers, missing details, saved setlists and build history move to the kept track.")
+	fmt.Println("")
+	fmt.Println("tracks rename [track] [new name] {--artist name}")
+	fmt.Println("- Renames a track, and optionally its artist, updating saved setlists and build history to match.")
+	fmt.Println("")
+	fmt.Println("tracks requires [song] {--add horns,keys} {--remove keys}")
+	fmt.Println("- Records the instruments or players a song can't be played without, or prints them when no flags are given.")
+	fmt.Println("")
+	fmt.Println("doctor")
+	fmt.Println("- Audits the library for tracks missing keys, BPMs or singers, invalid singer keys, duplicates and leftover working rows.")
+	fmt.Println("- Also shows minutes per singer, genre and decade coverage, and whether each combination of singers can fill the longest gig.")
 	fmt.Println("")
 	fmt.Println("singers")
 	fmt.Println("- Searches through all tracks in the singers table and prompts you to enter singer and key info for any songs with no singer listed.")
-	fmt.Println("- Future improvements will add in the feature to access songs that already have singers and keys.")
+	fmt.Println("")
+	fmt.Println("singers edit [song]")
+	fmt.Println("- Shows the singers and keys for a song and lets you change a singer's key or add a new singer.")
+	fmt.Println("- Song titles with spaces must be wrapped in quotes, e.g. ./setlist singers edit \"Mr. Brightside\"")
+	fmt.Println("- The song can also be a track ID, or \"Artist - Title\" when more than one artist has that title.")
+	fmt.Println("")
+	fmt.Println("singers remove [song] [singer]")
+	fmt.Println("- Removes a singer from a song.")
+	fmt.Println("")
+	fmt.Println("singers list {--singer name} {--missing}")
+	fmt.Println("- Lists every song with its singers and keys. Use --singer to only list one singer's songs.")
+	fmt.Println("- Use --missing to list songs with no singers, or with --singer, songs that singer has no key for.")
+	fmt.Println("")
+	fmt.Println("singers show [song]")
+	fmt.Println("- Shows the singers and keys for a song.")
+	fmt.Println("")
+	fmt.Println("singers suggest")
+	fmt.Println("- Suggests a key for every song with no singers, for every singer whose vocal range fits the song's melody range.")
+	fmt.Println("- The suggestions are listed for review before you choose to save all of them, go through them one by one, or cancel.")
+	fmt.Println("")
+	fmt.Println("singers duet add [song] [lead] [singer] {--role duet}")
+	fmt.Println("- Adds a partner to a lead singer's arrangement of a song, in the lead's key. --role describes the part, e.g. harmony.")
+	fmt.Println("- Duets are only built when every singer on them is at the gig, and count toward each singer's spacing and airtime.")
+	fmt.Println("")
+	fmt.Println("singers duet remove [song] [lead] [singer]")
+	fmt.Println("- Takes a partner off a lead singer's arrangement of a song.")
+	fmt.Println("")
+	fmt.Println("ranges {singer|track} {name} {lowest note} {highest note}")
+	fmt.Println("- Stores a singer's comfortable vocal range or a song's melody range, using notes like A2 or C#5 (middle C is C4).")
+	fmt.Println("- Once both are entered, the singers commands suggest the best key for that singer and show the resulting melody range.")
+	fmt.Println("- Running ranges with no arguments lists every singer's vocal range.")
+	fmt.Println("")
+	fmt.Println("profiles {show [name]} {edit [name] {--setting value ...}} {remove [name]}")
+	fmt.Println("- Lists the event profiles, named build presets like wedding, bar and corporate.")
+	fmt.Println("- edit creates or changes a profile. Settings: --description, --duration, --sets, --explicit allow|clean|exclude,")
+	fmt.Println("  --singers, --targets, --tolerance, --lineup, --request-spacing, --min-decade, --max-genre, --no-repeat-genre,")
+	fmt.Println("  --exclude-genres and --min-fast. With no settings given, each one is prompted for. A blank value clears a setting.")
+	fmt.Println("")
+	fmt.Println("genres {map [genre] [bucket]} {unmap [genre]}")
+	fmt.Println("- Lists the genre buckets used by the mix rules, how many songs are in each and the genres without a bucket.")
+	fmt.Println("- map puts every genre containing [genre] in [bucket], e.g. 'genres map \"country\" country'. unmap removes one.")
 	fmt.Println("")
 	fmt.Println("keys {missing}")
 	fmt.Println("- Searches for tracks in the tracks table and prompts you to enter original key info.")
@@ -33,8 +110,60 @@ func RunHelp() {
 	fmt.Println("database")
 	fmt.Println("- Allows for manual access to the database to make changes as needed.\n- This is only advised to those who are comfortable writing SQL commands.")
 	fmt.Println("")
-	fmt.Println("build")
+	fmt.Println("build {--explain} {--explain-json} {--targets Riley=40,Ty=40,Bos=20} {--tolerance 10} {--lineup guitar,bass,drums}")
+	fmt.Println("      {--min-decade 2000s=30} {--max-genre country=2} {--no-repeat-genre} {--exclude-genres metal} {--min-fast 40}")
+	fmt.Println("      {--profile wedding} {--sets 2} {--request-spacing 3} {--start 19:30} {--gap 20} {--hard-stop 23:00}")
+	fmt.Println("      {--time-window slow<20:00}")
 	fmt.Println("- Begins the setlist building process, starting with a questions about set length, requests, and 'Do Not Plays'.\n- Upon successful creation, the setlist will print out in your terminal.")
+	fmt.Println("- Requests and 'Do Not Plays' can be a Spotify playlist link, a CSV/M3U/XSPF/text file, or 'Artist - Title' lines pasted after typing 'paste'.")
+	fmt.Println("- Apple Music and YouTube Music playlists need to be exported to one of those file types first.")
+	fmt.Println("- Use --explain to replace the progress output with a trace of each slot: the songs considered, the rule that")
+	fmt.Println("  rejected each one and the song, singer and key chosen. --explain-json prints the same trace as JSON.")
+	fmt.Println("- Use --targets to give singers a share of the airtime in each set and over the night. Singers without a target share")
+	fmt.Println("  what is left, and --tolerance sets how many percentage points off target a share can be (10 by default).")
+	fmt.Println("- Use --lineup for a reduced lineup. Songs needing instruments or players outside it are left out and listed.")
+	fmt.Println("- Use --min-decade for the smallest share of the night from a decade, --max-genre to cap a genre in each set,")
+	fmt.Println("  --no-repeat-genre to keep a genre from playing twice in a row and --exclude-genres to leave genres out.")
+	fmt.Println("  Genres are matched through the buckets listed by the genres command. --min-fast 40 is an energy target, asking")
+	fmt.Println("  for at least 40% of the night's songs to be fast (120 BPM and up).")
+	fmt.Println("- Explicit songs can be allowed, left out, or allowed only as clean edits. Clean edits need a clean version")
+	fmt.Println("  (tracks edit --clean) and are marked '(clean edit)' in the printed setlist.")
+	fmt.Println("- Use --start 19:30 to print each song's start time, with --gap seconds between songs. --hard-stop 23:00 flags sets")
+	fmt.Println("  still playing then, and --time-window slow<20:00,fast>21:30,country=19:00-21:00 keeps songs to part of the night.")
+	fmt.Println("- Use --profile to start from an event profile. Its duration, singers and explicit policy skip those questions,")
+	fmt.Println("  and its other settings apply unless given as flags. --sets and --request-spacing change the set structure.")
+	fmt.Println("- Once the setlist prints, you can lock songs you like, replace a song with one of several that fit its spot,")
+	fmt.Println("  move songs between sets and regenerate the unlocked songs. Set durations and broken rules are shown after each change.")
+	fmt.Println("")
+	fmt.Println("serve {--addr :8080}")
+	fmt.Println("- Starts an HTTP/JSON API for browsing tracks, editing keys and singers, building setlists and saving them.")
+	fmt.Println("- Builds use the working table, so the server runs one build at a time.")
+	fmt.Println("")
+	fmt.Println("tui")
+	fmt.Println("- Opens a full-screen terminal UI with panes for the build parameters, the library and the generated sets.")
+	fmt.Println("- The library can be searched and filtered by singer, genre and key. Songs can be swapped, moved, locked and regenerated from the keyboard.")
+	fmt.Println("- Broken band rules are highlighted next to the songs that break them.")
+	fmt.Println("")
+	fmt.Println("ui {--addr :8080}")
+	fmt.Println("- Serves a web page for building setlists without the terminal, along with the API.")
+	fmt.Println("- Songs can be dragged to reorder them or move them between sets, and broken band rules are highlighted as you go.")
+	fmt.Println("- Each set can be regenerated on its own and each song can be swapped for another one that fits.")
+	fmt.Println("")
+	fmt.Println("stats {--format text|json} {--top 10}")
+	fmt.Println("- Reports on every finished build: most played songs, each singer's share of the airtime, request frequency,")
+	fmt.Println("  keys and tempos played, and songs that have never been played.")
+	fmt.Println("")
+	fmt.Println("why [song]")
+	fmt.Println("- Explains what happened to a song or request in the last build: where it was placed, or the rules that kept it out.")
+	fmt.Println("")
+	fmt.Println("lint [file] {--duration minutes} {--singers a,b} {--explicit allow|clean|exclude} {--dnp file}")
+	fmt.Println("- Checks a hand-edited setlist (CSV, JSON or 'Song - Singer - Key' lines as printed by build) against the band rules and prints each problem with its line number.")
+	fmt.Println("- --explicit sets the explicit lyrics policy, where clean only allows explicit songs with a clean version marked '(clean edit)'.")
+	fmt.Println("- Exits with 1 if rules are broken and 2 if the file can't be read.")
+	fmt.Println("")
+	fmt.Println("migrate [up|down|status]")
+	fmt.Println("- Upgrades the database schema to the version this binary needs, rolls back the last migration, or lists which migrations have been applied.")
+	fmt.Println("- Other commands refuse to run until the database is up to date.")
 	fmt.Println("")
 	fmt.Println("clear [table]")
 	fmt.Println("- Clears specified table from the database. Use this if you need to reset singers, tracks, or the working table")
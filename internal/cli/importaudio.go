@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	"github.com/rjfeeney/setlist_builder/extract"
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+func RunImportAudio(db *sql.DB, dir string) error {
+	info, statErr := os.Stat(dir)
+	if statErr != nil {
+		return fmt.Errorf("unable to open %s: %v", dir, statErr)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+
+	dbQueries := database.New(db)
+	files, findErr := extract.FindAudioFiles(dir)
+	if findErr != nil {
+		return findErr
+	}
+	if len(files) == 0 {
+		fmt.Printf("No MP3, FLAC or WAV files found in %s\n", dir)
+		return nil
+	}
+	fmt.Printf("Found %d audio files, please be patient as they are analyzed\n", len(files))
+	fmt.Println("")
+
+	added := 0
+	skipped := 0
+	failed := 0
+	for i, file := range files {
+		fmt.Printf("File# %d/%d: %s\n", i+1, len(files), file)
+		data, tagErr := extract.ReadLocalAudioTags(file)
+		if tagErr != nil {
+			fmt.Printf("unable to read %s: %v, skipping...\n", file, tagErr)
+			failed++
+			continue
+		}
+		params := database.GetTrackParams{
+			Name:   data.Name,
+			Artist: data.Artist,
+		}
+		_, getErr := dbQueries.GetTrack(context.Background(), params)
+		if getErr == nil {
+			fmt.Printf("song %v is already in database, skipping...\n", data.Name)
+			skipped++
+			continue
+		} else if getErr != sql.ErrNoRows {
+			fmt.Printf("database error on track %s - %s: %v\n", data.Artist, data.Name, getErr)
+			failed++
+			continue
+		}
+		key, bpm, duration, analysisErr := extract.ExtractTempoKeyAndDuration(file)
+		if analysisErr != nil {
+			fmt.Printf("failed to get key/bpm for %s - %s: %v\n", data.Artist, data.Name, analysisErr)
+			failed++
+			continue
+		}
+		trackParams := database.CreateTrackParams{
+			Name:              data.Name,
+			Artist:            data.Artist,
+			Genre:             data.Genres,
+			DurationInSeconds: int32(duration),
+			Year:              data.Year,
+			Explicit:          false,
+			Bpm:               int32(bpm),
+			OriginalKey:       key,
+		}
+		createErr := dbQueries.CreateTrack(context.Background(), trackParams)
+		if createErr != nil {
+			fmt.Printf("error saving track %s - %s to database: %v\n", data.Artist, data.Name, createErr)
+			failed++
+			continue
+		}
+		fmt.Printf("✅ Added track: %s by %s [%s, %d BPM]\n", data.Name, data.Artist, key, bpm)
+		added++
+	}
+
+	fmt.Println("")
+	fmt.Printf("Tracks added: %d\n", added)
+	fmt.Printf("Tracks already in database: %d\n", skipped)
+	fmt.Printf("Tracks failed: %d\n", failed)
+	fmt.Println("✅ Finished importing audio files.")
+	return nil
+}
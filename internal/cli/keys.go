@@ -39,8 +39,7 @@ func RunMissingKeys(db *sql.DB) error {
 			}
 			params := database.AddOriginalKeyParams{
 				OriginalKey: key,
-				Name:        emptyKeyTrack.Name,
-				Artist:      emptyKeyTrack.Artist,
+				ID:          emptyKeyTrack.ID,
 			}
 			addErr := dbQueries.AddOriginalKey(context.Background(), params)
 			if addErr != nil {
@@ -61,7 +60,7 @@ func RunKeysSearch(db *sql.DB) error {
 	fmt.Print("Please enter the track name you would like to change the key of: ")
 	name, _ := reader.ReadString('\n')
 	name = strings.TrimSpace(name)
-	track, getErr := dbQueries.GetTrackFromName(context.Background(), name)
+	track, getErr := findTrack(dbQueries, name)
 	if getErr != nil {
 		return getErr
 	}
@@ -85,8 +84,7 @@ func RunKeysSearch(db *sql.DB) error {
 	}
 	params := database.AddOriginalKeyParams{
 		OriginalKey: changedToKey,
-		Name:        track.Name,
-		Artist:      track.Artist,
+		ID:          track.ID,
 	}
 	addErr := dbQueries.AddOriginalKey(context.Background(), params)
 	if addErr != nil {
@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/library"
+)
+
+func loadLibrary(dbQueries *database.Queries) ([]library.Entry, error) {
+	tracks, tracksErr := dbQueries.GetAllTracks(context.Background())
+	if tracksErr != nil {
+		return nil, fmt.Errorf("failed to get all tracks: %v", tracksErr)
+	}
+	singers, singersErr := dbQueries.GetAllSingers(context.Background())
+	if singersErr != nil {
+		return nil, fmt.Errorf("failed to get all singers: %v", singersErr)
+	}
+	trackSingers := map[int32][]library.SingerKey{}
+	for _, singer := range singers {
+		trackSingers[singer.TrackID] = append(trackSingers[singer.TrackID], library.SingerKey{Singer: singer.Singer, Key: singer.Key})
+	}
+	entries := []library.Entry{}
+	for _, track := range tracks {
+		entries = append(entries, library.Entry{
+			Name:              track.Name,
+			Artist:            track.Artist,
+			Genre:             track.Genre,
+			DurationInSeconds: track.DurationInSeconds,
+			Year:              track.Year,
+			Explicit:          track.Explicit,
+			CleanVersion:      track.CleanVersion,
+			Bpm:               track.Bpm,
+			OriginalKey:       track.OriginalKey,
+			SpotifyID:         track.SpotifyID.String,
+			ISRC:              track.Isrc.String,
+			Singers:           trackSingers[track.ID],
+		})
+	}
+	return entries, nil
+}
+
+func RunLibraryExport(db *sql.DB, format, output string) error {
+	dbQueries := database.New(db)
+	entries, loadErr := loadLibrary(dbQueries)
+	if loadErr != nil {
+		return loadErr
+	}
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, createErr := os.Create(output)
+		if createErr != nil {
+			return fmt.Errorf("unable to create %s: %v", output, createErr)
+		}
+		defer file.Close()
+		w = file
+	}
+	if writeErr := library.Write(w, format, entries); writeErr != nil {
+		return fmt.Errorf("unable to write library: %v", writeErr)
+	}
+	if output != "" {
+		fmt.Printf("✅ Exported %d tracks to %s\n", len(entries), output)
+	}
+	return nil
+}
+
+func RunLibraryImport(db *sql.DB, path string, dryRun bool) error {
+	format, formatErr := library.FormatFromPath(path)
+	if formatErr != nil {
+		return formatErr
+	}
+	file, openErr := os.Open(path)
+	if openErr != nil {
+		return fmt.Errorf("unable to open %s: %v", path, openErr)
+	}
+	defer file.Close()
+
+	entries, readErr := library.Read(file, format)
+	if readErr == nil {
+		for i := range entries {
+			normalizeEntry(&entries[i])
+		}
+		readErr = library.Validate(entries, ValidateSinger, ValidateKey)
+	}
+	if readErr != nil {
+		fmt.Println("Unable to import library, please fix the following and try again:")
+		fmt.Println(readErr)
+		return fmt.Errorf("%s failed validation", path)
+	}
+
+	dbQueries := database.New(db)
+	current, loadErr := loadLibrary(dbQueries)
+	if loadErr != nil {
+		return loadErr
+	}
+	changes := library.Diff(current, entries)
+	added := 0
+	for _, change := range changes {
+		if change.New {
+			added++
+			fmt.Printf("+ %s - %s\n", change.Entry.Name, change.Entry.Artist)
+			continue
+		}
+		fmt.Printf("~ %s - %s\n", change.Entry.Name, change.Entry.Artist)
+		for _, field := range change.Changes {
+			fmt.Printf("    %s\n", field)
+		}
+	}
+	fmt.Println("")
+	fmt.Printf("New tracks: %d\n", added)
+	fmt.Printf("Updated tracks: %d\n", len(changes)-added)
+	fmt.Printf("Unchanged tracks: %d\n", len(entries)-len(changes))
+	if dryRun {
+		fmt.Println("Dry run, no changes have been made.")
+		return nil
+	}
+	if len(changes) == 0 {
+		fmt.Println("✅ Library is already up to date.")
+		return nil
+	}
+
+	tx, txErr := db.Begin()
+	if txErr != nil {
+		return fmt.Errorf("unable to start transaction: %v", txErr)
+	}
+	defer tx.Rollback()
+	txQueries := dbQueries.WithTx(tx)
+	for _, change := range changes {
+		entry := change.Entry
+		trackParams := database.UpsertTrackParams{
+			Name:              entry.Name,
+			Artist:            entry.Artist,
+			Genre:             entry.Genre,
+			DurationInSeconds: entry.DurationInSeconds,
+			Year:              entry.Year,
+			Explicit:          entry.Explicit,
+			Bpm:               entry.Bpm,
+			OriginalKey:       entry.OriginalKey,
+			CleanVersion:      entry.CleanVersion,
+			SpotifyID:         sql.NullString{String: entry.SpotifyID, Valid: entry.SpotifyID != ""},
+			Isrc:              sql.NullString{String: entry.ISRC, Valid: entry.ISRC != ""},
+		}
+		// library files are matched on name and artist, since IDs differ between databases
+		trackID, upsertErr := txQueries.UpsertTrack(context.Background(), trackParams)
+		if upsertErr != nil {
+			return fmt.Errorf("row %d: unable to save %s - %s: %v", entry.Row, entry.Name, entry.Artist, upsertErr)
+		}
+		for _, singer := range entry.Singers {
+			singerParams := database.UpsertSingerParams{
+				TrackID: trackID,
+				Singer:  singer.Singer,
+				Key:     singer.Key,
+			}
+			if upsertErr := txQueries.UpsertSinger(context.Background(), singerParams); upsertErr != nil {
+				return fmt.Errorf("row %d: unable to save singer %s for %s - %s: %v", entry.Row, singer.Singer, entry.Name, entry.Artist, upsertErr)
+			}
+		}
+	}
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("unable to commit import: %v", commitErr)
+	}
+	fmt.Println("✅ Finished importing library.")
+	return nil
+}
+
+// normalizeEntry matches the casing the singers and keys commands store.
+func normalizeEntry(entry *library.Entry) {
+	for i, singer := range entry.Singers {
+		entry.Singers[i].Singer = Capitalize(strings.ToLower(singer.Singer))
+		entry.Singers[i].Key = Capitalize(strings.ToLower(singer.Key))
+	}
+}
@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+	"github.com/rjfeeney/setlist_builder/internal/sources"
+)
+
+type lintIssue struct {
+	line      int
+	violation service.Violation
+}
+
+// RunLint checks a hand-edited setlist against the band rules and returns how many problems
+// were found. JSON setlists carry their own parameters, which the flags override when given.
+func RunLint(db *sql.DB, path string, duration int, singers string, explicit string, dnp string) (int, error) {
+	f, openErr := os.Open(path)
+	if openErr != nil {
+		return 0, fmt.Errorf("unable to open %s: %v", path, openErr)
+	}
+	defer f.Close()
+	file, readErr := service.ReadSetlistFile(f, service.SetlistFormatFromPath(path))
+	if readErr != nil {
+		return 0, fmt.Errorf("%s: %v", path, readErr)
+	}
+	setlist := file.Setlist
+	if len(setlist.Sets) == 0 {
+		return 0, fmt.Errorf("no songs found in %s", path)
+	}
+	dbQueries := database.New(db)
+	ctx := context.Background()
+	issues := []lintIssue{}
+	unknown := map[[2]int]bool{}
+	addIssue := func(set, position int, rule, message string) {
+		violation := service.Violation{Set: set, Position: position, Rule: rule, Message: message}
+		issues = append(issues, lintIssue{line: file.Line(set, position), violation: violation})
+	}
+
+	fileSingers := []string{}
+	for i := range setlist.Sets {
+		for j := range setlist.Sets[i].Entries {
+			entry := &setlist.Sets[i].Entries[j]
+			singer := strings.ToLower(entry.Singer)
+			if !ValidateSinger(singer) {
+				addIssue(i, j, service.RuleSinger, fmt.Sprintf("invalid singer %s", entry.Singer))
+			} else if !containsString(fileSingers, Capitalize(singer)) {
+				fileSingers = append(fileSingers, Capitalize(singer))
+			}
+			entry.Singer = Capitalize(singer)
+			for k, partner := range entry.Partners {
+				partnerName := strings.ToLower(partner.Singer)
+				if !ValidateSinger(partnerName) {
+					addIssue(i, j, service.RuleSinger, fmt.Sprintf("invalid singer %s", partner.Singer))
+				} else if !containsString(fileSingers, Capitalize(partnerName)) {
+					fileSingers = append(fileSingers, Capitalize(partnerName))
+				}
+				entry.Partners[k].Singer = Capitalize(partnerName)
+			}
+			key := strings.ToLower(entry.Key)
+			if !ValidateKey(key) {
+				addIssue(i, j, "invalid-key", fmt.Sprintf("invalid key %s", entry.Key))
+			}
+			entry.Key = Capitalize(key)
+
+			var track database.Track
+			var getErr error
+			if entry.Artist != "" {
+				params := database.GetTrackParams{
+					Name:   entry.Name,
+					Artist: entry.Artist,
+				}
+				track, getErr = dbQueries.GetTrack(ctx, params)
+			} else {
+				track, getErr = service.TrackFromName(ctx, dbQueries, entry.Name)
+			}
+			var ambiguous *service.AmbiguousTrackError
+			if getErr == sql.ErrNoRows {
+				addIssue(i, j, "unknown-song", fmt.Sprintf("%s is not in the library", entry.Name))
+				unknown[[2]int{i, j}] = true
+				continue
+			} else if errors.As(getErr, &ambiguous) {
+				// picking one of the songs would check the entry against the wrong artist and length
+				artists := []string{}
+				for _, match := range ambiguous.Tracks {
+					artists = append(artists, match.Artist)
+				}
+				addIssue(i, j, "ambiguous-song", fmt.Sprintf("%s is the title of more than one song (%s), so it can't be checked without its artist", entry.Name, strings.Join(artists, ", ")))
+				unknown[[2]int{i, j}] = true
+				continue
+			} else if getErr != nil {
+				return 0, fmt.Errorf("unable to look up %s: %v", entry.Name, getErr)
+			}
+			entry.Name = track.Name
+			entry.Artist = track.Artist
+			entry.DurationInSeconds = track.DurationInSeconds
+			entry.Explicit = track.Explicit
+		}
+	}
+
+	if !file.HasParams || singers != "" {
+		setlist.Params.Singers = fileSingers
+		if singers != "" {
+			setlist.Params.Singers = []string{}
+			for _, singer := range strings.Split(singers, ",") {
+				singer = strings.TrimSpace(strings.ToLower(singer))
+				if !ValidateSinger(singer) {
+					InvalidSingerMessage()
+					return 0, fmt.Errorf("invalid singer %s", singer)
+				}
+				setlist.Params.Singers = append(setlist.Params.Singers, Capitalize(singer))
+			}
+		}
+	}
+	if explicit != "" {
+		policy, policyErr := service.ParseExplicitPolicy(explicit)
+		if policyErr != nil {
+			return 0, policyErr
+		}
+		setlist.Params.SetExplicitPolicy(policy)
+	}
+	if dnp != "" {
+		wd, _ := os.Getwd()
+		spotify := sources.SpotifySource{
+			ClientID:     os.Getenv("SPOTIFY_ID"),
+			ClientSecret: os.Getenv("SPOTIFY_SECRET"),
+			Dir:          wd,
+		}
+		source, sourceErr := sources.FromInput(dnp, spotify)
+		if sourceErr != nil {
+			return 0, sourceErr
+		}
+		candidates, candidatesErr := source.Candidates()
+		if candidatesErr != nil {
+			return 0, candidatesErr
+		}
+		doNotPlays, ids := service.ResolveDoNotPlays(ctx, dbQueries, candidates)
+		for i, dnp := range doNotPlays {
+			setlist.Params.AddDoNotPlay(dnp, ids[i])
+		}
+	}
+	if duration > 0 {
+		lengths := service.SetLengths(int32(duration))
+		if len(lengths) != len(setlist.Sets) {
+			return 0, fmt.Errorf("a %d minute gig has %d sets, but %s has %d", duration, len(lengths), path, len(setlist.Sets))
+		}
+		for i, length := range lengths {
+			setlist.Sets[i].TargetMinutes = length
+		}
+	} else if !file.HasParams {
+		fmt.Println("No --duration given, set lengths will not be checked")
+	}
+
+	library, loadErr := service.LoadLibrary(ctx, dbQueries)
+	if loadErr != nil {
+		return 0, loadErr
+	}
+	for _, violation := range service.Validate(setlist, library) {
+		// unknown and ambiguous songs were already reported, and have no singers to check
+		if unknown[[2]int{violation.Set, violation.Position}] && violation.Rule == service.RuleSinger {
+			continue
+		}
+		issues = append(issues, lintIssue{line: file.Line(violation.Set, violation.Position), violation: violation})
+	}
+
+	sort.SliceStable(issues, func(a, b int) bool {
+		if issues[a].violation.Set != issues[b].violation.Set {
+			return issues[a].violation.Set < issues[b].violation.Set
+		}
+		return issues[a].line < issues[b].line
+	})
+	for _, issue := range issues {
+		if issue.line > 0 {
+			fmt.Printf("%s:%d: %s [%s]\n", path, issue.line, issue.violation, issue.violation.Rule)
+		} else {
+			fmt.Printf("%s: %s [%s]\n", path, issue.violation, issue.violation.Rule)
+		}
+	}
+	if len(issues) == 0 {
+		fmt.Printf("✅ No rule violations found in %s\n", path)
+	} else {
+		fmt.Printf("Found %d rule violations in %s\n", len(issues), path)
+	}
+	return len(issues), nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
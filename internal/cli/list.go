@@ -3,26 +3,276 @@ package cli
 import (
 	"context"
 	"database/sql"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/rjfeeney/setlist_builder/internal/database"
 )
 
-func RunList(db *sql.DB) error {
+// listColumns are the columns list can show, in the order they're listed in help.
+var listColumns = []string{"id", "name", "artist", "genre", "duration", "year", "explicit", "bpm", "key", "melody", "singers", "spotify_id", "isrc"}
+
+// ListOptions are the list flags as typed. Ranges are "low-high" or a single value, and
+// Explicit is "true", "false" or blank for either.
+type ListOptions struct {
+	Text       string
+	Singer     string
+	Key        string
+	Genre      string
+	Bpm        string
+	Year       string
+	Explicit   string
+	MissingKey bool
+	NoSinger   bool
+	Sort       string
+	Descending bool
+	Columns    string
+	Format     string
+}
+
+// parseRange reads "low-high" or a single value meaning exactly that value.
+func parseRange(value string) (string, string, error) {
+	low, high, isRange := strings.Cut(value, "-")
+	if !isRange {
+		high = low
+	}
+	low, high = strings.TrimSpace(low), strings.TrimSpace(high)
+	if low == "" || high == "" {
+		return "", "", fmt.Errorf("invalid range %q, please use low-high, e.g. 100-130", value)
+	}
+	return low, high, nil
+}
+
+func (o ListOptions) filter() (database.TrackFilter, error) {
+	filter := database.TrackFilter{
+		Text:       o.Text,
+		Singer:     o.Singer,
+		Key:        o.Key,
+		Genre:      o.Genre,
+		MissingKey: o.MissingKey,
+		NoSinger:   o.NoSinger,
+		Sort:       o.Sort,
+		Descending: o.Descending,
+	}
+	if o.Key != "" && !ValidateKey(strings.ToLower(o.Key)) {
+		return filter, fmt.Errorf("invalid key %q", o.Key)
+	}
+	if o.Bpm != "" {
+		low, high, rangeErr := parseRange(o.Bpm)
+		if rangeErr != nil {
+			return filter, rangeErr
+		}
+		minBpm, minErr := strconv.Atoi(low)
+		maxBpm, maxErr := strconv.Atoi(high)
+		if minErr != nil || maxErr != nil || minBpm > maxBpm {
+			return filter, fmt.Errorf("invalid bpm range %q, please use low-high, e.g. 100-130", o.Bpm)
+		}
+		filter.MinBpm, filter.MaxBpm = int32(minBpm), int32(maxBpm)
+	}
+	if o.Year != "" {
+		low, high, rangeErr := parseRange(o.Year)
+		if rangeErr != nil {
+			return filter, rangeErr
+		}
+		if !yearPattern.MatchString(low) || !yearPattern.MatchString(high) || low > high {
+			return filter, fmt.Errorf("invalid year range %q, please use low-high, e.g. 1980-1989", o.Year)
+		}
+		filter.MinYear, filter.MaxYear = low, high
+	}
+	if o.Explicit != "" {
+		explicit, parseErr := strconv.ParseBool(o.Explicit)
+		if parseErr != nil {
+			return filter, fmt.Errorf("invalid explicit value %q, please use true or false", o.Explicit)
+		}
+		filter.Explicit = sql.NullBool{Bool: explicit, Valid: true}
+	}
+	if _, ok := database.TrackSortColumns[filter.Sort]; filter.Sort != "" && !ok {
+		return filter, fmt.Errorf("unable to sort by %s, please use one of id, name, artist, duration, year, bpm or key", filter.Sort)
+	}
+	return filter, nil
+}
+
+func (o ListOptions) columns() ([]string, error) {
+	columns := []string{}
+	for _, column := range strings.Split(o.Columns, ",") {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if column == "" {
+			continue
+		}
+		if !containsString(listColumns, column) {
+			return nil, fmt.Errorf("unknown column %s, please choose from %s", column, strings.Join(listColumns, ", "))
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		columns = []string{"name", "artist"}
+	}
+	return columns, nil
+}
+
+func RunList(db *sql.DB, options ListOptions) error {
+	filter, filterErr := options.filter()
+	if filterErr != nil {
+		return filterErr
+	}
+	columns, columnsErr := options.columns()
+	if columnsErr != nil {
+		return columnsErr
+	}
+	if options.Format == "" {
+		options.Format = "table"
+	}
+	if options.Format != "table" && options.Format != "csv" && options.Format != "json" {
+		return fmt.Errorf("invalid format %s, please choose table, csv or json", options.Format)
+	}
 	dbQueries := database.New(db)
-	tracks, tracksErr := dbQueries.GetAllTracks(context.Background())
+	tracks, tracksErr := dbQueries.SearchTracks(context.Background(), filter)
 	if tracksErr != nil {
-		return fmt.Errorf("failed to get all tracks: %v", tracksErr)
+		return fmt.Errorf("failed to search tracks: %v", tracksErr)
+	}
+	singersByTrack := map[int32][]database.GetAllSingersRow{}
+	if containsString(columns, "singers") {
+		assignments, getErr := dbQueries.GetAllSingers(context.Background())
+		if getErr != nil {
+			return fmt.Errorf("failed to get singers: %v", getErr)
+		}
+		for _, assignment := range assignments {
+			singersByTrack[assignment.TrackID] = append(singersByTrack[assignment.TrackID], assignment)
+		}
+	}
+
+	switch options.Format {
+	case "json":
+		return writeTracksJSON(tracks, columns, singersByTrack)
+	case "csv":
+		return writeTracksCSV(tracks, columns, singersByTrack)
+	}
+	if len(tracks) == 0 {
+		fmt.Println("No tracks match.")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := []string{"#"}
+	for _, column := range columns {
+		header = append(header, strings.ToUpper(column))
 	}
-	fmt.Println("Listing all tracks:")
-	maxDuration := 0
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	totalDuration := 0
 	for i, track := range tracks {
-		fmt.Printf("%d. %s - %s\n", (i + 1), track.Name, track.Artist)
-		maxDuration += int(track.DurationInSeconds)
+		row := []string{strconv.Itoa(i + 1)}
+		for _, column := range columns {
+			row = append(row, trackColumn(track, column, singersByTrack[track.ID], ", "))
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+		totalDuration += int(track.DurationInSeconds)
 	}
-	maxDurationMinutes := maxDuration / 60
-	maxDurationSeconds := maxDuration % 60
-	fmt.Println("End of track list.")
-	fmt.Printf("Total track duration: %d minutes, %d seconds.\n", maxDurationMinutes, maxDurationSeconds)
+	w.Flush()
+	fmt.Printf("%d track(s), total duration: %d minutes, %d seconds.\n", len(tracks), totalDuration/60, totalDuration%60)
 	return nil
 }
+
+// trackColumn formats one column of a track for table and CSV output, joining lists with sep.
+func trackColumn(track database.Track, column string, singers []database.GetAllSingersRow, sep string) string {
+	switch column {
+	case "id":
+		return strconv.Itoa(int(track.ID))
+	case "name":
+		return track.Name
+	case "artist":
+		return track.Artist
+	case "genre":
+		return strings.Join(track.Genre, sep)
+	case "duration":
+		return formatSeconds(int(track.DurationInSeconds))
+	case "year":
+		return track.Year
+	case "explicit":
+		return strconv.FormatBool(track.Explicit)
+	case "bpm":
+		return strconv.Itoa(int(track.Bpm))
+	case "key":
+		return track.OriginalKey
+	case "melody":
+		if track.MelodyLow == "" || track.MelodyHigh == "" {
+			return ""
+		}
+		return track.MelodyLow + "-" + track.MelodyHigh
+	case "singers":
+		pairs := []string{}
+		for _, singer := range singers {
+			pairs = append(pairs, singer.Singer+":"+singer.Key)
+		}
+		return strings.Join(pairs, sep)
+	case "spotify_id":
+		return track.SpotifyID.String
+	case "isrc":
+		return track.Isrc.String
+	}
+	return ""
+}
+
+func writeTracksCSV(tracks []database.Track, columns []string, singersByTrack map[int32][]database.GetAllSingersRow) error {
+	w := csv.NewWriter(os.Stdout)
+	if writeErr := w.Write(columns); writeErr != nil {
+		return writeErr
+	}
+	for _, track := range tracks {
+		row := []string{}
+		for _, column := range columns {
+			// lists are separated by ';' like library export, since ',' separates columns
+			row = append(row, trackColumn(track, column, singersByTrack[track.ID], ";"))
+		}
+		if writeErr := w.Write(row); writeErr != nil {
+			return writeErr
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+type listSinger struct {
+	Singer string `json:"singer"`
+	Key    string `json:"key"`
+}
+
+func writeTracksJSON(tracks []database.Track, columns []string, singersByTrack map[int32][]database.GetAllSingersRow) error {
+	rows := []map[string]interface{}{}
+	for _, track := range tracks {
+		row := map[string]interface{}{}
+		for _, column := range columns {
+			switch column {
+			case "id":
+				row[column] = track.ID
+			case "genre":
+				genres := track.Genre
+				if genres == nil {
+					genres = []string{}
+				}
+				row[column] = genres
+			case "duration":
+				row["duration_in_seconds"] = track.DurationInSeconds
+			case "explicit":
+				row[column] = track.Explicit
+			case "bpm":
+				row[column] = track.Bpm
+			case "singers":
+				singers := []listSinger{}
+				for _, singer := range singersByTrack[track.ID] {
+					singers = append(singers, listSinger{Singer: singer.Singer, Key: singer.Key})
+				}
+				row[column] = singers
+			default:
+				row[column] = trackColumn(track, column, nil, "")
+			}
+		}
+		rows = append(rows, row)
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(rows)
+}
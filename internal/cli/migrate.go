@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/rjfeeney/setlist_builder/internal/migrate"
+	"github.com/rjfeeney/setlist_builder/sql/schema"
+)
+
+// CheckSchema refuses to go any further if the database hasn't been migrated to the schema this
+// binary was built with.
+func CheckSchema(db *sql.DB) error {
+	migrations, loadErr := migrate.Load(schema.Migrations)
+	if loadErr != nil {
+		return loadErr
+	}
+	return migrate.Check(db, migrations)
+}
+
+func RunMigrate(db *sql.DB, action string) error {
+	migrations, loadErr := migrate.Load(schema.Migrations)
+	if loadErr != nil {
+		return loadErr
+	}
+	switch action {
+	case "up":
+		done, upErr := migrate.Up(db, migrations)
+		for _, migration := range done {
+			fmt.Printf("✅ Applied %d_%s\n", migration.Version, migration.Name)
+		}
+		if upErr != nil {
+			return upErr
+		}
+		if len(done) == 0 {
+			fmt.Println("Database schema is already up to date")
+		}
+	case "down":
+		migration, downErr := migrate.Down(db, migrations)
+		if downErr != nil {
+			return downErr
+		}
+		if migration == nil {
+			fmt.Println("No migrations to roll back")
+			return nil
+		}
+		fmt.Printf("✅ Rolled back %d_%s\n", migration.Version, migration.Name)
+	case "status":
+		statuses, statusErr := migrate.Statuses(db, migrations)
+		if statusErr != nil {
+			return statusErr
+		}
+		pending := 0
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = "applied " + status.AppliedAt.Format("2006-01-02 15:04")
+			} else {
+				pending++
+			}
+			fmt.Printf("%03d_%-20s %s\n", status.Migration.Version, status.Migration.Name, applied)
+		}
+		if pending == 0 {
+			fmt.Println("✅ Database schema is up to date")
+		} else {
+			fmt.Printf("%d migrations pending, run ./setlist migrate up to apply them\n", pending)
+		}
+	default:
+		return fmt.Errorf("invalid action %s, please use up, down or status", action)
+	}
+	return nil
+}
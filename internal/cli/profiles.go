@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+)
+
+func LoadProfile(db *sql.DB, name string) (*service.Profile, error) {
+	profile, loadErr := service.LoadProfile(context.Background(), database.New(db), name)
+	if loadErr == sql.ErrNoRows {
+		return nil, fmt.Errorf("no profile named %s, use './setlist profiles' to see them", service.NormalizeProfileName(name))
+	}
+	return profile, loadErr
+}
+
+func printProfile(profile *service.Profile) {
+	for _, field := range service.ProfileFields {
+		value := profile.Field(field)
+		if value == "" {
+			continue
+		}
+		fmt.Printf(" - %s: %s\n", field, value)
+	}
+	if profile.Explicit() == "" {
+		fmt.Println(" - explicit: asked at each build")
+	}
+}
+
+func RunListProfiles(db *sql.DB) error {
+	profiles, loadErr := service.LoadProfiles(context.Background(), database.New(db))
+	if loadErr != nil {
+		return loadErr
+	}
+	fmt.Println("Event profiles:")
+	if len(profiles) == 0 {
+		fmt.Println("None, use './setlist profiles edit [name]' to add one")
+	}
+	for _, profile := range profiles {
+		if profile.Description == "" {
+			fmt.Printf(" - %s\n", profile.Name)
+			continue
+		}
+		fmt.Printf(" - %s: %s\n", profile.Name, profile.Description)
+	}
+	return nil
+}
+
+func RunShowProfile(db *sql.DB, name string) error {
+	profile, loadErr := LoadProfile(db, name)
+	if loadErr != nil {
+		return loadErr
+	}
+	fmt.Printf("%s profile:\n", Capitalize(profile.Name))
+	printProfile(profile)
+	return nil
+}
+
+// RunEditProfile changes the settings given in edits, or prompts for every setting when there
+// are none. Profiles that don't exist yet are created.
+func RunEditProfile(db *sql.DB, name string, edits map[string]string) error {
+	dbQueries := database.New(db)
+	profile, loadErr := service.LoadProfile(context.Background(), dbQueries, name)
+	created := loadErr == sql.ErrNoRows
+	if created {
+		profile = &service.Profile{Name: service.NormalizeProfileName(name)}
+	} else if loadErr != nil {
+		return loadErr
+	}
+	if len(edits) == 0 {
+		reader := bufio.NewReader(os.Stdin)
+		fmt.Printf("Editing the %s profile, leave a setting blank to keep it or type 'none' to clear it.\n", profile.Name)
+		for _, field := range service.ProfileFields {
+			for {
+				fmt.Printf("%s [%s]: ", Capitalize(field), profile.Field(field))
+				input, _ := reader.ReadString('\n')
+				input = strings.TrimSpace(input)
+				if input == "" {
+					break
+				}
+				if strings.ToLower(input) == "none" {
+					input = ""
+				}
+				if setErr := profile.Set(field, input); setErr != nil {
+					fmt.Printf("Invalid, %v\n", setErr)
+					continue
+				}
+				break
+			}
+		}
+	} else {
+		var fieldErrs []string
+		for _, field := range service.ProfileFields {
+			value, ok := edits[field]
+			if !ok {
+				continue
+			}
+			if setErr := profile.Set(field, value); setErr != nil {
+				fieldErrs = append(fieldErrs, setErr.Error())
+			}
+		}
+		if len(fieldErrs) > 0 {
+			return fmt.Errorf("%s", strings.Join(fieldErrs, "; "))
+		}
+	}
+	if saveErr := service.SaveProfile(context.Background(), dbQueries, profile); saveErr != nil {
+		return saveErr
+	}
+	if created {
+		fmt.Printf("✅ Created the %s profile\n", profile.Name)
+	} else {
+		fmt.Printf("✅ Updated the %s profile\n", profile.Name)
+	}
+	printProfile(profile)
+	return nil
+}
+
+func RunRemoveProfile(db *sql.DB, name string) error {
+	name = service.NormalizeProfileName(name)
+	removed, removeErr := database.New(db).DeleteProfile(context.Background(), name)
+	if removeErr != nil {
+		return fmt.Errorf("unable to remove the %s profile: %v", name, removeErr)
+	}
+	if removed == 0 {
+		return fmt.Errorf("no profile named %s", name)
+	}
+	fmt.Printf("✅ Removed the %s profile\n", name)
+	return nil
+}
@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/music"
+)
+
+func RunSetSingerRange(db *sql.DB, singer, low, high string) error {
+	singer = strings.TrimSpace(strings.ToLower(singer))
+	if !ValidateSinger(singer) {
+		InvalidSingerMessage()
+		return fmt.Errorf("invalid singer %s", singer)
+	}
+	singer = Capitalize(singer)
+	lowNote, highNote, rangeErr := formatRange(low, high)
+	if rangeErr != nil {
+		return rangeErr
+	}
+	dbQueries := database.New(db)
+	params := database.SetSingerRangeParams{
+		Singer:   singer,
+		LowNote:  lowNote,
+		HighNote: highNote,
+	}
+	if setErr := dbQueries.SetSingerRange(context.Background(), params); setErr != nil {
+		return fmt.Errorf("unable to save vocal range: %v", setErr)
+	}
+	fmt.Printf("✅ Set %s's vocal range to %s-%s\n", singer, lowNote, highNote)
+	return nil
+}
+
+func RunSetMelodyRange(db *sql.DB, song, low, high string) error {
+	lowNote, highNote, rangeErr := formatRange(low, high)
+	if rangeErr != nil {
+		return rangeErr
+	}
+	dbQueries := database.New(db)
+	track, findErr := findTrack(dbQueries, song)
+	if findErr != nil {
+		return findErr
+	}
+	params := database.SetMelodyRangeParams{
+		MelodyLow:  lowNote,
+		MelodyHigh: highNote,
+		ID:         track.ID,
+	}
+	if setErr := dbQueries.SetMelodyRange(context.Background(), params); setErr != nil {
+		return fmt.Errorf("unable to save melody range: %v", setErr)
+	}
+	fmt.Printf("✅ Set the melody range of %s - %s to %s-%s\n", track.Name, track.Artist, lowNote, highNote)
+	return nil
+}
+
+func RunListRanges(db *sql.DB) error {
+	dbQueries := database.New(db)
+	ranges, getErr := dbQueries.GetAllSingerRanges(context.Background())
+	if getErr != nil {
+		return fmt.Errorf("unable to get vocal ranges: %v", getErr)
+	}
+	fmt.Println("Singer vocal ranges:")
+	if len(ranges) == 0 {
+		fmt.Println("None, use './setlist ranges singer [singer] [lowest note] [highest note]' to add one")
+	}
+	for _, singerRange := range ranges {
+		fmt.Printf(" - %s: %s-%s\n", singerRange.Singer, singerRange.LowNote, singerRange.HighNote)
+	}
+	tracks, tracksErr := dbQueries.GetAllTracks(context.Background())
+	if tracksErr != nil {
+		return fmt.Errorf("failed to get all tracks: %v", tracksErr)
+	}
+	withRange := 0
+	for _, track := range tracks {
+		if track.MelodyLow != "" && track.MelodyHigh != "" {
+			withRange++
+		}
+	}
+	fmt.Printf("Tracks with a melody range: %d/%d\n", withRange, len(tracks))
+	return nil
+}
+
+func formatRange(low, high string) (string, string, error) {
+	lowNote, lowErr := music.FormatNote(low)
+	if lowErr != nil {
+		return "", "", lowErr
+	}
+	highNote, highErr := music.FormatNote(high)
+	if highErr != nil {
+		return "", "", highErr
+	}
+	if _, rangeErr := music.ParseRange(lowNote, highNote); rangeErr != nil {
+		return "", "", rangeErr
+	}
+	return lowNote, highNote, nil
+}
+
+// suggestKeyForSinger returns the best key for a singer on a track, or false if the singer's
+// vocal range or the track's melody range hasn't been entered.
+func suggestKeyForSinger(dbQueries *database.Queries, track database.Track, singer string) (music.Suggestion, bool) {
+	if track.MelodyLow == "" || track.MelodyHigh == "" || track.OriginalKey == "" {
+		return music.Suggestion{}, false
+	}
+	melody, melodyErr := music.ParseRange(track.MelodyLow, track.MelodyHigh)
+	if melodyErr != nil {
+		return music.Suggestion{}, false
+	}
+	singerRange, getErr := dbQueries.GetSingerRange(context.Background(), Capitalize(singer))
+	if getErr != nil {
+		return music.Suggestion{}, false
+	}
+	vocalRange, vocalErr := music.ParseRange(singerRange.LowNote, singerRange.HighNote)
+	if vocalErr != nil {
+		return music.Suggestion{}, false
+	}
+	suggestion, suggestErr := music.SuggestKey(track.OriginalKey, melody, vocalRange)
+	if suggestErr != nil {
+		return music.Suggestion{}, false
+	}
+	return suggestion, true
+}
+
+func printSuggestion(singer string, suggestion music.Suggestion) {
+	flats := music.UsesFlats(suggestion.Key)
+	if suggestion.Fits {
+		fmt.Printf("Suggested key for %s: %s (%+d semitones), melody range %s\n", singer, suggestion.Key, suggestion.Semitones, suggestion.Range.String(flats))
+	} else {
+		fmt.Printf("Closest key for %s: %s (%+d semitones), melody range %s does not fully fit their vocal range\n", singer, suggestion.Key, suggestion.Semitones, suggestion.Range.String(flats))
+	}
+}
+
+type keySuggestion struct {
+	track      database.Track
+	singer     string
+	suggestion music.Suggestion
+}
+
+// RunSuggestKeys suggests keys for every song with no singers, for every singer whose range fits
+// the melody, and saves the suggestions once they have been reviewed.
+func RunSuggestKeys(db *sql.DB) error {
+	dbQueries := database.New(db)
+	reader := bufio.NewReader(os.Stdin)
+	ranges, rangesErr := dbQueries.GetAllSingerRanges(context.Background())
+	if rangesErr != nil {
+		return fmt.Errorf("unable to get vocal ranges: %v", rangesErr)
+	}
+	if len(ranges) == 0 {
+		return fmt.Errorf("no vocal ranges entered, please use './setlist ranges singer' first")
+	}
+	tracks, tracksErr := dbQueries.GetTracksWithoutSingers(context.Background())
+	if tracksErr != nil {
+		return fmt.Errorf("failed to get tracks without singers: %v", tracksErr)
+	}
+	fmt.Println("Suggesting keys for tracks with unassigned singers...")
+	suggestions := []keySuggestion{}
+	noMelody := 0
+	for _, track := range tracks {
+		if track.MelodyLow == "" || track.MelodyHigh == "" {
+			noMelody++
+			continue
+		}
+		for _, singerRange := range ranges {
+			suggestion, ok := suggestKeyForSinger(dbQueries, track, singerRange.Singer)
+			if !ok || !suggestion.Fits {
+				continue
+			}
+			suggestions = append(suggestions, keySuggestion{track: track, singer: singerRange.Singer, suggestion: suggestion})
+		}
+	}
+	if noMelody > 0 {
+		fmt.Printf("Skipped %d tracks with no melody range, use './setlist ranges track' to add them\n", noMelody)
+	}
+	if len(suggestions) == 0 {
+		fmt.Println("No suggestions found.")
+		return nil
+	}
+	fmt.Println("")
+	for i, s := range suggestions {
+		fmt.Printf("%d. %s - %s: %s in %s (%+d), melody range %s\n", i+1, s.track.Name, s.track.Artist, s.singer, s.suggestion.Key, s.suggestion.Semitones, s.suggestion.Range.String(music.UsesFlats(s.suggestion.Key)))
+	}
+	fmt.Println("")
+
+	review := false
+	for {
+		fmt.Print("Type 'Y' to save all suggestions, 'review' to go through them one by one, or 'N' to cancel: ")
+		answer, _ := reader.ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer == "n" {
+			fmt.Println("No suggestions saved.")
+			return nil
+		} else if answer == "review" {
+			review = true
+		} else if answer != "y" {
+			fmt.Println("Invalid response, please try again")
+			continue
+		}
+		break
+	}
+
+	saved := 0
+	for _, s := range suggestions {
+		key := s.suggestion.Key
+		if review {
+			fmt.Println("")
+			fmt.Printf("%s - %s\n", s.track.Name, s.track.Artist)
+			printSuggestion(s.singer, s.suggestion)
+			prompt := fmt.Sprintf("Hit enter to accept %s, type another key to override, or type 'skip': ", key)
+			fmt.Print(prompt)
+			answer, _ := reader.ReadString('\n')
+			answer = strings.TrimSpace(strings.ToLower(answer))
+			if answer == "skip" {
+				continue
+			}
+			if answer != "" {
+				if !ValidateKey(answer) {
+					key = promptKey(reader, "Please enter a valid key (leaving blank will accept the suggestion): ", key)
+				} else {
+					key = Capitalize(answer)
+				}
+			}
+		}
+		params := database.AddToSingersParams{
+			TrackID: s.track.ID,
+			Singer:  s.singer,
+			Key:     key,
+		}
+		if addErr := dbQueries.AddToSingers(context.Background(), params); addErr != nil {
+			return fmt.Errorf("error adding singer to database: %v", addErr)
+		}
+		saved++
+	}
+	fmt.Printf("✅ Saved %d suggested keys.\n", saved)
+	return nil
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/server"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+)
+
+func newServer(db *sql.DB) *server.Server {
+	builder := service.NewBuilder(db, io.Discard)
+	return server.New(database.New(db), builder.BuildFromInput)
+}
+
+func RunServe(db *sql.DB, addr string) error {
+	srv := newServer(db)
+	fmt.Printf("Serving the setlist API on %s, press Ctrl+C to stop\n", addr)
+	if err := http.ListenAndServe(addr, srv); err != nil {
+		return fmt.Errorf("server stopped: %v", err)
+	}
+	return nil
+}
+
+func RunUI(db *sql.DB, addr string) error {
+	srv := newServer(db)
+	srv.ServeUI()
+	url := "http://" + addr
+	if strings.HasPrefix(addr, ":") {
+		url = "http://localhost" + addr
+	}
+	fmt.Printf("Open %s in your browser to build setlists, press Ctrl+C to stop\n", url)
+	if err := http.ListenAndServe(addr, srv); err != nil {
+		return fmt.Errorf("server stopped: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,349 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/constants"
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+)
+
+func printSingerAssignments(assignments []database.Singer, parts []database.SingerPart) {
+	if len(assignments) == 0 {
+		fmt.Println("No singers assigned")
+		return
+	}
+	for _, assignment := range assignments {
+		partners := []string{}
+		for _, part := range parts {
+			if part.Lead == assignment.Singer {
+				partners = append(partners, fmt.Sprintf("%s (%s)", part.Singer, part.Role))
+			}
+		}
+		if len(partners) == 0 {
+			fmt.Printf(" - %s (%s)\n", assignment.Singer, assignment.Key)
+			continue
+		}
+		fmt.Printf(" - %s (%s) leading, with %s\n", assignment.Singer, assignment.Key, strings.Join(partners, " & "))
+	}
+}
+
+// parseSingerName validates a singer typed on the command line and returns it capitalized the
+// way singers are stored.
+func parseSingerName(singer string) (string, error) {
+	singer = strings.TrimSpace(strings.ToLower(singer))
+	if !ValidateSinger(singer) {
+		InvalidSingerMessage()
+		return "", fmt.Errorf("invalid singer %s", singer)
+	}
+	return Capitalize(singer), nil
+}
+
+// promptKey asks for a key until a valid one is entered. A blank answer returns defaultKey.
+func promptKey(reader *bufio.Reader, prompt, defaultKey string) string {
+	for {
+		fmt.Print(prompt)
+		keyInput, _ := reader.ReadString('\n')
+		keyInput = strings.TrimSpace(strings.ToLower(keyInput))
+		if keyInput == "" {
+			return defaultKey
+		}
+		if !ValidateKey(keyInput) {
+			fmt.Println("")
+			fmt.Println("Invalid key, please choose a valid key from the list:")
+			for _, key := range constants.ValidKeys {
+				key = Capitalize(key)
+				fmt.Print(key + ", ")
+			}
+			fmt.Println("")
+			continue
+		}
+		return Capitalize(keyInput)
+	}
+}
+
+func RunShowSingers(db *sql.DB, song string) error {
+	dbQueries := database.New(db)
+	track, findErr := findTrack(dbQueries, song)
+	if findErr != nil {
+		return findErr
+	}
+	assignments, getErr := dbQueries.GetSingersForTrack(context.Background(), track.ID)
+	if getErr != nil {
+		return fmt.Errorf("unable to get singers for %s: %v", track.Name, getErr)
+	}
+	parts, partsErr := dbQueries.GetSingerParts(context.Background(), track.ID)
+	if partsErr != nil {
+		return fmt.Errorf("unable to get duet parts for %s: %v", track.Name, partsErr)
+	}
+	fmt.Printf("%s - %s (original key: %s)\n", track.Name, track.Artist, track.OriginalKey)
+	printSingerAssignments(assignments, parts)
+	return nil
+}
+
+func RunEditSingers(db *sql.DB, song string) error {
+	dbQueries := database.New(db)
+	reader := bufio.NewReader(os.Stdin)
+	track, findErr := findTrack(dbQueries, song)
+	if findErr != nil {
+		return findErr
+	}
+	for {
+		assignments, getErr := dbQueries.GetSingersForTrack(context.Background(), track.ID)
+		if getErr != nil {
+			return fmt.Errorf("unable to get singers for %s: %v", track.Name, getErr)
+		}
+		parts, partsErr := dbQueries.GetSingerParts(context.Background(), track.ID)
+		if partsErr != nil {
+			return fmt.Errorf("unable to get duet parts for %s: %v", track.Name, partsErr)
+		}
+		fmt.Println("")
+		fmt.Printf("Current singers for %s by %s:\n", track.Name, track.Artist)
+		printSingerAssignments(assignments, parts)
+		fmt.Println("")
+		fmt.Print("Enter a singer to change their key (new singers will be added), or hit enter to finish: ")
+		singerInput, _ := reader.ReadString('\n')
+		singerInput = strings.TrimSpace(strings.ToLower(singerInput))
+		if singerInput == "" {
+			break
+		}
+		if !ValidateSinger(singerInput) {
+			InvalidSingerMessage()
+			continue
+		}
+		singer := Capitalize(singerInput)
+		var current *database.Singer
+		for i := range assignments {
+			if assignments[i].Singer == singer {
+				current = &assignments[i]
+				break
+			}
+		}
+		if current == nil {
+			prompt := fmt.Sprintf("Please enter the key that %s sings %s in (leaving blank will keep the song in its original key of %s): ", singer, track.Name, track.OriginalKey)
+			defaultKey := Capitalize(track.OriginalKey)
+			if suggestion, ok := suggestKeyForSinger(dbQueries, track, singer); ok {
+				printSuggestion(singer, suggestion)
+				prompt = fmt.Sprintf("Please enter the key that %s sings %s in (leaving blank will use the suggested key of %s): ", singer, track.Name, suggestion.Key)
+				defaultKey = suggestion.Key
+			}
+			key := promptKey(reader, prompt, defaultKey)
+			addParams := database.AddToSingersParams{
+				TrackID: track.ID,
+				Singer:  singer,
+				Key:     key,
+			}
+			if addErr := dbQueries.AddToSingers(context.Background(), addParams); addErr != nil {
+				return fmt.Errorf("error adding singer to database: %v", addErr)
+			}
+			fmt.Printf("✅ Added %s in %s\n", singer, key)
+			continue
+		}
+		prompt := fmt.Sprintf("Please enter the new key for %s (leaving blank will keep %s): ", singer, current.Key)
+		key := promptKey(reader, prompt, current.Key)
+		updateParams := database.UpdateSingerKeyParams{
+			Key:     key,
+			TrackID: track.ID,
+			Singer:  singer,
+		}
+		if updateErr := dbQueries.UpdateSingerKey(context.Background(), updateParams); updateErr != nil {
+			return fmt.Errorf("error updating singer key: %v", updateErr)
+		}
+		fmt.Printf("✅ Updated %s to %s\n", singer, key)
+	}
+	fmt.Println("✅ Finished editing singers.")
+	return nil
+}
+
+func RunRemoveSinger(db *sql.DB, song, singer string) error {
+	singer = strings.TrimSpace(strings.ToLower(singer))
+	if !ValidateSinger(singer) {
+		InvalidSingerMessage()
+		return fmt.Errorf("invalid singer %s", singer)
+	}
+	singer = Capitalize(singer)
+	dbQueries := database.New(db)
+	track, findErr := findTrack(dbQueries, song)
+	if findErr != nil {
+		return findErr
+	}
+	params := database.RemoveSingerParams{
+		TrackID: track.ID,
+		Singer:  singer,
+	}
+	removed, removeErr := dbQueries.RemoveSinger(context.Background(), params)
+	if removeErr != nil {
+		return fmt.Errorf("error removing singer: %v", removeErr)
+	}
+	if removed == 0 {
+		return fmt.Errorf("%s is not assigned to %s", singer, track.Name)
+	}
+	fmt.Printf("✅ Removed %s from %s - %s\n", singer, track.Name, track.Artist)
+	return nil
+}
+
+// RunAddDuetPart adds singer as a partner on lead's arrangement of a song, so the song is only
+// picked with lead when singer is at the gig too. Adding an existing partner changes their role.
+func RunAddDuetPart(db *sql.DB, song, lead, singer, role string) error {
+	lead, leadErr := parseSingerName(lead)
+	if leadErr != nil {
+		return leadErr
+	}
+	singer, singerErr := parseSingerName(singer)
+	if singerErr != nil {
+		return singerErr
+	}
+	if lead == singer {
+		return fmt.Errorf("%s can't sing a duet with themselves", lead)
+	}
+	role = strings.TrimSpace(strings.ToLower(role))
+	if role == "" {
+		role = service.DefaultRole
+	}
+	dbQueries := database.New(db)
+	track, findErr := findTrack(dbQueries, song)
+	if findErr != nil {
+		return findErr
+	}
+	assignments, getErr := dbQueries.GetSingersForTrack(context.Background(), track.ID)
+	if getErr != nil {
+		return fmt.Errorf("unable to get singers for %s: %v", track.Name, getErr)
+	}
+	hasKey := false
+	for _, assignment := range assignments {
+		if assignment.Singer == lead {
+			hasKey = true
+			break
+		}
+	}
+	if !hasKey {
+		return fmt.Errorf("%s does not have a key for %s yet, add one with ./setlist singers edit [song] first", lead, track.Name)
+	}
+	params := database.AddSingerPartParams{
+		TrackID: track.ID,
+		Lead:    lead,
+		Singer:  singer,
+		Role:    role,
+	}
+	if addErr := dbQueries.AddSingerPart(context.Background(), params); addErr != nil {
+		return fmt.Errorf("error adding duet part: %v", addErr)
+	}
+	fmt.Printf("✅ %s now sings %s - %s with %s (%s)\n", lead, track.Name, track.Artist, singer, role)
+	return nil
+}
+
+func RunRemoveDuetPart(db *sql.DB, song, lead, singer string) error {
+	lead, leadErr := parseSingerName(lead)
+	if leadErr != nil {
+		return leadErr
+	}
+	singer, singerErr := parseSingerName(singer)
+	if singerErr != nil {
+		return singerErr
+	}
+	dbQueries := database.New(db)
+	track, findErr := findTrack(dbQueries, song)
+	if findErr != nil {
+		return findErr
+	}
+	params := database.RemoveSingerPartParams{
+		TrackID: track.ID,
+		Lead:    lead,
+		Singer:  singer,
+	}
+	removed, removeErr := dbQueries.RemoveSingerPart(context.Background(), params)
+	if removeErr != nil {
+		return fmt.Errorf("error removing duet part: %v", removeErr)
+	}
+	if removed == 0 {
+		return fmt.Errorf("%s does not sing %s with %s", lead, track.Name, singer)
+	}
+	fmt.Printf("✅ Removed %s from %s's part on %s - %s\n", singer, lead, track.Name, track.Artist)
+	return nil
+}
+
+func RunListSingers(db *sql.DB, singer string, missing bool) error {
+	dbQueries := database.New(db)
+	if singer != "" {
+		singer = strings.TrimSpace(strings.ToLower(singer))
+		if !ValidateSinger(singer) {
+			InvalidSingerMessage()
+			return fmt.Errorf("invalid singer %s", singer)
+		}
+		singer = Capitalize(singer)
+	}
+
+	if missing {
+		var tracks []database.Track
+		if singer == "" {
+			fmt.Println("Listing all tracks with no singers:")
+			var getErr error
+			tracks, getErr = dbQueries.GetTracksWithoutSingers(context.Background())
+			if getErr != nil {
+				return fmt.Errorf("failed to get tracks without singers: %v", getErr)
+			}
+		} else {
+			fmt.Printf("Listing all tracks %s has no key for:\n", singer)
+			allTracks, tracksErr := dbQueries.GetAllTracks(context.Background())
+			if tracksErr != nil {
+				return fmt.Errorf("failed to get all tracks: %v", tracksErr)
+			}
+			assignments, getErr := dbQueries.GetSingerAssignments(context.Background(), singer)
+			if getErr != nil {
+				return fmt.Errorf("failed to get songs for %s: %v", singer, getErr)
+			}
+			assigned := map[int32]bool{}
+			for _, assignment := range assignments {
+				assigned[assignment.TrackID] = true
+			}
+			for _, track := range allTracks {
+				if !assigned[track.ID] {
+					tracks = append(tracks, track)
+				}
+			}
+		}
+		for i, track := range tracks {
+			fmt.Printf("%d. %s - %s\n", i+1, track.Name, track.Artist)
+		}
+		fmt.Printf("Total tracks: %d\n", len(tracks))
+		return nil
+	}
+
+	if singer != "" {
+		assignments, getErr := dbQueries.GetSingerAssignments(context.Background(), singer)
+		if getErr != nil {
+			return fmt.Errorf("failed to get songs for %s: %v", singer, getErr)
+		}
+		fmt.Printf("Listing all songs for %s:\n", singer)
+		for i, assignment := range assignments {
+			fmt.Printf("%d. %s - %s (%s)\n", i+1, assignment.Name, assignment.Artist, assignment.Key)
+		}
+		fmt.Printf("Total songs: %d\n", len(assignments))
+		return nil
+	}
+
+	assignments, getErr := dbQueries.GetAllSingers(context.Background())
+	if getErr != nil {
+		return fmt.Errorf("failed to get all singers: %v", getErr)
+	}
+	fmt.Println("Listing all singer assignments:")
+	count := 0
+	for i := 0; i < len(assignments); {
+		trackID := assignments[i].TrackID
+		song := assignments[i].Name
+		artist := assignments[i].Artist
+		singers := []string{}
+		for ; i < len(assignments) && assignments[i].TrackID == trackID; i++ {
+			singers = append(singers, fmt.Sprintf("%s (%s)", assignments[i].Singer, assignments[i].Key))
+		}
+		count++
+		fmt.Printf("%d. %s - %s: %s\n", count, song, artist, strings.Join(singers, ", "))
+	}
+	fmt.Printf("Total songs with singers: %d\n", count)
+	return nil
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+)
+
+func printCountStats(title string, counts []service.CountStat, top int) {
+	fmt.Println(title)
+	if len(counts) == 0 {
+		fmt.Println("   None yet")
+	}
+	for i, count := range counts {
+		if i == top {
+			fmt.Printf("   ... and %d more\n", len(counts)-top)
+			break
+		}
+		fmt.Printf("   - %s: %d\n", count.Value, count.Count)
+	}
+	fmt.Println("")
+}
+
+// RunStats reports on every build recorded by the build command. top limits how many rows of
+// each list are printed as text, JSON output always includes everything.
+func RunStats(db *sql.DB, format string, top int) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %s, please choose text or json", format)
+	}
+	if top < 1 {
+		return fmt.Errorf("invalid --top %d, please use a number greater than 0", top)
+	}
+	dbQueries := database.New(db)
+	setlists, historyErr := service.LoadBuildHistory(context.Background(), dbQueries)
+	if historyErr != nil {
+		return fmt.Errorf("unable to load build history: %v", historyErr)
+	}
+	tracks, tracksErr := dbQueries.GetAllTracks(context.Background())
+	if tracksErr != nil {
+		return fmt.Errorf("failed to get all tracks: %v", tracksErr)
+	}
+	stats := service.ComputeStats(setlists, tracks)
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+
+	if stats.Builds == 0 {
+		fmt.Println("No builds have been recorded yet, stats are collected every time the build command finishes.")
+		return nil
+	}
+	fmt.Printf("Stats from %d recorded build(s)\n", stats.Builds)
+	fmt.Println("")
+	fmt.Println("Most played songs:")
+	for i, song := range stats.Songs {
+		if i == top {
+			fmt.Printf("   ... and %d more\n", len(stats.Songs)-top)
+			break
+		}
+		fmt.Printf("   %d. %s - %s: %d\n", i+1, song.Name, song.Artist, song.Plays)
+	}
+	fmt.Println("")
+	fmt.Println("Airtime per singer:")
+	for _, singer := range stats.Singers {
+		fmt.Printf("   - %s: %.1f%% (%d songs, %s over %d gig(s))\n", singer.Singer, singer.Share, singer.Songs, formatSeconds(singer.Seconds), singer.Gigs)
+	}
+	fmt.Println("")
+	fmt.Println("Most requested songs:")
+	if len(stats.Requests) == 0 {
+		fmt.Println("   None yet")
+	}
+	for i, request := range stats.Requests {
+		if i == top {
+			fmt.Printf("   ... and %d more\n", len(stats.Requests)-top)
+			break
+		}
+		name := request.Name
+		if request.Artist != "" {
+			name += " - " + request.Artist
+		}
+		fmt.Printf("   %d. %s: requested %d time(s), played %d\n", i+1, name, request.Requested, request.Played)
+	}
+	fmt.Println("")
+	printCountStats("Keys played:", stats.Keys, top)
+	printCountStats("Tempos played (BPM):", stats.Bpms, top)
+	fmt.Printf("Never played: %d song(s)\n", len(stats.NeverPlayed))
+	for i, track := range stats.NeverPlayed {
+		if i == top {
+			fmt.Printf("   ... and %d more\n", len(stats.NeverPlayed)-top)
+			break
+		}
+		fmt.Printf("   - %s - %s\n", track.Name, track.Artist)
+	}
+	return nil
+}
@@ -0,0 +1,447 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+)
+
+// findTrack looks a track up by ID when given a number, otherwise by name. A number that isn't
+// an ID is tried as a title, for songs like "1999".
+func findTrack(dbQueries *database.Queries, track string) (database.Track, error) {
+	id, convErr := strconv.Atoi(track)
+	if convErr != nil {
+		return findTrackByName(dbQueries, track)
+	}
+	found, getErr := dbQueries.GetTrackByID(context.Background(), int32(id))
+	if getErr == sql.ErrNoRows {
+		if byName, nameErr := service.TrackFromName(context.Background(), dbQueries, track); nameErr == nil {
+			return byName, nil
+		}
+		return found, fmt.Errorf("no track with ID %d was found in the database", id)
+	} else if getErr != nil {
+		return found, fmt.Errorf("unable to look up track %d: %v", id, getErr)
+	}
+	return found, nil
+}
+
+// findTrackByName looks a track up by its exact title, or "Artist - Title" when more than one
+// artist has the title.
+func findTrackByName(dbQueries *database.Queries, song string) (database.Track, error) {
+	track, getErr := service.TrackFromName(context.Background(), dbQueries, song)
+	var ambiguous *service.AmbiguousTrackError
+	if getErr == sql.ErrNoRows {
+		return track, fmt.Errorf("%s was not found in the database, note that spelling must be exact (but it is not case sensitive), or use the track ID", song)
+	} else if errors.As(getErr, &ambiguous) {
+		return track, getErr
+	} else if getErr != nil {
+		return track, fmt.Errorf("unable to look up %s: %v", song, getErr)
+	}
+	return track, nil
+}
+
+func printTrackSummary(track database.Track) {
+	fmt.Printf(" #%d %s - %s (%s)", track.ID, track.Name, track.Artist, formatSeconds(int(track.DurationInSeconds)))
+	if track.SpotifyID.Valid {
+		fmt.Printf(" spotify:%s", track.SpotifyID.String)
+	}
+	fmt.Println("")
+}
+
+// mergeTracks merges each duplicate into keep in a single transaction.
+func mergeTracks(db *sql.DB, duplicates []database.Track, keep database.Track) error {
+	tx, txErr := db.Begin()
+	if txErr != nil {
+		return fmt.Errorf("unable to start transaction: %v", txErr)
+	}
+	defer tx.Rollback()
+	txQueries := database.New(db).WithTx(tx)
+	changed := 0
+	for _, duplicate := range duplicates {
+		merged, setlists, mergeErr := service.MergeTracks(context.Background(), txQueries, duplicate, keep)
+		if mergeErr != nil {
+			return mergeErr
+		}
+		keep = merged
+		changed += setlists
+	}
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("unable to commit merge: %v", commitErr)
+	}
+	for _, duplicate := range duplicates {
+		fmt.Printf("✅ Merged %s - %s into %s - %s\n", duplicate.Name, duplicate.Artist, keep.Name, keep.Artist)
+	}
+	if changed > 0 {
+		fmt.Printf("Updated %d saved setlist(s).\n", changed)
+	}
+	return nil
+}
+
+func RunTracksDedupe(db *sql.DB) error {
+	dbQueries := database.New(db)
+	tracks, tracksErr := dbQueries.GetAllTracks(context.Background())
+	if tracksErr != nil {
+		return fmt.Errorf("failed to get all tracks: %v", tracksErr)
+	}
+	groups := service.FindDuplicates(tracks)
+	if len(groups) == 0 {
+		fmt.Println("✅ No likely duplicates found.")
+		return nil
+	}
+	fmt.Printf("Found %d group(s) of likely duplicates.\n", len(groups))
+	reader := bufio.NewReader(os.Stdin)
+	for _, group := range groups {
+		fmt.Println("")
+		for _, track := range group {
+			printTrackSummary(track)
+		}
+		for {
+			fmt.Print("Enter the ID of the track to keep, the rest will be merged into it (leave blank to skip): ")
+			input, _ := reader.ReadString('\n')
+			input = strings.TrimSpace(input)
+			if input == "" {
+				fmt.Println("Skipped.")
+				break
+			}
+			keepIndex := -1
+			for i, track := range group {
+				if strings.TrimPrefix(input, "#") == strconv.Itoa(int(track.ID)) {
+					keepIndex = i
+				}
+			}
+			if keepIndex == -1 {
+				fmt.Println("Invalid, please enter one of the IDs listed above")
+				continue
+			}
+			duplicates := append([]database.Track{}, group[:keepIndex]...)
+			duplicates = append(duplicates, group[keepIndex+1:]...)
+			if mergeErr := mergeTracks(db, duplicates, group[keepIndex]); mergeErr != nil {
+				return mergeErr
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func RunTracksMerge(db *sql.DB, duplicateArg, keepArg string) error {
+	dbQueries := database.New(db)
+	duplicate, findErr := findTrack(dbQueries, duplicateArg)
+	if findErr != nil {
+		return findErr
+	}
+	keep, findErr := findTrack(dbQueries, keepArg)
+	if findErr != nil {
+		return findErr
+	}
+	return mergeTracks(db, []database.Track{duplicate}, keep)
+}
+
+func RunTracksRename(db *sql.DB, trackArg, name, artist string) error {
+	dbQueries := database.New(db)
+	track, findErr := findTrack(dbQueries, trackArg)
+	if findErr != nil {
+		return findErr
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("the new name can't be blank")
+	}
+	if artist == "" {
+		artist = track.Artist
+	}
+	tx, txErr := db.Begin()
+	if txErr != nil {
+		return fmt.Errorf("unable to start transaction: %v", txErr)
+	}
+	defer tx.Rollback()
+	renamed, changed, renameErr := service.RenameTrack(context.Background(), dbQueries.WithTx(tx), track, name, artist)
+	if renameErr != nil {
+		return renameErr
+	}
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("unable to commit rename: %v", commitErr)
+	}
+	fmt.Printf("✅ Renamed %s - %s to %s - %s\n", track.Name, track.Artist, renamed.Name, renamed.Artist)
+	if changed > 0 {
+		fmt.Printf("Updated %d saved setlist(s).\n", changed)
+	}
+	return nil
+}
+
+// trackEditFields are the fields tracks edit can change, in the order they are prompted for.
+var trackEditFields = []string{"duration", "year", "genre", "explicit", "clean", "bpm", "key"}
+
+var yearPattern = regexp.MustCompile(`^\d{4}$`)
+
+func trackFieldValue(track database.Track, field string) string {
+	switch field {
+	case "duration":
+		return formatSeconds(int(track.DurationInSeconds))
+	case "year":
+		return track.Year
+	case "genre":
+		return strings.Join(track.Genre, ", ")
+	case "explicit":
+		return strconv.FormatBool(track.Explicit)
+	case "clean":
+		return strconv.FormatBool(track.CleanVersion)
+	case "bpm":
+		return strconv.Itoa(int(track.Bpm))
+	case "key":
+		return track.OriginalKey
+	}
+	return ""
+}
+
+// setTrackField validates value and stores it in the given field of track.
+func setTrackField(track *database.Track, field, value string) error {
+	value = strings.TrimSpace(value)
+	switch field {
+	case "duration":
+		seconds, parseErr := parseDuration(value)
+		if parseErr != nil {
+			return parseErr
+		}
+		track.DurationInSeconds = seconds
+	case "year":
+		if !yearPattern.MatchString(value) {
+			return fmt.Errorf("invalid year %q, please use a four digit year like 1985", value)
+		}
+		track.Year = value
+	case "genre":
+		genres := []string{}
+		for _, genre := range strings.Split(value, ",") {
+			genre = strings.ToLower(strings.TrimSpace(genre))
+			if genre != "" && !containsString(genres, genre) {
+				genres = append(genres, genre)
+			}
+		}
+		track.Genre = genres
+	case "explicit":
+		switch strings.ToLower(value) {
+		case "true", "yes", "y":
+			track.Explicit = true
+		case "false", "no", "n":
+			track.Explicit = false
+		default:
+			return fmt.Errorf("invalid explicit value %q, please use true or false", value)
+		}
+	case "clean":
+		switch strings.ToLower(value) {
+		case "true", "yes", "y":
+			track.CleanVersion = true
+		case "false", "no", "n":
+			track.CleanVersion = false
+		default:
+			return fmt.Errorf("invalid clean value %q, please use true or false", value)
+		}
+	case "bpm":
+		bpm, convErr := strconv.Atoi(value)
+		if convErr != nil || bpm < 1 || bpm > 300 {
+			return fmt.Errorf("invalid bpm %q, please use a number between 1 and 300", value)
+		}
+		track.Bpm = int32(bpm)
+	case "key":
+		key := strings.ToLower(value)
+		if !ValidateKey(key) {
+			return fmt.Errorf("invalid key %q", value)
+		}
+		track.OriginalKey = Capitalize(key)
+	default:
+		return fmt.Errorf("unknown field %s", field)
+	}
+	return nil
+}
+
+// parseDuration reads a duration given as seconds ("245") or minutes and seconds ("4:05").
+func parseDuration(value string) (int32, error) {
+	invalid := fmt.Errorf("invalid duration %q, please use seconds (245) or minutes and seconds (4:05)", value)
+	minutes, seconds, hasMinutes := strings.Cut(value, ":")
+	if !hasMinutes {
+		minutes, seconds = "0", value
+	}
+	m, minutesErr := strconv.Atoi(minutes)
+	s, secondsErr := strconv.Atoi(seconds)
+	if minutesErr != nil || secondsErr != nil || m < 0 || s < 0 || (hasMinutes && s > 59) {
+		return 0, invalid
+	}
+	total := m*60 + s
+	if total <= 0 || total > 3600 {
+		return 0, invalid
+	}
+	return int32(total), nil
+}
+
+func RunTracksShow(db *sql.DB, song string) error {
+	dbQueries := database.New(db)
+	track, findErr := findTrack(dbQueries, song)
+	if findErr != nil {
+		return findErr
+	}
+	assignments, getErr := dbQueries.GetSingersForTrack(context.Background(), track.ID)
+	if getErr != nil {
+		return fmt.Errorf("unable to get singers for %s: %v", track.Name, getErr)
+	}
+	parts, partsErr := dbQueries.GetSingerParts(context.Background(), track.ID)
+	if partsErr != nil {
+		return fmt.Errorf("unable to get duet parts for %s: %v", track.Name, partsErr)
+	}
+	requirements, requirementsErr := dbQueries.GetTrackRequirements(context.Background(), track.ID)
+	if requirementsErr != nil {
+		return fmt.Errorf("unable to get requirements for %s: %v", track.Name, requirementsErr)
+	}
+	notSet := func(value string) string {
+		if value == "" {
+			return "not set"
+		}
+		return value
+	}
+	fmt.Printf("%s - %s (ID %d)\n", track.Name, track.Artist, track.ID)
+	fmt.Printf("Duration: %s\n", formatSeconds(int(track.DurationInSeconds)))
+	fmt.Printf("Year: %s\n", notSet(track.Year))
+	fmt.Printf("Genre: %s\n", notSet(strings.Join(track.Genre, ", ")))
+	fmt.Printf("Explicit: %t\n", track.Explicit)
+	if track.Explicit {
+		fmt.Printf("Clean version: %t\n", track.CleanVersion)
+	}
+	if track.Bpm == 0 {
+		fmt.Println("BPM: not set")
+	} else {
+		fmt.Printf("BPM: %d\n", track.Bpm)
+	}
+	fmt.Printf("Original key: %s\n", notSet(track.OriginalKey))
+	if track.MelodyLow != "" && track.MelodyHigh != "" {
+		fmt.Printf("Melody range: %s - %s\n", track.MelodyLow, track.MelodyHigh)
+	} else {
+		fmt.Println("Melody range: not set")
+	}
+	fmt.Printf("Spotify ID: %s\n", notSet(track.SpotifyID.String))
+	fmt.Printf("ISRC: %s\n", notSet(track.Isrc.String))
+	if len(requirements) == 0 {
+		fmt.Println("Requires: none")
+	} else {
+		fmt.Printf("Requires: %s\n", strings.Join(requirements, ", "))
+	}
+	fmt.Println("Singers:")
+	printSingerAssignments(assignments, parts)
+	return nil
+}
+
+// RunTracksRequires adds and removes the instruments or players a song can't be played without,
+// then prints what it needs. Builds with a lineup leave out songs needing anyone not in it.
+func RunTracksRequires(db *sql.DB, song string, add, remove []string) error {
+	dbQueries := database.New(db)
+	track, findErr := findTrack(dbQueries, song)
+	if findErr != nil {
+		return findErr
+	}
+	ctx := context.Background()
+	for _, instrument := range add {
+		params := database.AddTrackRequirementParams{
+			TrackID:    track.ID,
+			Instrument: instrument,
+		}
+		if addErr := dbQueries.AddTrackRequirement(ctx, params); addErr != nil {
+			return fmt.Errorf("error adding %s to %s: %v", instrument, track.Name, addErr)
+		}
+		fmt.Printf("✅ %s now needs %s\n", track.Name, instrument)
+	}
+	for _, instrument := range remove {
+		params := database.RemoveTrackRequirementParams{
+			TrackID:    track.ID,
+			Instrument: instrument,
+		}
+		removed, removeErr := dbQueries.RemoveTrackRequirement(ctx, params)
+		if removeErr != nil {
+			return fmt.Errorf("error removing %s from %s: %v", instrument, track.Name, removeErr)
+		}
+		if removed == 0 {
+			fmt.Printf("⚠️ %s didn't need %s\n", track.Name, instrument)
+			continue
+		}
+		fmt.Printf("✅ %s no longer needs %s\n", track.Name, instrument)
+	}
+	requirements, getErr := dbQueries.GetTrackRequirements(ctx, track.ID)
+	if getErr != nil {
+		return fmt.Errorf("unable to get requirements for %s: %v", track.Name, getErr)
+	}
+	if len(requirements) == 0 {
+		fmt.Printf("%s - %s has no requirements\n", track.Name, track.Artist)
+		return nil
+	}
+	fmt.Printf("%s - %s needs: %s\n", track.Name, track.Artist, strings.Join(requirements, ", "))
+	return nil
+}
+
+// RunTracksEdit changes a track's metadata. edits maps field names to new values; when it is
+// empty, each field is prompted for instead.
+func RunTracksEdit(db *sql.DB, song string, edits map[string]string) error {
+	dbQueries := database.New(db)
+	track, findErr := findTrack(dbQueries, song)
+	if findErr != nil {
+		return findErr
+	}
+	edited := track
+	if len(edits) == 0 {
+		reader := bufio.NewReader(os.Stdin)
+		fmt.Printf("Editing %s - %s, leave a field blank to keep its current value.\n", track.Name, track.Artist)
+		for _, field := range trackEditFields {
+			for {
+				fmt.Printf("%s [%s]: ", Capitalize(field), trackFieldValue(edited, field))
+				input, _ := reader.ReadString('\n')
+				input = strings.TrimSpace(input)
+				if input == "" {
+					break
+				}
+				if setErr := setTrackField(&edited, field, input); setErr != nil {
+					fmt.Printf("Invalid, %v\n", setErr)
+					continue
+				}
+				break
+			}
+		}
+	} else {
+		var fieldErrs []string
+		for _, field := range trackEditFields {
+			value, ok := edits[field]
+			if !ok {
+				continue
+			}
+			if setErr := setTrackField(&edited, field, value); setErr != nil {
+				fieldErrs = append(fieldErrs, setErr.Error())
+			}
+		}
+		if len(fieldErrs) > 0 {
+			return fmt.Errorf("%s", strings.Join(fieldErrs, "; "))
+		}
+	}
+	changes := []string{}
+	for _, field := range trackEditFields {
+		before, after := trackFieldValue(track, field), trackFieldValue(edited, field)
+		if before != after {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", field, before, after))
+		}
+	}
+	if len(changes) == 0 {
+		fmt.Println("No changes made.")
+		return nil
+	}
+	if updateErr := service.UpdateTrack(context.Background(), dbQueries, edited); updateErr != nil {
+		return fmt.Errorf("unable to update %s: %v", track.Name, updateErr)
+	}
+	fmt.Printf("✅ Updated %s - %s\n", track.Name, track.Artist)
+	for _, change := range changes {
+		fmt.Printf(" - %s\n", change)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rjfeeney/setlist_builder/internal/tui"
+)
+
+func RunTUI(db *sql.DB) error {
+	program := tea.NewProgram(tui.New(db), tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		return fmt.Errorf("terminal UI stopped: %v", err)
+	}
+	return nil
+}
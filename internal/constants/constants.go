@@ -1,4 +1,16 @@
 package constants
 
+import "unicode"
+
 var ValidSingers = []string{"bos", "riley", "jared", "ty"}
 var ValidKeys = []string{"a", "a#", "b", "c", "c#", "d", "d#", "e", "f", "f#", "g", "g#", "ab", "bb", "db", "eb", "gb"}
+
+// Capitalize upper cases the first letter of s, the way singers and keys are stored.
+func Capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
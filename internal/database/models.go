@@ -6,16 +6,64 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 )
 
+type BuildHistory struct {
+	ID        int32
+	CreatedAt time.Time
+	Setlist   json.RawMessage
+}
+
+type BuildTrace struct {
+	ID        int32
+	CreatedAt time.Time
+	Setlist   json.RawMessage
+	Trace     json.RawMessage
+}
+
+type GenreBucket struct {
+	Genre  string
+	Bucket string
+}
+
+type Profile struct {
+	Name      string
+	UpdatedAt time.Time
+	Settings  json.RawMessage
+}
+
+type Setlist struct {
+	ID        int32
+	Name      string
+	CreatedAt time.Time
+	Data      json.RawMessage
+}
+
 type Singer struct {
-	Song   string
-	Artist string
-	Singer string
-	Key    string
+	TrackID int32
+	Singer  string
+	Key     string
+}
+
+type SingerPart struct {
+	TrackID int32
+	Lead    string
+	Singer  string
+	Role    string
+}
+
+type SingerRange struct {
+	Singer   string
+	LowNote  string
+	HighNote string
 }
 
 type Track struct {
+	ID                int32
+	SpotifyID         sql.NullString
+	Isrc              sql.NullString
 	Name              string
 	Artist            string
 	Genre             []string
@@ -24,9 +72,18 @@ type Track struct {
 	Explicit          bool
 	Bpm               int32
 	OriginalKey       string
+	MelodyLow         string
+	MelodyHigh        string
+	CleanVersion      bool
+}
+
+type TrackRequirement struct {
+	TrackID    int32
+	Instrument string
 }
 
 type Working struct {
+	TrackID           int32
 	Name              string
 	Artist            string
 	Genre             []string
@@ -37,4 +94,5 @@ type Working struct {
 	OriginalKey       string
 	Singer            sql.NullString
 	SingerKey         sql.NullString
+	CleanVersion      bool
 }
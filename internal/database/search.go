@@ -0,0 +1,151 @@
+package database
+
+// Hand written: sqlc can't generate queries whose WHERE clause depends on which filters are set.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// TrackSortColumns maps the sort names SearchTracks accepts to their columns.
+var TrackSortColumns = map[string]string{
+	"id":       "t.id",
+	"name":     "t.name",
+	"artist":   "t.artist",
+	"duration": "t.duration_in_seconds",
+	"year":     "t.year",
+	"bpm":      "t.bpm",
+	"key":      "t.original_key",
+}
+
+// TrackFilter narrows SearchTracks. Zero values don't filter.
+type TrackFilter struct {
+	// Text matches anywhere in the name or artist.
+	Text   string
+	Singer string
+	Key    string
+	// Genre matches anywhere in any of the track's genres, so "rock" matches "classic rock".
+	Genre      string
+	MinBpm     int32
+	MaxBpm     int32
+	MinYear    string
+	MaxYear    string
+	Explicit   sql.NullBool
+	MissingKey bool
+	NoSinger   bool
+	// Sort is a key of TrackSortColumns, defaulting to name. Ties are broken by name and artist.
+	Sort       string
+	Descending bool
+}
+
+// buildTrackSearch returns the query and arguments for filter.
+func buildTrackSearch(filter TrackFilter) (string, []interface{}, error) {
+	conditions := []string{}
+	args := []interface{}{}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if filter.Text != "" {
+		pattern := arg("%" + filter.Text + "%")
+		conditions = append(conditions, fmt.Sprintf("(t.name ILIKE %s OR t.artist ILIKE %s)", pattern, pattern))
+	}
+	if filter.Singer != "" {
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM singers s WHERE s.track_id = t.id AND LOWER(s.singer) = LOWER(%s))", arg(filter.Singer)))
+	}
+	if filter.Key != "" {
+		conditions = append(conditions, fmt.Sprintf("LOWER(t.original_key) = LOWER(%s)", arg(filter.Key)))
+	}
+	if filter.Genre != "" {
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM unnest(t.genre) g WHERE g ILIKE %s)", arg("%"+filter.Genre+"%")))
+	}
+	if filter.MinBpm > 0 {
+		conditions = append(conditions, fmt.Sprintf("t.bpm >= %s", arg(filter.MinBpm)))
+	}
+	if filter.MaxBpm > 0 {
+		conditions = append(conditions, fmt.Sprintf("t.bpm <= %s", arg(filter.MaxBpm)))
+	}
+	// years are stored as four digit text, so they compare correctly as strings
+	if filter.MinYear != "" {
+		conditions = append(conditions, fmt.Sprintf("t.year >= %s", arg(filter.MinYear)))
+	}
+	if filter.MaxYear != "" {
+		conditions = append(conditions, fmt.Sprintf("t.year <= %s", arg(filter.MaxYear)))
+	}
+	if filter.Explicit.Valid {
+		conditions = append(conditions, fmt.Sprintf("t.explicit = %s", arg(filter.Explicit.Bool)))
+	}
+	if filter.MissingKey {
+		conditions = append(conditions, "t.original_key = ''")
+	}
+	if filter.NoSinger {
+		conditions = append(conditions, "NOT EXISTS (SELECT 1 FROM singers s WHERE s.track_id = t.id)")
+	}
+
+	sort := filter.Sort
+	if sort == "" {
+		sort = "name"
+	}
+	column, ok := TrackSortColumns[sort]
+	if !ok {
+		return "", nil, fmt.Errorf("unable to sort by %s", sort)
+	}
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+
+	query := "SELECT t.id, t.spotify_id, t.isrc, t.name, t.artist, t.genre, t.duration_in_seconds, t.year, t.explicit, t.bpm, t.original_key, t.melody_low, t.melody_high, t.clean_version FROM tracks t"
+	if len(conditions) > 0 {
+		query += "\nWHERE " + strings.Join(conditions, "\n  AND ")
+	}
+	query += fmt.Sprintf("\nORDER BY %s %s, t.name, t.artist", column, direction)
+	return query, args, nil
+}
+
+// SearchTracks returns the tracks matching filter.
+func (q *Queries) SearchTracks(ctx context.Context, filter TrackFilter) ([]Track, error) {
+	query, args, buildErr := buildTrackSearch(filter)
+	if buildErr != nil {
+		return nil, buildErr
+	}
+	rows, err := q.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Track
+	for rows.Next() {
+		var i Track
+		if err := rows.Scan(
+			&i.ID,
+			&i.SpotifyID,
+			&i.Isrc,
+			&i.Name,
+			&i.Artist,
+			pq.Array(&i.Genre),
+			&i.DurationInSeconds,
+			&i.Year,
+			&i.Explicit,
+			&i.Bpm,
+			&i.OriginalKey,
+			&i.MelodyLow,
+			&i.MelodyHigh,
+			&i.CleanVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildTrackSearch(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     TrackFilter
+		conditions []string
+		order      string
+		args       []interface{}
+		expectErr  bool
+	}{
+		{
+			name:  "no filters",
+			order: "ORDER BY t.name ASC, t.name, t.artist",
+		},
+		{
+			name:       "singer and bpm range",
+			filter:     TrackFilter{Singer: "riley", MinBpm: 100, MaxBpm: 130, Sort: "bpm", Descending: true},
+			conditions: []string{"LOWER(s.singer) = LOWER($1)", "t.bpm >= $2", "t.bpm <= $3"},
+			order:      "ORDER BY t.bpm DESC, t.name, t.artist",
+			args:       []interface{}{"riley", int32(100), int32(130)},
+		},
+		{
+			name:       "clean songs with no key or singer",
+			filter:     TrackFilter{Explicit: sql.NullBool{Bool: false, Valid: true}, MissingKey: true, NoSinger: true},
+			conditions: []string{"t.explicit = $1", "t.original_key = ''", "NOT EXISTS"},
+			args:       []interface{}{false},
+		},
+		{
+			name:       "text and genre match partially",
+			filter:     TrackFilter{Text: "sun", Genre: "rock", MinYear: "1980", MaxYear: "1989"},
+			conditions: []string{"t.name ILIKE $1 OR t.artist ILIKE $1", "g ILIKE $2", "t.year >= $3", "t.year <= $4"},
+			args:       []interface{}{"%sun%", "%rock%", "1980", "1989"},
+		},
+		{
+			name:      "unknown sort",
+			filter:    TrackFilter{Sort: "name; DROP TABLE tracks"},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := buildTrackSearch(tt.filter)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Expected error: %v, got %v", tt.expectErr, err)
+			}
+			if tt.expectErr {
+				return
+			}
+			if len(tt.conditions) == 0 && strings.Contains(query, "WHERE") {
+				t.Errorf("Expected no WHERE clause, got %s", query)
+			}
+			for _, condition := range tt.conditions {
+				if !strings.Contains(query, condition) {
+					t.Errorf("Expected query to contain %q, got %s", condition, query)
+				}
+			}
+			if tt.order != "" && !strings.HasSuffix(query, tt.order) {
+				t.Errorf("Expected query to end with %q, got %s", tt.order, query)
+			}
+			if len(tt.args) > 0 && !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("Expected args %v, got %v", tt.args, args)
+			}
+		})
+	}
+}
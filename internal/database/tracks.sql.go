@@ -8,6 +8,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/lib/pq"
 )
@@ -16,17 +17,46 @@ const addOriginalKey = `-- name: AddOriginalKey :exec
 UPDATE tracks
 SET
     original_key = $1
-WHERE name = $2 AND artist = $3
+WHERE id = $2
 `
 
 type AddOriginalKeyParams struct {
 	OriginalKey string
-	Name        string
-	Artist      string
+	ID          int32
 }
 
 func (q *Queries) AddOriginalKey(ctx context.Context, arg AddOriginalKeyParams) error {
-	_, err := q.db.ExecContext(ctx, addOriginalKey, arg.OriginalKey, arg.Name, arg.Artist)
+	_, err := q.db.ExecContext(ctx, addOriginalKey, arg.OriginalKey, arg.ID)
+	return err
+}
+
+const addSingerPart = `-- name: AddSingerPart :exec
+INSERT INTO singer_parts (track_id, lead, singer, role)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4
+)
+ON CONFLICT (track_id, lead, singer) DO UPDATE
+SET
+    role = EXCLUDED.role
+`
+
+type AddSingerPartParams struct {
+	TrackID int32
+	Lead    string
+	Singer  string
+	Role    string
+}
+
+func (q *Queries) AddSingerPart(ctx context.Context, arg AddSingerPartParams) error {
+	_, err := q.db.ExecContext(ctx, addSingerPart,
+		arg.TrackID,
+		arg.Lead,
+		arg.Singer,
+		arg.Role,
+	)
 	return err
 }
 
@@ -35,55 +65,61 @@ UPDATE working
 SET 
     singer = $1,
     singer_key = $2
-WHERE name = $3 AND artist = $4
+WHERE track_id = $3
 `
 
 type AddSingerToWorkingParams struct {
 	Singer    sql.NullString
 	SingerKey sql.NullString
-	Name      string
-	Artist    string
+	TrackID   int32
 }
 
 func (q *Queries) AddSingerToWorking(ctx context.Context, arg AddSingerToWorkingParams) error {
-	_, err := q.db.ExecContext(ctx, addSingerToWorking,
-		arg.Singer,
-		arg.SingerKey,
-		arg.Name,
-		arg.Artist,
-	)
+	_, err := q.db.ExecContext(ctx, addSingerToWorking, arg.Singer, arg.SingerKey, arg.TrackID)
 	return err
 }
 
 const addToSingers = `-- name: AddToSingers :exec
-INSERT INTO singers (song, artist, singer, key)
+INSERT INTO singers (track_id, singer, key)
 VALUES (
     $1,
     $2,
-    $3,
-    $4
+    $3
 )
 `
 
 type AddToSingersParams struct {
-	Song   string
-	Artist string
-	Singer string
-	Key    string
+	TrackID int32
+	Singer  string
+	Key     string
 }
 
 func (q *Queries) AddToSingers(ctx context.Context, arg AddToSingersParams) error {
-	_, err := q.db.ExecContext(ctx, addToSingers,
-		arg.Song,
-		arg.Artist,
-		arg.Singer,
-		arg.Key,
-	)
+	_, err := q.db.ExecContext(ctx, addToSingers, arg.TrackID, arg.Singer, arg.Key)
+	return err
+}
+
+const addTrackRequirement = `-- name: AddTrackRequirement :exec
+INSERT INTO track_requirements (track_id, instrument)
+VALUES (
+    $1,
+    $2
+)
+ON CONFLICT (track_id, instrument) DO NOTHING
+`
+
+type AddTrackRequirementParams struct {
+	TrackID    int32
+	Instrument string
+}
+
+func (q *Queries) AddTrackRequirement(ctx context.Context, arg AddTrackRequirementParams) error {
+	_, err := q.db.ExecContext(ctx, addTrackRequirement, arg.TrackID, arg.Instrument)
 	return err
 }
 
 const addTrackToWorking = `-- name: AddTrackToWorking :exec
-INSERT INTO working (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key)
+INSERT INTO working (track_id, name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, clean_version)
 VALUES (
     $1,
     $2,
@@ -92,11 +128,14 @@ VALUES (
     $5,
     $6,
     $7,
-    $8
+    $8,
+    $9,
+    $10
 )
 `
 
 type AddTrackToWorkingParams struct {
+	TrackID           int32
 	Name              string
 	Artist            string
 	Genre             []string
@@ -105,10 +144,12 @@ type AddTrackToWorkingParams struct {
 	Explicit          bool
 	Bpm               int32
 	OriginalKey       string
+	CleanVersion      bool
 }
 
 func (q *Queries) AddTrackToWorking(ctx context.Context, arg AddTrackToWorkingParams) error {
 	_, err := q.db.ExecContext(ctx, addTrackToWorking,
+		arg.TrackID,
 		arg.Name,
 		arg.Artist,
 		pq.Array(arg.Genre),
@@ -117,15 +158,17 @@ func (q *Queries) AddTrackToWorking(ctx context.Context, arg AddTrackToWorkingPa
 		arg.Explicit,
 		arg.Bpm,
 		arg.OriginalKey,
+		arg.CleanVersion,
 	)
 	return err
 }
 
 const checkKeys = `-- name: CheckKeys :many
-SELECT name, artist FROM tracks WHERE original_key = '' OR original_key IS NULL
+SELECT id, name, artist FROM tracks WHERE original_key = '' OR original_key IS NULL
 `
 
 type CheckKeysRow struct {
+	ID     int32
 	Name   string
 	Artist string
 }
@@ -139,7 +182,7 @@ func (q *Queries) CheckKeys(ctx context.Context) ([]CheckKeysRow, error) {
 	var items []CheckKeysRow
 	for rows.Next() {
 		var i CheckKeysRow
-		if err := rows.Scan(&i.Name, &i.Artist); err != nil {
+		if err := rows.Scan(&i.ID, &i.Name, &i.Artist); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -155,17 +198,12 @@ func (q *Queries) CheckKeys(ctx context.Context) ([]CheckKeysRow, error) {
 
 const checkSingers = `-- name: CheckSingers :one
 SELECT NOT EXISTS (
-  SELECT 1 FROM singers WHERE song = $1 AND artist = $2
+  SELECT 1 FROM singers WHERE track_id = $1
 )
 `
 
-type CheckSingersParams struct {
-	Song   string
-	Artist string
-}
-
-func (q *Queries) CheckSingers(ctx context.Context, arg CheckSingersParams) (bool, error) {
-	row := q.db.QueryRowContext(ctx, checkSingers, arg.Song, arg.Artist)
+func (q *Queries) CheckSingers(ctx context.Context, trackID int32) (bool, error) {
+	row := q.db.QueryRowContext(ctx, checkSingers, trackID)
 	var not_exists bool
 	err := row.Scan(&not_exists)
 	return not_exists, err
@@ -189,6 +227,15 @@ func (q *Queries) CleanTracks(ctx context.Context) error {
 	return err
 }
 
+const clearBuildTraces = `-- name: ClearBuildTraces :exec
+DELETE FROM build_traces
+`
+
+func (q *Queries) ClearBuildTraces(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, clearBuildTraces)
+	return err
+}
+
 const clearWorking = `-- name: ClearWorking :exec
 DELETE FROM working
 `
@@ -210,8 +257,64 @@ func (q *Queries) CountSingers(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const createBuildHistory = `-- name: CreateBuildHistory :exec
+INSERT INTO build_history (setlist)
+VALUES (
+    $1
+)
+`
+
+func (q *Queries) CreateBuildHistory(ctx context.Context, setlist json.RawMessage) error {
+	_, err := q.db.ExecContext(ctx, createBuildHistory, setlist)
+	return err
+}
+
+const createBuildTrace = `-- name: CreateBuildTrace :exec
+INSERT INTO build_traces (setlist, trace)
+VALUES (
+    $1,
+    $2
+)
+`
+
+type CreateBuildTraceParams struct {
+	Setlist json.RawMessage
+	Trace   json.RawMessage
+}
+
+func (q *Queries) CreateBuildTrace(ctx context.Context, arg CreateBuildTraceParams) error {
+	_, err := q.db.ExecContext(ctx, createBuildTrace, arg.Setlist, arg.Trace)
+	return err
+}
+
+const createSetlist = `-- name: CreateSetlist :one
+INSERT INTO setlists (name, data)
+VALUES (
+    $1,
+    $2
+)
+RETURNING id, name, created_at, data
+`
+
+type CreateSetlistParams struct {
+	Name string
+	Data json.RawMessage
+}
+
+func (q *Queries) CreateSetlist(ctx context.Context, arg CreateSetlistParams) (Setlist, error) {
+	row := q.db.QueryRowContext(ctx, createSetlist, arg.Name, arg.Data)
+	var i Setlist
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.Data,
+	)
+	return i, err
+}
+
 const createTrack = `-- name: CreateTrack :exec
-INSERT INTO tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key)
+INSERT INTO tracks (spotify_id, isrc, name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key)
 VALUES (
     $1,
     $2,
@@ -220,11 +323,15 @@ VALUES (
     $5,
     $6,
     $7,
-    $8
+    $8,
+    $9,
+    $10
 )
 `
 
 type CreateTrackParams struct {
+	SpotifyID         sql.NullString
+	Isrc              sql.NullString
 	Name              string
 	Artist            string
 	Genre             []string
@@ -237,6 +344,8 @@ type CreateTrackParams struct {
 
 func (q *Queries) CreateTrack(ctx context.Context, arg CreateTrackParams) error {
 	_, err := q.db.ExecContext(ctx, createTrack,
+		arg.SpotifyID,
+		arg.Isrc,
 		arg.Name,
 		arg.Artist,
 		pq.Array(arg.Genre),
@@ -249,42 +358,88 @@ func (q *Queries) CreateTrack(ctx context.Context, arg CreateTrackParams) error
 	return err
 }
 
+const deleteProfile = `-- name: DeleteProfile :execrows
+DELETE FROM profiles WHERE name = $1
+`
+
+func (q *Queries) DeleteProfile(ctx context.Context, name string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteProfile, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteSetlist = `-- name: DeleteSetlist :execrows
+DELETE FROM setlists WHERE id = $1
+`
+
+func (q *Queries) DeleteSetlist(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteSetlist, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteTrack = `-- name: DeleteTrack :exec
-DELETE FROM tracks WHERE tracks.name = $1 AND tracks.artist = $2
+DELETE FROM tracks WHERE tracks.id = $1
 `
 
-type DeleteTrackParams struct {
+func (q *Queries) DeleteTrack(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteTrack, id)
+	return err
+}
+
+const findTrack = `-- name: FindTrack :one
+SELECT id, spotify_id, isrc, name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, melody_low, melody_high, clean_version FROM tracks WHERE lower(name) = lower($1) AND lower(artist) = lower($2)
+`
+
+type FindTrackParams struct {
 	Name   string
 	Artist string
 }
 
-func (q *Queries) DeleteTrack(ctx context.Context, arg DeleteTrackParams) error {
-	_, err := q.db.ExecContext(ctx, deleteTrack, arg.Name, arg.Artist)
-	return err
+func (q *Queries) FindTrack(ctx context.Context, arg FindTrackParams) (Track, error) {
+	row := q.db.QueryRowContext(ctx, findTrack, arg.Name, arg.Artist)
+	var i Track
+	err := row.Scan(
+		&i.ID,
+		&i.SpotifyID,
+		&i.Isrc,
+		&i.Name,
+		&i.Artist,
+		pq.Array(&i.Genre),
+		&i.DurationInSeconds,
+		&i.Year,
+		&i.Explicit,
+		&i.Bpm,
+		&i.OriginalKey,
+		&i.MelodyLow,
+		&i.MelodyHigh,
+		&i.CleanVersion,
+	)
+	return i, err
 }
 
-const getAllTracks = `-- name: GetAllTracks :many
-SELECT name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key FROM tracks
+const getAllBuildTraces = `-- name: GetAllBuildTraces :many
+SELECT id, created_at, setlist, trace FROM build_traces ORDER BY created_at DESC, id DESC
 `
 
-func (q *Queries) GetAllTracks(ctx context.Context) ([]Track, error) {
-	rows, err := q.db.QueryContext(ctx, getAllTracks)
+func (q *Queries) GetAllBuildTraces(ctx context.Context) ([]BuildTrace, error) {
+	rows, err := q.db.QueryContext(ctx, getAllBuildTraces)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Track
+	var items []BuildTrace
 	for rows.Next() {
-		var i Track
+		var i BuildTrace
 		if err := rows.Scan(
-			&i.Name,
-			&i.Artist,
-			pq.Array(&i.Genre),
-			&i.DurationInSeconds,
-			&i.Year,
-			&i.Explicit,
-			&i.Bpm,
-			&i.OriginalKey,
+			&i.ID,
+			&i.CreatedAt,
+			&i.Setlist,
+			&i.Trace,
 		); err != nil {
 			return nil, err
 		}
@@ -299,30 +454,51 @@ func (q *Queries) GetAllTracks(ctx context.Context) ([]Track, error) {
 	return items, nil
 }
 
-const getAllWorking = `-- name: GetAllWorking :many
-SELECT name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, singer, singer_key FROM working
+const getAllProfiles = `-- name: GetAllProfiles :many
+SELECT name, updated_at, settings FROM profiles ORDER BY name
 `
 
-func (q *Queries) GetAllWorking(ctx context.Context) ([]Working, error) {
-	rows, err := q.db.QueryContext(ctx, getAllWorking)
+func (q *Queries) GetAllProfiles(ctx context.Context) ([]Profile, error) {
+	rows, err := q.db.QueryContext(ctx, getAllProfiles)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Working
+	var items []Profile
 	for rows.Next() {
-		var i Working
+		var i Profile
+		if err := rows.Scan(&i.Name, &i.UpdatedAt, &i.Settings); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllSetlists = `-- name: GetAllSetlists :many
+SELECT id, name, created_at, data FROM setlists ORDER BY created_at DESC
+`
+
+func (q *Queries) GetAllSetlists(ctx context.Context) ([]Setlist, error) {
+	rows, err := q.db.QueryContext(ctx, getAllSetlists)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Setlist
+	for rows.Next() {
+		var i Setlist
 		if err := rows.Scan(
+			&i.ID,
 			&i.Name,
-			&i.Artist,
-			pq.Array(&i.Genre),
-			&i.DurationInSeconds,
-			&i.Year,
-			&i.Explicit,
-			&i.Bpm,
-			&i.OriginalKey,
-			&i.Singer,
-			&i.SingerKey,
+			&i.CreatedAt,
+			&i.Data,
 		); err != nil {
 			return nil, err
 		}
@@ -337,31 +513,39 @@ func (q *Queries) GetAllWorking(ctx context.Context) ([]Working, error) {
 	return items, nil
 }
 
-const getSingerCombos = `-- name: GetSingerCombos :many
-SELECT singer, key from singers WHERE song = $1 and artist = $2 AND singer = ANY($3::text[])
+const getAllSingerParts = `-- name: GetAllSingerParts :many
+SELECT p.track_id, t.name, t.artist, p.lead, p.singer, p.role
+FROM singer_parts p
+JOIN tracks t ON t.id = p.track_id
+ORDER BY t.name, t.artist, p.lead, p.singer
 `
 
-type GetSingerCombosParams struct {
-	Song    string
+type GetAllSingerPartsRow struct {
+	TrackID int32
+	Name    string
 	Artist  string
-	Column3 []string
-}
-
-type GetSingerCombosRow struct {
-	Singer string
-	Key    string
+	Lead    string
+	Singer  string
+	Role    string
 }
 
-func (q *Queries) GetSingerCombos(ctx context.Context, arg GetSingerCombosParams) ([]GetSingerCombosRow, error) {
-	rows, err := q.db.QueryContext(ctx, getSingerCombos, arg.Song, arg.Artist, pq.Array(arg.Column3))
+func (q *Queries) GetAllSingerParts(ctx context.Context) ([]GetAllSingerPartsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAllSingerParts)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetSingerCombosRow
+	var items []GetAllSingerPartsRow
 	for rows.Next() {
-		var i GetSingerCombosRow
-		if err := rows.Scan(&i.Singer, &i.Key); err != nil {
+		var i GetAllSingerPartsRow
+		if err := rows.Scan(
+			&i.TrackID,
+			&i.Name,
+			&i.Artist,
+			&i.Lead,
+			&i.Singer,
+			&i.Role,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -375,23 +559,23 @@ func (q *Queries) GetSingerCombos(ctx context.Context, arg GetSingerCombosParams
 	return items, nil
 }
 
-const getSingers = `-- name: GetSingers :many
-SELECT singer FROM singers
+const getAllSingerRanges = `-- name: GetAllSingerRanges :many
+SELECT singer, low_note, high_note FROM singer_ranges ORDER BY singer
 `
 
-func (q *Queries) GetSingers(ctx context.Context) ([]string, error) {
-	rows, err := q.db.QueryContext(ctx, getSingers)
+func (q *Queries) GetAllSingerRanges(ctx context.Context) ([]SingerRange, error) {
+	rows, err := q.db.QueryContext(ctx, getAllSingerRanges)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []SingerRange
 	for rows.Next() {
-		var singer string
-		if err := rows.Scan(&singer); err != nil {
+		var i SingerRange
+		if err := rows.Scan(&i.Singer, &i.LowNote, &i.HighNote); err != nil {
 			return nil, err
 		}
-		items = append(items, singer)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -402,102 +586,705 @@ func (q *Queries) GetSingers(ctx context.Context) ([]string, error) {
 	return items, nil
 }
 
-const getTrack = `-- name: GetTrack :one
-SELECT name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key FROM tracks WHERE tracks.name = $1 AND tracks.artist = $2
-`
-
-type GetTrackParams struct {
-	Name   string
-	Artist string
-}
-
-func (q *Queries) GetTrack(ctx context.Context, arg GetTrackParams) (Track, error) {
-	row := q.db.QueryRowContext(ctx, getTrack, arg.Name, arg.Artist)
-	var i Track
-	err := row.Scan(
-		&i.Name,
-		&i.Artist,
-		pq.Array(&i.Genre),
-		&i.DurationInSeconds,
-		&i.Year,
-		&i.Explicit,
-		&i.Bpm,
-		&i.OriginalKey,
-	)
-	return i, err
-}
-
-const getTrackFromName = `-- name: GetTrackFromName :one
-SELECT name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key FROM tracks WHERE name ILIKE $1
-`
-
-func (q *Queries) GetTrackFromName(ctx context.Context, name string) (Track, error) {
-	row := q.db.QueryRowContext(ctx, getTrackFromName, name)
-	var i Track
-	err := row.Scan(
-		&i.Name,
-		&i.Artist,
-		pq.Array(&i.Genre),
-		&i.DurationInSeconds,
-		&i.Year,
-		&i.Explicit,
-		&i.Bpm,
-		&i.OriginalKey,
-	)
-	return i, err
-}
-
-const getTracksWithSingers = `-- name: GetTracksWithSingers :many
-SELECT
-    t.name,
-    t.artist,
-    t.genre,
-    t.duration_in_seconds,
-    t.year,
-    t.explicit,
-    t.bpm,
-    t.original_key,
-    s.singer,
-    s.key AS singer_key
-FROM
-    tracks t
-JOIN
-    singers s ON t.name = s.song AND t.artist = s.artist
+const getAllSingers = `-- name: GetAllSingers :many
+SELECT s.track_id, t.name, t.artist, s.singer, s.key
+FROM singers s
+JOIN tracks t ON t.id = s.track_id
+ORDER BY t.name, t.artist, s.singer
 `
 
-type GetTracksWithSingersRow struct {
-	Name              string
-	Artist            string
-	Genre             []string
-	DurationInSeconds int32
-	Year              string
-	Explicit          bool
-	Bpm               int32
-	OriginalKey       string
-	Singer            string
-	SingerKey         string
+type GetAllSingersRow struct {
+	TrackID int32
+	Name    string
+	Artist  string
+	Singer  string
+	Key     string
 }
 
-func (q *Queries) GetTracksWithSingers(ctx context.Context) ([]GetTracksWithSingersRow, error) {
-	rows, err := q.db.QueryContext(ctx, getTracksWithSingers)
+func (q *Queries) GetAllSingers(ctx context.Context) ([]GetAllSingersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAllSingers)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetTracksWithSingersRow
+	var items []GetAllSingersRow
 	for rows.Next() {
-		var i GetTracksWithSingersRow
+		var i GetAllSingersRow
 		if err := rows.Scan(
+			&i.TrackID,
 			&i.Name,
 			&i.Artist,
-			pq.Array(&i.Genre),
-			&i.DurationInSeconds,
-			&i.Year,
-			&i.Explicit,
-			&i.Bpm,
-			&i.OriginalKey,
 			&i.Singer,
-			&i.SingerKey,
+			&i.Key,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllTrackRequirements = `-- name: GetAllTrackRequirements :many
+SELECT r.track_id, t.name, t.artist, r.instrument
+FROM track_requirements r
+JOIN tracks t ON t.id = r.track_id
+ORDER BY t.name, t.artist, r.instrument
+`
+
+type GetAllTrackRequirementsRow struct {
+	TrackID    int32
+	Name       string
+	Artist     string
+	Instrument string
+}
+
+func (q *Queries) GetAllTrackRequirements(ctx context.Context) ([]GetAllTrackRequirementsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAllTrackRequirements)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAllTrackRequirementsRow
+	for rows.Next() {
+		var i GetAllTrackRequirementsRow
+		if err := rows.Scan(
+			&i.TrackID,
+			&i.Name,
+			&i.Artist,
+			&i.Instrument,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllTracks = `-- name: GetAllTracks :many
+SELECT id, spotify_id, isrc, name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, melody_low, melody_high, clean_version FROM tracks
+`
+
+func (q *Queries) GetAllTracks(ctx context.Context) ([]Track, error) {
+	rows, err := q.db.QueryContext(ctx, getAllTracks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Track
+	for rows.Next() {
+		var i Track
+		if err := rows.Scan(
+			&i.ID,
+			&i.SpotifyID,
+			&i.Isrc,
+			&i.Name,
+			&i.Artist,
+			pq.Array(&i.Genre),
+			&i.DurationInSeconds,
+			&i.Year,
+			&i.Explicit,
+			&i.Bpm,
+			&i.OriginalKey,
+			&i.MelodyLow,
+			&i.MelodyHigh,
+			&i.CleanVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllWorking = `-- name: GetAllWorking :many
+SELECT track_id, name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, singer, singer_key, clean_version FROM working
+`
+
+func (q *Queries) GetAllWorking(ctx context.Context) ([]Working, error) {
+	rows, err := q.db.QueryContext(ctx, getAllWorking)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Working
+	for rows.Next() {
+		var i Working
+		if err := rows.Scan(
+			&i.TrackID,
+			&i.Name,
+			&i.Artist,
+			pq.Array(&i.Genre),
+			&i.DurationInSeconds,
+			&i.Year,
+			&i.Explicit,
+			&i.Bpm,
+			&i.OriginalKey,
+			&i.Singer,
+			&i.SingerKey,
+			&i.CleanVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBuildHistory = `-- name: GetBuildHistory :many
+SELECT id, created_at, setlist FROM build_history ORDER BY created_at, id
+`
+
+func (q *Queries) GetBuildHistory(ctx context.Context) ([]BuildHistory, error) {
+	rows, err := q.db.QueryContext(ctx, getBuildHistory)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BuildHistory
+	for rows.Next() {
+		var i BuildHistory
+		if err := rows.Scan(&i.ID, &i.CreatedAt, &i.Setlist); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGenreBuckets = `-- name: GetGenreBuckets :many
+SELECT genre, bucket FROM genre_buckets ORDER BY genre
+`
+
+func (q *Queries) GetGenreBuckets(ctx context.Context) ([]GenreBucket, error) {
+	rows, err := q.db.QueryContext(ctx, getGenreBuckets)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GenreBucket
+	for rows.Next() {
+		var i GenreBucket
+		if err := rows.Scan(&i.Genre, &i.Bucket); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLastBuildTrace = `-- name: GetLastBuildTrace :one
+SELECT id, created_at, setlist, trace FROM build_traces ORDER BY created_at DESC, id DESC LIMIT 1
+`
+
+func (q *Queries) GetLastBuildTrace(ctx context.Context) (BuildTrace, error) {
+	row := q.db.QueryRowContext(ctx, getLastBuildTrace)
+	var i BuildTrace
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Setlist,
+		&i.Trace,
+	)
+	return i, err
+}
+
+const getProfile = `-- name: GetProfile :one
+SELECT name, updated_at, settings FROM profiles WHERE name = $1
+`
+
+func (q *Queries) GetProfile(ctx context.Context, name string) (Profile, error) {
+	row := q.db.QueryRowContext(ctx, getProfile, name)
+	var i Profile
+	err := row.Scan(&i.Name, &i.UpdatedAt, &i.Settings)
+	return i, err
+}
+
+const getSetlist = `-- name: GetSetlist :one
+SELECT id, name, created_at, data FROM setlists WHERE id = $1
+`
+
+func (q *Queries) GetSetlist(ctx context.Context, id int32) (Setlist, error) {
+	row := q.db.QueryRowContext(ctx, getSetlist, id)
+	var i Setlist
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.Data,
+	)
+	return i, err
+}
+
+const getSingerAssignments = `-- name: GetSingerAssignments :many
+SELECT s.track_id, t.name, t.artist, s.singer, s.key
+FROM singers s
+JOIN tracks t ON t.id = s.track_id
+WHERE s.singer = $1
+ORDER BY t.name, t.artist
+`
+
+type GetSingerAssignmentsRow struct {
+	TrackID int32
+	Name    string
+	Artist  string
+	Singer  string
+	Key     string
+}
+
+func (q *Queries) GetSingerAssignments(ctx context.Context, singer string) ([]GetSingerAssignmentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSingerAssignments, singer)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSingerAssignmentsRow
+	for rows.Next() {
+		var i GetSingerAssignmentsRow
+		if err := rows.Scan(
+			&i.TrackID,
+			&i.Name,
+			&i.Artist,
+			&i.Singer,
+			&i.Key,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSingerCombos = `-- name: GetSingerCombos :many
+SELECT singer, key from singers WHERE track_id = $1 AND singer = ANY($2::text[])
+`
+
+type GetSingerCombosParams struct {
+	TrackID int32
+	Column2 []string
+}
+
+type GetSingerCombosRow struct {
+	Singer string
+	Key    string
+}
+
+func (q *Queries) GetSingerCombos(ctx context.Context, arg GetSingerCombosParams) ([]GetSingerCombosRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSingerCombos, arg.TrackID, pq.Array(arg.Column2))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSingerCombosRow
+	for rows.Next() {
+		var i GetSingerCombosRow
+		if err := rows.Scan(&i.Singer, &i.Key); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSingerParts = `-- name: GetSingerParts :many
+SELECT track_id, lead, singer, role FROM singer_parts WHERE track_id = $1 ORDER BY lead, singer
+`
+
+func (q *Queries) GetSingerParts(ctx context.Context, trackID int32) ([]SingerPart, error) {
+	rows, err := q.db.QueryContext(ctx, getSingerParts, trackID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SingerPart
+	for rows.Next() {
+		var i SingerPart
+		if err := rows.Scan(
+			&i.TrackID,
+			&i.Lead,
+			&i.Singer,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSingerRange = `-- name: GetSingerRange :one
+SELECT singer, low_note, high_note FROM singer_ranges WHERE singer = $1
+`
+
+func (q *Queries) GetSingerRange(ctx context.Context, singer string) (SingerRange, error) {
+	row := q.db.QueryRowContext(ctx, getSingerRange, singer)
+	var i SingerRange
+	err := row.Scan(&i.Singer, &i.LowNote, &i.HighNote)
+	return i, err
+}
+
+const getSingers = `-- name: GetSingers :many
+SELECT singer FROM singers
+`
+
+func (q *Queries) GetSingers(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getSingers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var singer string
+		if err := rows.Scan(&singer); err != nil {
+			return nil, err
+		}
+		items = append(items, singer)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSingersForTrack = `-- name: GetSingersForTrack :many
+SELECT track_id, singer, key FROM singers WHERE track_id = $1 ORDER BY singer
+`
+
+func (q *Queries) GetSingersForTrack(ctx context.Context, trackID int32) ([]Singer, error) {
+	rows, err := q.db.QueryContext(ctx, getSingersForTrack, trackID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Singer
+	for rows.Next() {
+		var i Singer
+		if err := rows.Scan(&i.TrackID, &i.Singer, &i.Key); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTrack = `-- name: GetTrack :one
+SELECT id, spotify_id, isrc, name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, melody_low, melody_high, clean_version FROM tracks WHERE tracks.name = $1 AND tracks.artist = $2
+`
+
+type GetTrackParams struct {
+	Name   string
+	Artist string
+}
+
+func (q *Queries) GetTrack(ctx context.Context, arg GetTrackParams) (Track, error) {
+	row := q.db.QueryRowContext(ctx, getTrack, arg.Name, arg.Artist)
+	var i Track
+	err := row.Scan(
+		&i.ID,
+		&i.SpotifyID,
+		&i.Isrc,
+		&i.Name,
+		&i.Artist,
+		pq.Array(&i.Genre),
+		&i.DurationInSeconds,
+		&i.Year,
+		&i.Explicit,
+		&i.Bpm,
+		&i.OriginalKey,
+		&i.MelodyLow,
+		&i.MelodyHigh,
+		&i.CleanVersion,
+	)
+	return i, err
+}
+
+const getTrackByID = `-- name: GetTrackByID :one
+SELECT id, spotify_id, isrc, name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, melody_low, melody_high, clean_version FROM tracks WHERE id = $1
+`
+
+func (q *Queries) GetTrackByID(ctx context.Context, id int32) (Track, error) {
+	row := q.db.QueryRowContext(ctx, getTrackByID, id)
+	var i Track
+	err := row.Scan(
+		&i.ID,
+		&i.SpotifyID,
+		&i.Isrc,
+		&i.Name,
+		&i.Artist,
+		pq.Array(&i.Genre),
+		&i.DurationInSeconds,
+		&i.Year,
+		&i.Explicit,
+		&i.Bpm,
+		&i.OriginalKey,
+		&i.MelodyLow,
+		&i.MelodyHigh,
+		&i.CleanVersion,
+	)
+	return i, err
+}
+
+const getTrackBySpotifyID = `-- name: GetTrackBySpotifyID :one
+SELECT id, spotify_id, isrc, name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, melody_low, melody_high, clean_version FROM tracks WHERE spotify_id = $1
+`
+
+func (q *Queries) GetTrackBySpotifyID(ctx context.Context, spotifyID sql.NullString) (Track, error) {
+	row := q.db.QueryRowContext(ctx, getTrackBySpotifyID, spotifyID)
+	var i Track
+	err := row.Scan(
+		&i.ID,
+		&i.SpotifyID,
+		&i.Isrc,
+		&i.Name,
+		&i.Artist,
+		pq.Array(&i.Genre),
+		&i.DurationInSeconds,
+		&i.Year,
+		&i.Explicit,
+		&i.Bpm,
+		&i.OriginalKey,
+		&i.MelodyLow,
+		&i.MelodyHigh,
+		&i.CleanVersion,
+	)
+	return i, err
+}
+
+const getTrackRequirements = `-- name: GetTrackRequirements :many
+SELECT instrument FROM track_requirements WHERE track_id = $1 ORDER BY instrument
+`
+
+func (q *Queries) GetTrackRequirements(ctx context.Context, trackID int32) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getTrackRequirements, trackID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var instrument string
+		if err := rows.Scan(&instrument); err != nil {
+			return nil, err
+		}
+		items = append(items, instrument)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTracksFromName = `-- name: GetTracksFromName :many
+SELECT id, spotify_id, isrc, name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, melody_low, melody_high, clean_version FROM tracks WHERE lower(name) = lower($1) ORDER BY id
+`
+
+func (q *Queries) GetTracksFromName(ctx context.Context, name string) ([]Track, error) {
+	rows, err := q.db.QueryContext(ctx, getTracksFromName, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Track
+	for rows.Next() {
+		var i Track
+		if err := rows.Scan(
+			&i.ID,
+			&i.SpotifyID,
+			&i.Isrc,
+			&i.Name,
+			&i.Artist,
+			pq.Array(&i.Genre),
+			&i.DurationInSeconds,
+			&i.Year,
+			&i.Explicit,
+			&i.Bpm,
+			&i.OriginalKey,
+			&i.MelodyLow,
+			&i.MelodyHigh,
+			&i.CleanVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTracksWithSingers = `-- name: GetTracksWithSingers :many
+SELECT
+    t.id,
+    t.name,
+    t.artist,
+    t.genre,
+    t.duration_in_seconds,
+    t.year,
+    t.explicit,
+    t.bpm,
+    t.original_key,
+    t.clean_version,
+    s.singer,
+    s.key AS singer_key
+FROM
+    tracks t
+JOIN
+    singers s ON s.track_id = t.id
+`
+
+type GetTracksWithSingersRow struct {
+	ID                int32
+	Name              string
+	Artist            string
+	Genre             []string
+	DurationInSeconds int32
+	Year              string
+	Explicit          bool
+	Bpm               int32
+	OriginalKey       string
+	CleanVersion      bool
+	Singer            string
+	SingerKey         string
+}
+
+func (q *Queries) GetTracksWithSingers(ctx context.Context) ([]GetTracksWithSingersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTracksWithSingers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTracksWithSingersRow
+	for rows.Next() {
+		var i GetTracksWithSingersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Artist,
+			pq.Array(&i.Genre),
+			&i.DurationInSeconds,
+			&i.Year,
+			&i.Explicit,
+			&i.Bpm,
+			&i.OriginalKey,
+			&i.CleanVersion,
+			&i.Singer,
+			&i.SingerKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTracksWithoutSingers = `-- name: GetTracksWithoutSingers :many
+SELECT id, spotify_id, isrc, name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, melody_low, melody_high, clean_version FROM tracks
+WHERE NOT EXISTS (
+  SELECT 1 FROM singers WHERE singers.track_id = tracks.id
+)
+ORDER BY name, artist
+`
+
+func (q *Queries) GetTracksWithoutSingers(ctx context.Context) ([]Track, error) {
+	rows, err := q.db.QueryContext(ctx, getTracksWithoutSingers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Track
+	for rows.Next() {
+		var i Track
+		if err := rows.Scan(
+			&i.ID,
+			&i.SpotifyID,
+			&i.Isrc,
+			&i.Name,
+			&i.Artist,
+			pq.Array(&i.Genre),
+			&i.DurationInSeconds,
+			&i.Year,
+			&i.Explicit,
+			&i.Bpm,
+			&i.OriginalKey,
+			&i.MelodyLow,
+			&i.MelodyHigh,
+			&i.CleanVersion,
 		); err != nil {
 			return nil, err
 		}
@@ -513,13 +1300,14 @@ func (q *Queries) GetTracksWithSingers(ctx context.Context) ([]GetTracksWithSing
 }
 
 const getWorking = `-- name: GetWorking :one
-SELECT name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, singer, singer_key FROM working WHERE working.name = $1
+SELECT track_id, name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, singer, singer_key, clean_version FROM working WHERE working.track_id = $1
 `
 
-func (q *Queries) GetWorking(ctx context.Context, name string) (Working, error) {
-	row := q.db.QueryRowContext(ctx, getWorking, name)
+func (q *Queries) GetWorking(ctx context.Context, trackID int32) (Working, error) {
+	row := q.db.QueryRowContext(ctx, getWorking, trackID)
 	var i Working
 	err := row.Scan(
+		&i.TrackID,
 		&i.Name,
 		&i.Artist,
 		pq.Array(&i.Genre),
@@ -530,28 +1318,213 @@ func (q *Queries) GetWorking(ctx context.Context, name string) (Working, error)
 		&i.OriginalKey,
 		&i.Singer,
 		&i.SingerKey,
+		&i.CleanVersion,
 	)
 	return i, err
 }
 
+const moveSingerParts = `-- name: MoveSingerParts :exec
+INSERT INTO singer_parts (track_id, lead, singer, role)
+SELECT $1::int, p.lead, p.singer, p.role FROM singer_parts p
+WHERE p.track_id = $2
+AND NOT EXISTS (
+  SELECT 1 FROM singer_parts kept WHERE kept.track_id = $1::int AND kept.lead = p.lead
+)
+ON CONFLICT (track_id, lead, singer) DO NOTHING
+`
+
+type MoveSingerPartsParams struct {
+	ToTrackID   int32
+	FromTrackID int32
+}
+
+func (q *Queries) MoveSingerParts(ctx context.Context, arg MoveSingerPartsParams) error {
+	_, err := q.db.ExecContext(ctx, moveSingerParts, arg.ToTrackID, arg.FromTrackID)
+	return err
+}
+
+const moveSingers = `-- name: MoveSingers :exec
+INSERT INTO singers (track_id, singer, key)
+SELECT $1::int, singer, key FROM singers
+WHERE track_id = $2
+ON CONFLICT (track_id, singer) DO NOTHING
+`
+
+type MoveSingersParams struct {
+	ToTrackID   int32
+	FromTrackID int32
+}
+
+func (q *Queries) MoveSingers(ctx context.Context, arg MoveSingersParams) error {
+	_, err := q.db.ExecContext(ctx, moveSingers, arg.ToTrackID, arg.FromTrackID)
+	return err
+}
+
+const moveTrackRequirements = `-- name: MoveTrackRequirements :exec
+INSERT INTO track_requirements (track_id, instrument)
+SELECT $1::int, instrument FROM track_requirements
+WHERE track_id = $2
+ON CONFLICT (track_id, instrument) DO NOTHING
+`
+
+type MoveTrackRequirementsParams struct {
+	ToTrackID   int32
+	FromTrackID int32
+}
+
+func (q *Queries) MoveTrackRequirements(ctx context.Context, arg MoveTrackRequirementsParams) error {
+	_, err := q.db.ExecContext(ctx, moveTrackRequirements, arg.ToTrackID, arg.FromTrackID)
+	return err
+}
+
 const removeFromWorking = `-- name: RemoveFromWorking :exec
-DELETE FROM working WHERE working.name = $1
+DELETE FROM working WHERE working.track_id = $1
+`
+
+func (q *Queries) RemoveFromWorking(ctx context.Context, trackID int32) error {
+	_, err := q.db.ExecContext(ctx, removeFromWorking, trackID)
+	return err
+}
+
+const removeGenreBucket = `-- name: RemoveGenreBucket :execrows
+DELETE FROM genre_buckets WHERE genre = $1
+`
+
+func (q *Queries) RemoveGenreBucket(ctx context.Context, genre string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeGenreBucket, genre)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeSinger = `-- name: RemoveSinger :execrows
+DELETE FROM singers WHERE track_id = $1 AND singer = $2
+`
+
+type RemoveSingerParams struct {
+	TrackID int32
+	Singer  string
+}
+
+func (q *Queries) RemoveSinger(ctx context.Context, arg RemoveSingerParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeSinger, arg.TrackID, arg.Singer)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeSingerPart = `-- name: RemoveSingerPart :execrows
+DELETE FROM singer_parts WHERE track_id = $1 AND lead = $2 AND singer = $3
+`
+
+type RemoveSingerPartParams struct {
+	TrackID int32
+	Lead    string
+	Singer  string
+}
+
+func (q *Queries) RemoveSingerPart(ctx context.Context, arg RemoveSingerPartParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeSingerPart, arg.TrackID, arg.Lead, arg.Singer)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeTrackRequirement = `-- name: RemoveTrackRequirement :execrows
+DELETE FROM track_requirements WHERE track_id = $1 AND instrument = $2
+`
+
+type RemoveTrackRequirementParams struct {
+	TrackID    int32
+	Instrument string
+}
+
+func (q *Queries) RemoveTrackRequirement(ctx context.Context, arg RemoveTrackRequirementParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeTrackRequirement, arg.TrackID, arg.Instrument)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setGenreBucket = `-- name: SetGenreBucket :exec
+INSERT INTO genre_buckets (genre, bucket)
+VALUES (
+    $1,
+    $2
+)
+ON CONFLICT (genre) DO UPDATE
+SET
+    bucket = EXCLUDED.bucket
+`
+
+type SetGenreBucketParams struct {
+	Genre  string
+	Bucket string
+}
+
+func (q *Queries) SetGenreBucket(ctx context.Context, arg SetGenreBucketParams) error {
+	_, err := q.db.ExecContext(ctx, setGenreBucket, arg.Genre, arg.Bucket)
+	return err
+}
+
+const setMelodyRange = `-- name: SetMelodyRange :exec
+UPDATE tracks
+SET
+    melody_low = $1,
+    melody_high = $2
+WHERE id = $3
+`
+
+type SetMelodyRangeParams struct {
+	MelodyLow  string
+	MelodyHigh string
+	ID         int32
+}
+
+func (q *Queries) SetMelodyRange(ctx context.Context, arg SetMelodyRangeParams) error {
+	_, err := q.db.ExecContext(ctx, setMelodyRange, arg.MelodyLow, arg.MelodyHigh, arg.ID)
+	return err
+}
+
+const setSingerRange = `-- name: SetSingerRange :exec
+INSERT INTO singer_ranges (singer, low_note, high_note)
+VALUES (
+    $1,
+    $2,
+    $3
+)
+ON CONFLICT (singer) DO UPDATE
+SET
+    low_note = EXCLUDED.low_note,
+    high_note = EXCLUDED.high_note
 `
 
-func (q *Queries) RemoveFromWorking(ctx context.Context, name string) error {
-	_, err := q.db.ExecContext(ctx, removeFromWorking, name)
+type SetSingerRangeParams struct {
+	Singer   string
+	LowNote  string
+	HighNote string
+}
+
+func (q *Queries) SetSingerRange(ctx context.Context, arg SetSingerRangeParams) error {
+	_, err := q.db.ExecContext(ctx, setSingerRange, arg.Singer, arg.LowNote, arg.HighNote)
 	return err
 }
 
 const sumDurationForSinger = `-- name: SumDurationForSinger :many
 SELECT
   s.singer,
-  SUM(t.duration_in_seconds) AS total_duration
+  COUNT(*) AS song_count,
+  SUM(t.duration_in_seconds) AS total_duration,
+  COALESCE(SUM(t.duration_in_seconds) FILTER (WHERE NOT t.explicit OR t.clean_version), 0)::bigint AS clean_duration
 FROM
   singers s
 JOIN
   tracks t
-  ON s.song = t.name AND s.artist = t.artist
+  ON s.track_id = t.id
 WHERE
   s.singer = ANY($1::text[])
 GROUP BY
@@ -562,7 +1535,9 @@ ORDER BY
 
 type SumDurationForSingerRow struct {
 	Singer        string
+	SongCount     int64
 	TotalDuration int64
+	CleanDuration int64
 }
 
 func (q *Queries) SumDurationForSinger(ctx context.Context, dollar_1 []string) ([]SumDurationForSingerRow, error) {
@@ -574,7 +1549,12 @@ func (q *Queries) SumDurationForSinger(ctx context.Context, dollar_1 []string) (
 	var items []SumDurationForSingerRow
 	for rows.Next() {
 		var i SumDurationForSingerRow
-		if err := rows.Scan(&i.Singer, &i.TotalDuration); err != nil {
+		if err := rows.Scan(
+			&i.Singer,
+			&i.SongCount,
+			&i.TotalDuration,
+			&i.CleanDuration,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -587,3 +1567,231 @@ func (q *Queries) SumDurationForSinger(ctx context.Context, dollar_1 []string) (
 	}
 	return items, nil
 }
+
+const updateBuildHistory = `-- name: UpdateBuildHistory :exec
+UPDATE build_history SET setlist = $1 WHERE id = $2
+`
+
+type UpdateBuildHistoryParams struct {
+	Setlist json.RawMessage
+	ID      int32
+}
+
+func (q *Queries) UpdateBuildHistory(ctx context.Context, arg UpdateBuildHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, updateBuildHistory, arg.Setlist, arg.ID)
+	return err
+}
+
+const updateBuildTrace = `-- name: UpdateBuildTrace :exec
+UPDATE build_traces
+SET
+    setlist = $1,
+    trace = $2
+WHERE id = $3
+`
+
+type UpdateBuildTraceParams struct {
+	Setlist json.RawMessage
+	Trace   json.RawMessage
+	ID      int32
+}
+
+func (q *Queries) UpdateBuildTrace(ctx context.Context, arg UpdateBuildTraceParams) error {
+	_, err := q.db.ExecContext(ctx, updateBuildTrace, arg.Setlist, arg.Trace, arg.ID)
+	return err
+}
+
+const updateSetlistData = `-- name: UpdateSetlistData :exec
+UPDATE setlists SET data = $1 WHERE id = $2
+`
+
+type UpdateSetlistDataParams struct {
+	Data json.RawMessage
+	ID   int32
+}
+
+func (q *Queries) UpdateSetlistData(ctx context.Context, arg UpdateSetlistDataParams) error {
+	_, err := q.db.ExecContext(ctx, updateSetlistData, arg.Data, arg.ID)
+	return err
+}
+
+const updateSingerKey = `-- name: UpdateSingerKey :exec
+UPDATE singers
+SET
+    key = $1
+WHERE track_id = $2 AND singer = $3
+`
+
+type UpdateSingerKeyParams struct {
+	Key     string
+	TrackID int32
+	Singer  string
+}
+
+func (q *Queries) UpdateSingerKey(ctx context.Context, arg UpdateSingerKeyParams) error {
+	_, err := q.db.ExecContext(ctx, updateSingerKey, arg.Key, arg.TrackID, arg.Singer)
+	return err
+}
+
+const updateTrack = `-- name: UpdateTrack :exec
+UPDATE tracks
+SET
+    spotify_id = $1,
+    isrc = $2,
+    name = $3,
+    artist = $4,
+    genre = $5,
+    duration_in_seconds = $6,
+    year = $7,
+    explicit = $8,
+    bpm = $9,
+    original_key = $10,
+    melody_low = $11,
+    melody_high = $12,
+    clean_version = $13
+WHERE id = $14
+`
+
+type UpdateTrackParams struct {
+	SpotifyID         sql.NullString
+	Isrc              sql.NullString
+	Name              string
+	Artist            string
+	Genre             []string
+	DurationInSeconds int32
+	Year              string
+	Explicit          bool
+	Bpm               int32
+	OriginalKey       string
+	MelodyLow         string
+	MelodyHigh        string
+	CleanVersion      bool
+	ID                int32
+}
+
+func (q *Queries) UpdateTrack(ctx context.Context, arg UpdateTrackParams) error {
+	_, err := q.db.ExecContext(ctx, updateTrack,
+		arg.SpotifyID,
+		arg.Isrc,
+		arg.Name,
+		arg.Artist,
+		pq.Array(arg.Genre),
+		arg.DurationInSeconds,
+		arg.Year,
+		arg.Explicit,
+		arg.Bpm,
+		arg.OriginalKey,
+		arg.MelodyLow,
+		arg.MelodyHigh,
+		arg.CleanVersion,
+		arg.ID,
+	)
+	return err
+}
+
+const upsertProfile = `-- name: UpsertProfile :exec
+INSERT INTO profiles (name, settings)
+VALUES (
+    $1,
+    $2
+)
+ON CONFLICT (name) DO UPDATE
+SET
+    settings = EXCLUDED.settings,
+    updated_at = NOW()
+`
+
+type UpsertProfileParams struct {
+	Name     string
+	Settings json.RawMessage
+}
+
+func (q *Queries) UpsertProfile(ctx context.Context, arg UpsertProfileParams) error {
+	_, err := q.db.ExecContext(ctx, upsertProfile, arg.Name, arg.Settings)
+	return err
+}
+
+const upsertSinger = `-- name: UpsertSinger :exec
+INSERT INTO singers (track_id, singer, key)
+VALUES (
+    $1,
+    $2,
+    $3
+)
+ON CONFLICT (track_id, singer) DO UPDATE
+SET
+    key = EXCLUDED.key
+`
+
+type UpsertSingerParams struct {
+	TrackID int32
+	Singer  string
+	Key     string
+}
+
+func (q *Queries) UpsertSinger(ctx context.Context, arg UpsertSingerParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSinger, arg.TrackID, arg.Singer, arg.Key)
+	return err
+}
+
+const upsertTrack = `-- name: UpsertTrack :one
+INSERT INTO tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, clean_version, spotify_id, isrc)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11
+)
+ON CONFLICT (name, artist) DO UPDATE
+SET
+    genre = EXCLUDED.genre,
+    duration_in_seconds = EXCLUDED.duration_in_seconds,
+    year = EXCLUDED.year,
+    explicit = EXCLUDED.explicit,
+    bpm = EXCLUDED.bpm,
+    original_key = EXCLUDED.original_key,
+    clean_version = EXCLUDED.clean_version,
+    spotify_id = COALESCE(EXCLUDED.spotify_id, tracks.spotify_id),
+    isrc = COALESCE(EXCLUDED.isrc, tracks.isrc)
+RETURNING id
+`
+
+type UpsertTrackParams struct {
+	Name              string
+	Artist            string
+	Genre             []string
+	DurationInSeconds int32
+	Year              string
+	Explicit          bool
+	Bpm               int32
+	OriginalKey       string
+	CleanVersion      bool
+	SpotifyID         sql.NullString
+	Isrc              sql.NullString
+}
+
+func (q *Queries) UpsertTrack(ctx context.Context, arg UpsertTrackParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, upsertTrack,
+		arg.Name,
+		arg.Artist,
+		pq.Array(arg.Genre),
+		arg.DurationInSeconds,
+		arg.Year,
+		arg.Explicit,
+		arg.Bpm,
+		arg.OriginalKey,
+		arg.CleanVersion,
+		arg.SpotifyID,
+		arg.Isrc,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
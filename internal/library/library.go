@@ -0,0 +1,347 @@
+package library
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var CSVHeader = []string{"name", "artist", "genre", "duration_in_seconds", "year", "explicit", "clean_version", "bpm", "original_key", "spotify_id", "isrc", "singers"}
+
+type SingerKey struct {
+	Singer string `json:"singer"`
+	Key    string `json:"key"`
+}
+
+type Entry struct {
+	Name              string      `json:"name"`
+	Artist            string      `json:"artist"`
+	Genre             []string    `json:"genre"`
+	DurationInSeconds int32       `json:"duration_in_seconds"`
+	Year              string      `json:"year"`
+	Explicit          bool        `json:"explicit"`
+	CleanVersion      bool        `json:"clean_version"`
+	Bpm               int32       `json:"bpm"`
+	OriginalKey       string      `json:"original_key"`
+	SpotifyID         string      `json:"spotify_id"`
+	ISRC              string      `json:"isrc"`
+	Singers           []SingerKey `json:"singers"`
+	Row               int         `json:"-"`
+}
+
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func FormatFromPath(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return "csv", nil
+	case ".json":
+		return "json", nil
+	}
+	return "", fmt.Errorf("unable to tell format of %s, please use a .csv or .json file", path)
+}
+
+func Write(w io.Writer, format string, entries []Entry) error {
+	switch format {
+	case "csv":
+		return WriteCSV(w, entries)
+	case "json":
+		return WriteJSON(w, entries)
+	}
+	return fmt.Errorf("invalid format %s, please choose csv or json", format)
+}
+
+func Read(r io.Reader, format string) ([]Entry, error) {
+	switch format {
+	case "csv":
+		return ReadCSV(r)
+	case "json":
+		return ReadJSON(r)
+	}
+	return nil, fmt.Errorf("invalid format %s, please choose csv or json", format)
+}
+
+func WriteJSON(w io.Writer, entries []Entry) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
+
+func ReadJSON(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("unable to decode JSON: %v", err)
+	}
+	for i := range entries {
+		entries[i].Row = i + 1
+	}
+	return entries, nil
+}
+
+// WriteCSV writes one row per track. Genres are separated by ';' and singers are written as
+// 'Singer:Key' pairs separated by ';' so the file can be edited in a spreadsheet.
+func WriteCSV(w io.Writer, entries []Entry) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(CSVHeader); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		singers := []string{}
+		for _, singer := range entry.Singers {
+			singers = append(singers, singer.Singer+":"+singer.Key)
+		}
+		record := []string{
+			entry.Name,
+			entry.Artist,
+			strings.Join(entry.Genre, ";"),
+			strconv.Itoa(int(entry.DurationInSeconds)),
+			entry.Year,
+			strconv.FormatBool(entry.Explicit),
+			strconv.FormatBool(entry.CleanVersion),
+			strconv.Itoa(int(entry.Bpm)),
+			entry.OriginalKey,
+			entry.SpotifyID,
+			entry.ISRC,
+			strings.Join(singers, ";"),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadCSV reads rows written by WriteCSV. Row numbers count the header as row 1 so they match
+// the line numbers shown in a spreadsheet.
+func ReadCSV(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, headerErr := reader.Read()
+	if headerErr != nil {
+		return nil, fmt.Errorf("unable to read CSV header: %v", headerErr)
+	}
+	columns := map[string]int{}
+	for i, column := range header {
+		columns[strings.TrimSpace(strings.ToLower(column))] = i
+	}
+	for _, required := range []string{"name", "artist"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header is missing required column %s", required)
+		}
+	}
+
+	var entries []Entry
+	var rowErrs []error
+	row := 1
+	for {
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		}
+		row++
+		if readErr != nil {
+			return nil, RowError{Row: row, Err: readErr}
+		}
+		get := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		entry := Entry{
+			Name:        get("name"),
+			Artist:      get("artist"),
+			Year:        get("year"),
+			OriginalKey: get("original_key"),
+			SpotifyID:   get("spotify_id"),
+			ISRC:        get("isrc"),
+			Row:         row,
+		}
+		for _, genre := range strings.Split(get("genre"), ";") {
+			if genre = strings.TrimSpace(genre); genre != "" {
+				entry.Genre = append(entry.Genre, genre)
+			}
+		}
+		if value := get("duration_in_seconds"); value != "" {
+			duration, err := strconv.Atoi(value)
+			if err != nil {
+				rowErrs = append(rowErrs, RowError{Row: row, Err: fmt.Errorf("invalid duration_in_seconds %q", value)})
+			}
+			entry.DurationInSeconds = int32(duration)
+		}
+		if value := get("bpm"); value != "" {
+			bpm, err := strconv.Atoi(value)
+			if err != nil {
+				rowErrs = append(rowErrs, RowError{Row: row, Err: fmt.Errorf("invalid bpm %q", value)})
+			}
+			entry.Bpm = int32(bpm)
+		}
+		if value := get("explicit"); value != "" {
+			explicit, err := strconv.ParseBool(value)
+			if err != nil {
+				rowErrs = append(rowErrs, RowError{Row: row, Err: fmt.Errorf("invalid explicit value %q, please use true or false", value)})
+			}
+			entry.Explicit = explicit
+		}
+		if value := get("clean_version"); value != "" {
+			cleanVersion, err := strconv.ParseBool(value)
+			if err != nil {
+				rowErrs = append(rowErrs, RowError{Row: row, Err: fmt.Errorf("invalid clean_version value %q, please use true or false", value)})
+			}
+			entry.CleanVersion = cleanVersion
+		}
+		for _, pair := range strings.Split(get("singers"), ";") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			singer, key, found := strings.Cut(pair, ":")
+			if !found {
+				rowErrs = append(rowErrs, RowError{Row: row, Err: fmt.Errorf("invalid singer %q, please use Singer:Key", pair)})
+				continue
+			}
+			entry.Singers = append(entry.Singers, SingerKey{Singer: strings.TrimSpace(singer), Key: strings.TrimSpace(key)})
+		}
+		entries = append(entries, entry)
+	}
+	if len(rowErrs) > 0 {
+		return entries, ValidationErrors(rowErrs)
+	}
+	return entries, nil
+}
+
+type ValidationErrors []error
+
+func (v ValidationErrors) Error() string {
+	messages := []string{}
+	for _, err := range v {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Validate checks every entry against the rules used by the interactive commands and returns
+// all row errors at once. validSinger and validKey receive lower case input.
+func Validate(entries []Entry, validSinger, validKey func(string) bool) error {
+	var rowErrs []error
+	seen := map[string]int{}
+	for _, entry := range entries {
+		fail := func(format string, a ...any) {
+			rowErrs = append(rowErrs, RowError{Row: entry.Row, Err: fmt.Errorf(format, a...)})
+		}
+		if entry.Name == "" {
+			fail("name is required")
+		}
+		if entry.Artist == "" {
+			fail("artist is required")
+		}
+		id := strings.ToLower(entry.Name) + "\x00" + strings.ToLower(entry.Artist)
+		if firstRow, ok := seen[id]; ok {
+			fail("%s - %s is a duplicate of row %d", entry.Name, entry.Artist, firstRow)
+		} else {
+			seen[id] = entry.Row
+		}
+		if entry.DurationInSeconds <= 0 {
+			fail("duration_in_seconds must be greater than 0")
+		}
+		if entry.Bpm < 0 {
+			fail("bpm cannot be negative")
+		}
+		if entry.OriginalKey != "" && !validKey(strings.ToLower(entry.OriginalKey)) {
+			fail("invalid original_key %q", entry.OriginalKey)
+		}
+		singersSeen := map[string]bool{}
+		for _, singer := range entry.Singers {
+			lowerSinger := strings.ToLower(singer.Singer)
+			if !validSinger(lowerSinger) {
+				fail("invalid singer %q", singer.Singer)
+			}
+			if singersSeen[lowerSinger] {
+				fail("singer %q is listed more than once", singer.Singer)
+			}
+			singersSeen[lowerSinger] = true
+			if !validKey(strings.ToLower(singer.Key)) {
+				fail("invalid key %q for singer %s", singer.Key, singer.Singer)
+			}
+		}
+	}
+	if len(rowErrs) > 0 {
+		return ValidationErrors(rowErrs)
+	}
+	return nil
+}
+
+type Change struct {
+	Entry   Entry
+	New     bool
+	Changes []string
+}
+
+// Diff compares imported entries against the current library. Entries that already match are
+// left out of the result.
+func Diff(current, incoming []Entry) []Change {
+	existing := map[string]Entry{}
+	for _, entry := range current {
+		existing[entry.Name+"\x00"+entry.Artist] = entry
+	}
+	var changes []Change
+	for _, entry := range incoming {
+		old, ok := existing[entry.Name+"\x00"+entry.Artist]
+		if !ok {
+			changes = append(changes, Change{Entry: entry, New: true})
+			continue
+		}
+		var fields []string
+		compare := func(field, before, after string) {
+			if before != after {
+				fields = append(fields, fmt.Sprintf("%s: %q -> %q", field, before, after))
+			}
+		}
+		compare("genre", strings.Join(old.Genre, ";"), strings.Join(entry.Genre, ";"))
+		compare("duration_in_seconds", strconv.Itoa(int(old.DurationInSeconds)), strconv.Itoa(int(entry.DurationInSeconds)))
+		compare("year", old.Year, entry.Year)
+		compare("explicit", strconv.FormatBool(old.Explicit), strconv.FormatBool(entry.Explicit))
+		compare("clean_version", strconv.FormatBool(old.CleanVersion), strconv.FormatBool(entry.CleanVersion))
+		compare("bpm", strconv.Itoa(int(old.Bpm)), strconv.Itoa(int(entry.Bpm)))
+		compare("original_key", old.OriginalKey, entry.OriginalKey)
+		// blank IDs keep the ones already saved, so older files don't clear them
+		if entry.SpotifyID != "" {
+			compare("spotify_id", old.SpotifyID, entry.SpotifyID)
+		}
+		if entry.ISRC != "" {
+			compare("isrc", old.ISRC, entry.ISRC)
+		}
+		oldSingers := map[string]string{}
+		for _, singer := range old.Singers {
+			oldSingers[singer.Singer] = singer.Key
+		}
+		sortedSingers := append([]SingerKey{}, entry.Singers...)
+		sort.Slice(sortedSingers, func(i, j int) bool { return sortedSingers[i].Singer < sortedSingers[j].Singer })
+		for _, singer := range sortedSingers {
+			oldKey, ok := oldSingers[singer.Singer]
+			if !ok {
+				fields = append(fields, fmt.Sprintf("singer %s: added in %s", singer.Singer, singer.Key))
+			} else if oldKey != singer.Key {
+				fields = append(fields, fmt.Sprintf("singer %s: %s -> %s", singer.Singer, oldKey, singer.Key))
+			}
+		}
+		if len(fields) > 0 {
+			changes = append(changes, Change{Entry: entry, Changes: fields})
+		}
+	}
+	return changes
+}
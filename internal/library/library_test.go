@@ -0,0 +1,266 @@
+package library
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []Entry
+	}{
+		{
+			name: "every column",
+			entries: []Entry{
+				{
+					Name:              "Mr. Brightside",
+					Artist:            "The Killers",
+					Genre:             []string{"rock", "indie"},
+					DurationInSeconds: 222,
+					Year:              "2004",
+					Bpm:               148,
+					OriginalKey:       "Db",
+					SpotifyID:         "003vvx7Niy0yvhvHt4a68B",
+					ISRC:              "USIR20400274",
+					Singers:           []SingerKey{{Singer: "Ty", Key: "Db"}, {Singer: "Riley", Key: "Bb"}},
+				},
+				{
+					Name:              "Pink Pony Club",
+					Artist:            "Chappell Roan",
+					DurationInSeconds: 258,
+					Year:              "2020",
+					Explicit:          true,
+					CleanVersion:      true,
+					Bpm:               116,
+					OriginalKey:       "F",
+				},
+			},
+		},
+		{
+			name: "commas and quotes",
+			entries: []Entry{
+				{Name: `Don't Stop "Believin'"`, Artist: "Journey, Live", DurationInSeconds: 250, Bpm: 119},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if writeErr := WriteCSV(&buf, tt.entries); writeErr != nil {
+				t.Fatalf("unexpected error: %v", writeErr)
+			}
+			entries, readErr := ReadCSV(&buf)
+			if readErr != nil {
+				t.Fatalf("unexpected error: %v", readErr)
+			}
+			expected := slices.Clone(tt.entries)
+			for i := range expected {
+				expected[i].Row = i + 2
+			}
+			if !reflect.DeepEqual(entries, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, entries)
+			}
+		})
+	}
+}
+
+func TestReadCSVOlderHeader(t *testing.T) {
+	// files exported before clean versions and track IDs were added
+	input := "name,artist,genre,duration_in_seconds,year,explicit,bpm,original_key,singers\n" +
+		"Africa,Toto,soft rock,295,1982,false,93,B,Riley:B;Ty:A\n"
+	entries, readErr := ReadCSV(strings.NewReader(input))
+	if readErr != nil {
+		t.Fatalf("unexpected error: %v", readErr)
+	}
+	expected := []Entry{{
+		Name:              "Africa",
+		Artist:            "Toto",
+		Genre:             []string{"soft rock"},
+		DurationInSeconds: 295,
+		Year:              "1982",
+		Bpm:               93,
+		OriginalKey:       "B",
+		Singers:           []SingerKey{{Singer: "Riley", Key: "B"}, {Singer: "Ty", Key: "A"}},
+		Row:               2,
+	}}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, entries)
+	}
+
+	if _, headerErr := ReadCSV(strings.NewReader("name,genre\nAfrica,rock\n")); headerErr == nil {
+		t.Error("Expected an error for a header without an artist column")
+	}
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		rows  []int
+	}{
+		{
+			name:  "bad numbers",
+			input: "name,artist,duration_in_seconds,bpm\nAfrica,Toto,long,93\nValerie,Amy Winehouse,220,fast\n",
+			rows:  []int{2, 3},
+		},
+		{
+			name:  "bad flags",
+			input: "name,artist,explicit,clean_version\nAfrica,Toto,maybe,false\nValerie,Amy Winehouse,true,sometimes\n",
+			rows:  []int{2, 3},
+		},
+		{
+			name:  "singer without a key",
+			input: "name,artist,singers\nAfrica,Toto,Riley:B\nValerie,Amy Winehouse,Riley\n",
+			rows:  []int{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, readErr := ReadCSV(strings.NewReader(tt.input))
+			var validationErrs ValidationErrors
+			if !errors.As(readErr, &validationErrs) {
+				t.Fatalf("Expected validation errors, got %v", readErr)
+			}
+			if rows := errorRows(validationErrs); !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("Expected errors on rows %v, got %v", tt.rows, validationErrs)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	validSinger := func(singer string) bool { return singer == "riley" || singer == "ty" }
+	validKey := func(key string) bool { return key == "a" || key == "b" || key == "db" }
+	valid := Entry{Name: "Africa", Artist: "Toto", DurationInSeconds: 295, OriginalKey: "B", Singers: []SingerKey{{Singer: "Riley", Key: "B"}}, Row: 2}
+	tests := []struct {
+		name    string
+		entries []Entry
+		rows    []int
+	}{
+		{
+			name:    "valid",
+			entries: []Entry{valid},
+		},
+		{
+			name: "missing fields",
+			entries: []Entry{
+				valid,
+				{Artist: "Toto", DurationInSeconds: 200, Row: 3},
+				{Name: "Valerie", Row: 4},
+			},
+			// row 4 is missing both an artist and a duration
+			rows: []int{3, 4, 4},
+		},
+		{
+			name: "duplicates ignore case",
+			entries: []Entry{
+				valid,
+				{Name: "AFRICA", Artist: "toto", DurationInSeconds: 295, Row: 3},
+			},
+			rows: []int{3},
+		},
+		{
+			name: "bad keys and singers",
+			entries: []Entry{
+				{Name: "Africa", Artist: "Toto", DurationInSeconds: 295, OriginalKey: "H", Row: 2},
+				{Name: "Valerie", Artist: "Amy Winehouse", DurationInSeconds: 220, Singers: []SingerKey{{Singer: "Sam", Key: "A"}}, Row: 3},
+				{Name: "Dreams", Artist: "Fleetwood Mac", DurationInSeconds: 254, Singers: []SingerKey{{Singer: "Ty", Key: "A"}, {Singer: "ty", Key: "Db"}}, Row: 4},
+			},
+			rows: []int{2, 3, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validateErr := Validate(tt.entries, validSinger, validKey)
+			if len(tt.rows) == 0 {
+				if validateErr != nil {
+					t.Fatalf("unexpected error: %v", validateErr)
+				}
+				return
+			}
+			var validationErrs ValidationErrors
+			if !errors.As(validateErr, &validationErrs) {
+				t.Fatalf("Expected validation errors, got %v", validateErr)
+			}
+			if rows := errorRows(validationErrs); !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("Expected errors on rows %v, got %v", tt.rows, validationErrs)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	current := []Entry{
+		{Name: "Africa", Artist: "Toto", DurationInSeconds: 295, Year: "1982", Bpm: 93, OriginalKey: "B", SpotifyID: "abc", Singers: []SingerKey{{Singer: "Riley", Key: "B"}}},
+		{Name: "Valerie", Artist: "Amy Winehouse", DurationInSeconds: 220, Explicit: true},
+	}
+	tests := []struct {
+		name     string
+		incoming []Entry
+		expected []Change
+	}{
+		{
+			name:     "unchanged",
+			incoming: current,
+		},
+		{
+			name: "blank track IDs keep the saved ones",
+			incoming: []Entry{
+				{Name: "Africa", Artist: "Toto", DurationInSeconds: 295, Year: "1982", Bpm: 93, OriginalKey: "B", Singers: []SingerKey{{Singer: "Riley", Key: "B"}}},
+			},
+		},
+		{
+			name: "new track",
+			incoming: []Entry{
+				{Name: "Dreams", Artist: "Fleetwood Mac", DurationInSeconds: 254},
+			},
+			expected: []Change{{Entry: Entry{Name: "Dreams", Artist: "Fleetwood Mac", DurationInSeconds: 254}, New: true}},
+		},
+		{
+			name: "changed fields and singers",
+			incoming: []Entry{
+				{Name: "Africa", Artist: "Toto", DurationInSeconds: 295, Year: "1982", Bpm: 94, OriginalKey: "B", SpotifyID: "def", Singers: []SingerKey{{Singer: "Ty", Key: "A"}, {Singer: "Riley", Key: "C"}}},
+				{Name: "Valerie", Artist: "Amy Winehouse", DurationInSeconds: 220, Explicit: true, CleanVersion: true},
+			},
+			expected: []Change{
+				{
+					Entry: Entry{Name: "Africa", Artist: "Toto", DurationInSeconds: 295, Year: "1982", Bpm: 94, OriginalKey: "B", SpotifyID: "def", Singers: []SingerKey{{Singer: "Ty", Key: "A"}, {Singer: "Riley", Key: "C"}}},
+					Changes: []string{
+						`bpm: "93" -> "94"`,
+						`spotify_id: "abc" -> "def"`,
+						"singer Riley: B -> C",
+						"singer Ty: added in A",
+					},
+				},
+				{
+					Entry:   Entry{Name: "Valerie", Artist: "Amy Winehouse", DurationInSeconds: 220, Explicit: true, CleanVersion: true},
+					Changes: []string{`clean_version: "false" -> "true"`},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if changes := Diff(current, tt.incoming); !reflect.DeepEqual(changes, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, changes)
+			}
+		})
+	}
+}
+
+// errorRows returns the row of each error, in order.
+func errorRows(errs ValidationErrors) []int {
+	rows := []int{}
+	for _, err := range errs {
+		var rowErr RowError
+		if errors.As(err, &rowErr) {
+			rows = append(rows, rowErr.Row)
+		}
+	}
+	return rows
+}
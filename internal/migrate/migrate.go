@@ -0,0 +1,306 @@
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Migration is one numbered goose file from sql/schema, split into its up and down halves.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration along with when it was applied. AppliedAt is nil for pending ones.
+type Status struct {
+	Migration Migration
+	AppliedAt *time.Time
+}
+
+var (
+	fileNamePattern    = regexp.MustCompile(`^(\d+)_(.+)\.sql$`)
+	createTablePattern = regexp.MustCompile(`(?i)CREATE TABLE (?:IF NOT EXISTS )?(\w+)`)
+)
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+// Load reads and sorts the migrations in fsys.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, readErr := fs.ReadDir(fsys, ".")
+	if readErr != nil {
+		return nil, readErr
+	}
+	migrations := []Migration{}
+	seen := map[int64]string{}
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", other, entry.Name())
+		}
+		seen[version] = entry.Name()
+		data, fileErr := fs.ReadFile(fsys, entry.Name())
+		if fileErr != nil {
+			return nil, fileErr
+		}
+		up, down, parseErr := parse(string(data))
+		if parseErr != nil {
+			return nil, fmt.Errorf("%s: %v", entry.Name(), parseErr)
+		}
+		migrations = append(migrations, Migration{Version: version, Name: match[2], Up: up, Down: down})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// parse splits a goose file on its "-- +goose Up" and "-- +goose Down" annotations.
+func parse(data string) (string, string, error) {
+	var up, down strings.Builder
+	var current *strings.Builder
+	for _, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "-- +goose") {
+			switch strings.TrimSpace(strings.TrimPrefix(trimmed, "-- +goose")) {
+			case "Up":
+				current = &up
+			case "Down":
+				current = &down
+			}
+			// StatementBegin/End only matter to goose's statement splitting
+			continue
+		}
+		if current != nil {
+			current.WriteString(line)
+			current.WriteString("\n")
+		}
+	}
+	if strings.TrimSpace(up.String()) == "" {
+		return "", "", fmt.Errorf("no '-- +goose Up' section")
+	}
+	return up.String(), down.String(), nil
+}
+
+// applied returns when each recorded migration was applied, creating the schema_migrations
+// table first if needed. Databases set up before it existed get their history imported.
+func applied(db *sql.DB, migrations []Migration) (map[int64]time.Time, error) {
+	exists, existsErr := tableExists(db, "schema_migrations")
+	if existsErr != nil {
+		return nil, existsErr
+	}
+	if !exists {
+		if _, createErr := db.Exec(createMigrationsTable); createErr != nil {
+			return nil, fmt.Errorf("unable to create schema_migrations table: %v", createErr)
+		}
+		if importErr := importHistory(db, migrations); importErr != nil {
+			return nil, importErr
+		}
+	}
+	return readApplied(db)
+}
+
+func readApplied(db *sql.DB) (map[int64]time.Time, error) {
+	rows, queryErr := db.Query("SELECT version, applied_at FROM schema_migrations")
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer rows.Close()
+	versions := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if scanErr := rows.Scan(&version, &appliedAt); scanErr != nil {
+			return nil, scanErr
+		}
+		versions[version] = appliedAt
+	}
+	return versions, rows.Err()
+}
+
+// importHistory fills in schema_migrations for a database that was migrated by goose or created
+// from init.sql. Goose's own table is used when it's there, otherwise migrations count as
+// applied for as long as every table they create already exists.
+func importHistory(db *sql.DB, migrations []Migration) error {
+	gooseExists, gooseErr := tableExists(db, "goose_db_version")
+	if gooseErr != nil {
+		return gooseErr
+	}
+	versions := map[int64]bool{}
+	if gooseExists {
+		rows, queryErr := db.Query("SELECT version_id, is_applied FROM goose_db_version ORDER BY id")
+		if queryErr != nil {
+			return fmt.Errorf("unable to read goose_db_version: %v", queryErr)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var version int64
+			var isApplied bool
+			if scanErr := rows.Scan(&version, &isApplied); scanErr != nil {
+				return scanErr
+			}
+			// goose adds a row for every up and down, so the last one wins
+			versions[version] = isApplied
+		}
+		if rowsErr := rows.Err(); rowsErr != nil {
+			return rowsErr
+		}
+	} else {
+		for _, migration := range migrations {
+			tables := createTablePattern.FindAllStringSubmatch(migration.Up, -1)
+			if len(tables) == 0 {
+				break
+			}
+			allExist := true
+			for _, table := range tables {
+				exists, existsErr := tableExists(db, table[1])
+				if existsErr != nil {
+					return existsErr
+				}
+				allExist = allExist && exists
+			}
+			if !allExist {
+				break
+			}
+			versions[migration.Version] = true
+		}
+	}
+	for _, migration := range migrations {
+		if !versions[migration.Version] {
+			continue
+		}
+		if _, insertErr := db.Exec("INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name); insertErr != nil {
+			return fmt.Errorf("unable to record migration %d: %v", migration.Version, insertErr)
+		}
+	}
+	return nil
+}
+
+func tableExists(db *sql.DB, table string) (bool, error) {
+	var name sql.NullString
+	if queryErr := db.QueryRow("SELECT to_regclass($1)::text", "public."+table).Scan(&name); queryErr != nil {
+		return false, queryErr
+	}
+	return name.Valid, nil
+}
+
+// Statuses lists every migration and whether it has been applied.
+func Statuses(db *sql.DB, migrations []Migration) ([]Status, error) {
+	versions, appliedErr := applied(db, migrations)
+	if appliedErr != nil {
+		return nil, appliedErr
+	}
+	statuses := []Status{}
+	for _, migration := range migrations {
+		status := Status{Migration: migration}
+		if appliedAt, ok := versions[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Up applies every pending migration in order, each in its own transaction, and returns the
+// ones it applied.
+func Up(db *sql.DB, migrations []Migration) ([]Migration, error) {
+	versions, appliedErr := applied(db, migrations)
+	if appliedErr != nil {
+		return nil, appliedErr
+	}
+	done := []Migration{}
+	for _, migration := range migrations {
+		if _, ok := versions[migration.Version]; ok {
+			continue
+		}
+		runErr := run(db, migration.Up, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+		if runErr != nil {
+			return done, fmt.Errorf("migration %d_%s failed: %v", migration.Version, migration.Name, runErr)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down rolls back the most recently applied migration. It returns nil if nothing is applied.
+func Down(db *sql.DB, migrations []Migration) (*Migration, error) {
+	versions, appliedErr := applied(db, migrations)
+	if appliedErr != nil {
+		return nil, appliedErr
+	}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		if _, ok := versions[migration.Version]; !ok {
+			continue
+		}
+		if strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("migration %d_%s can't be rolled back, it has no down section", migration.Version, migration.Name)
+		}
+		runErr := run(db, migration.Down, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+		if runErr != nil {
+			return nil, fmt.Errorf("rolling back %d_%s failed: %v", migration.Version, migration.Name, runErr)
+		}
+		return &migration, nil
+	}
+	return nil, nil
+}
+
+func run(db *sql.DB, statements string, record string, args ...any) error {
+	tx, txErr := db.Begin()
+	if txErr != nil {
+		return txErr
+	}
+	defer tx.Rollback()
+	if _, execErr := tx.Exec(statements); execErr != nil {
+		return execErr
+	}
+	if _, recordErr := tx.Exec(record, args...); recordErr != nil {
+		return recordErr
+	}
+	return tx.Commit()
+}
+
+// Check makes sure the database schema matches the migrations without changing anything.
+func Check(db *sql.DB, migrations []Migration) error {
+	exists, existsErr := tableExists(db, "schema_migrations")
+	if existsErr != nil {
+		return fmt.Errorf("unable to check the database schema: %v", existsErr)
+	}
+	if !exists {
+		return fmt.Errorf("the database has no migration history yet, run ./setlist migrate up to set it up")
+	}
+	versions, readErr := readApplied(db)
+	if readErr != nil {
+		return fmt.Errorf("unable to read schema_migrations: %v", readErr)
+	}
+	known := map[int64]bool{}
+	pending := []string{}
+	for _, migration := range migrations {
+		known[migration.Version] = true
+		if _, ok := versions[migration.Version]; !ok {
+			pending = append(pending, fmt.Sprintf("%d_%s", migration.Version, migration.Name))
+		}
+	}
+	for version := range versions {
+		if !known[version] {
+			return fmt.Errorf("the database has migration %d, which this version of setlist doesn't know about, please update setlist", version)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("the database schema is out of date, %d migrations pending (%s), run ./setlist migrate up to upgrade it", len(pending), strings.Join(pending, ", "))
+	}
+	return nil
+}
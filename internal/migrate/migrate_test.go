@@ -0,0 +1,58 @@
+package migrate
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/rjfeeney/setlist_builder/sql/schema"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"002_second.sql": {Data: []byte("-- +goose Up\nALTER TABLE a ADD COLUMN b TEXT;\n\n-- +goose Down\nALTER TABLE a DROP COLUMN b;\n")},
+		"001_first.sql":  {Data: []byte("-- +goose Up\n-- +goose StatementBegin\nCREATE TABLE a (id INT);\n-- +goose StatementEnd\n-- +goose Down\nDROP TABLE a;\n")},
+		"README.md":      {Data: []byte("not a migration")},
+	}
+	migrations, loadErr := Load(fsys)
+	if loadErr != nil {
+		t.Fatalf("unexpected error: %v", loadErr)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 1 || migrations[1].Name != "second" {
+		t.Fatalf("Unexpected migrations: %+v", migrations)
+	}
+	if strings.Contains(migrations[0].Up, "goose") || !strings.Contains(migrations[0].Up, "CREATE TABLE a") {
+		t.Errorf("Unexpected up section: %q", migrations[0].Up)
+	}
+	if strings.TrimSpace(migrations[0].Down) != "DROP TABLE a;" {
+		t.Errorf("Unexpected down section: %q", migrations[0].Down)
+	}
+
+	fsys["003_broken.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE c (id INT);")}
+	if _, loadErr := Load(fsys); loadErr == nil {
+		t.Errorf("Expected an error for a migration without an up section")
+	}
+}
+
+// init.sql creates the schema for new Docker databases, so it has to record every migration.
+func TestInitSQLRecordsEveryMigration(t *testing.T) {
+	migrations, loadErr := Load(schema.Migrations)
+	if loadErr != nil {
+		t.Fatalf("unexpected error: %v", loadErr)
+	}
+	data, readErr := os.ReadFile("../../init.sql")
+	if readErr != nil {
+		t.Fatalf("unable to read init.sql: %v", readErr)
+	}
+	recorded := regexp.MustCompile(`\((\d+), '(\w+)'\)`).FindAllStringSubmatch(string(data), -1)
+	if len(recorded) != len(migrations) {
+		t.Fatalf("Expected init.sql to record %d migrations, got %d", len(migrations), len(recorded))
+	}
+	for i, migration := range migrations {
+		if recorded[i][2] != migration.Name {
+			t.Errorf("Expected init.sql to record %d_%s, got %s_%s", migration.Version, migration.Name, recorded[i][1], recorded[i][2])
+		}
+	}
+}
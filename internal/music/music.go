@@ -0,0 +1,155 @@
+package music
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var pitchClasses = map[string]int{
+	"c": 0, "c#": 1, "db": 1, "d": 2, "d#": 3, "eb": 3, "e": 4, "f": 5,
+	"f#": 6, "gb": 6, "g": 7, "g#": 8, "ab": 8, "a": 9, "a#": 10, "bb": 10, "b": 11,
+}
+
+var sharpNames = []string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B"}
+var flatNames = []string{"C", "Db", "D", "Eb", "E", "F", "Gb", "G", "Ab", "A", "Bb", "B"}
+
+// PitchClass returns 0-11 for a key or note name without an octave, e.g. "Bb" or "c#".
+func PitchClass(key string) (int, error) {
+	pitch, ok := pitchClasses[strings.ToLower(strings.TrimSpace(key))]
+	if !ok {
+		return 0, fmt.Errorf("invalid key %q", key)
+	}
+	return pitch, nil
+}
+
+// ParseNote converts scientific pitch notation ("A3", "C#5", "Eb4") to a MIDI note number.
+func ParseNote(note string) (int, error) {
+	note = strings.TrimSpace(note)
+	split := len(note)
+	for split > 0 && (note[split-1] >= '0' && note[split-1] <= '9' || note[split-1] == '-') {
+		split--
+	}
+	if split == 0 || split == len(note) {
+		return 0, fmt.Errorf("invalid note %q, please use a note name and octave like A3 or C#5", note)
+	}
+	pitch, pitchErr := PitchClass(note[:split])
+	if pitchErr != nil {
+		return 0, fmt.Errorf("invalid note %q, please use a note name and octave like A3 or C#5", note)
+	}
+	octave, octaveErr := strconv.Atoi(note[split:])
+	if octaveErr != nil {
+		return 0, fmt.Errorf("invalid octave in note %q", note)
+	}
+	return (octave+1)*12 + pitch, nil
+}
+
+// NoteName converts a MIDI note number back to scientific pitch notation.
+func NoteName(midi int, flats bool) string {
+	names := sharpNames
+	if flats {
+		names = flatNames
+	}
+	return fmt.Sprintf("%s%d", names[((midi%12)+12)%12], midi/12-1)
+}
+
+// UsesFlats reports whether a key is spelled with a flat, so transposed keys can keep the
+// same spelling.
+func UsesFlats(key string) bool {
+	key = strings.TrimSpace(key)
+	return len(key) > 1 && strings.ToLower(key[1:]) == "b"
+}
+
+// TransposeKey moves a key by a number of semitones, keeping sharp or flat spelling.
+func TransposeKey(key string, semitones int) (string, error) {
+	pitch, err := PitchClass(key)
+	if err != nil {
+		return "", err
+	}
+	names := sharpNames
+	if UsesFlats(key) {
+		names = flatNames
+	}
+	return names[(((pitch+semitones)%12)+12)%12], nil
+}
+
+type Range struct {
+	Low  int
+	High int
+}
+
+func ParseRange(low, high string) (Range, error) {
+	lowNote, lowErr := ParseNote(low)
+	if lowErr != nil {
+		return Range{}, lowErr
+	}
+	highNote, highErr := ParseNote(high)
+	if highErr != nil {
+		return Range{}, highErr
+	}
+	if lowNote > highNote {
+		return Range{}, fmt.Errorf("lowest note %s is higher than highest note %s", low, high)
+	}
+	return Range{Low: lowNote, High: highNote}, nil
+}
+
+func (r Range) Transpose(semitones int) Range {
+	return Range{Low: r.Low + semitones, High: r.High + semitones}
+}
+
+func (r Range) String(flats bool) string {
+	return NoteName(r.Low, flats) + "-" + NoteName(r.High, flats)
+}
+
+type Suggestion struct {
+	Key       string
+	Semitones int
+	Range     Range
+	Fits      bool
+}
+
+// SuggestKey finds the transposition (up to two octaves either way) that puts the melody in the
+// singer's range. Keys closest to the original win, then the one leaving the most room at the
+// edges of the range. When nothing fits, the key that overshoots the range the least is
+// returned with Fits set to false.
+func SuggestKey(originalKey string, melody, singer Range) (Suggestion, error) {
+	if _, err := PitchClass(originalKey); err != nil {
+		return Suggestion{}, err
+	}
+	best := Suggestion{}
+	bestDistance, bestScore := 0, 0
+	found := false
+	for semitones := -24; semitones <= 24; semitones++ {
+		transposed := melody.Transpose(semitones)
+		lowRoom := transposed.Low - singer.Low
+		highRoom := singer.High - transposed.High
+		fits := lowRoom >= 0 && highRoom >= 0
+		distance := ((semitones % 12) + 12) % 12
+		if distance > 6 {
+			distance = 12 - distance
+		}
+		score := min(lowRoom, highRoom)
+		better := !found ||
+			(fits && !best.Fits) ||
+			(fits == best.Fits && fits && (distance < bestDistance || distance == bestDistance && score > bestScore)) ||
+			(fits == best.Fits && !fits && score > bestScore)
+		if !better {
+			continue
+		}
+		key, _ := TransposeKey(originalKey, semitones)
+		best = Suggestion{Key: key, Semitones: semitones, Range: transposed, Fits: fits}
+		bestDistance, bestScore = distance, score
+		found = true
+	}
+	return best, nil
+}
+
+// FormatNote re-spells a note typed by the user, e.g. "c#4" becomes "C#4".
+func FormatNote(note string) (string, error) {
+	midi, err := ParseNote(note)
+	if err != nil {
+		return "", err
+	}
+	note = strings.TrimSpace(note)
+	return NoteName(midi, strings.Contains(strings.ToLower(note[1:]), "b")), nil
+}
@@ -0,0 +1,86 @@
+package music
+
+import "testing"
+
+func TestParseNote(t *testing.T) {
+	tests := []struct {
+		note          string
+		expected      int
+		expectedError bool
+	}{
+		{note: "C4", expected: 60},
+		{note: "a3", expected: 57},
+		{note: "C#5", expected: 73},
+		{note: "Eb4", expected: 63},
+		{note: "H2", expectedError: true},
+		{note: "C", expectedError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.note, func(t *testing.T) {
+			got, err := ParseNote(tt.note)
+			if (err != nil) != tt.expectedError {
+				t.Fatalf("Expected error: %v, got error: %v", tt.expectedError, err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTransposeKey(t *testing.T) {
+	tests := []struct {
+		key       string
+		semitones int
+		expected  string
+	}{
+		{key: "C", semitones: 2, expected: "D"},
+		{key: "Bb", semitones: 3, expected: "Db"},
+		{key: "F#", semitones: -7, expected: "B"},
+		{key: "a", semitones: 3, expected: "C"},
+	}
+	for _, tt := range tests {
+		got, err := TransposeKey(tt.key, tt.semitones)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != tt.expected {
+			t.Errorf("Expected %s + %d to be %s, got %s", tt.key, tt.semitones, tt.expected, got)
+		}
+	}
+}
+
+func TestSuggestKey(t *testing.T) {
+	singer, _ := ParseRange("A2", "A4")
+	tests := []struct {
+		name         string
+		key          string
+		melodyLow    string
+		melodyHigh   string
+		expectedKey  string
+		expectedFits bool
+	}{
+		{name: "original key fits", key: "G", melodyLow: "B2", melodyHigh: "E4", expectedKey: "G", expectedFits: true},
+		{name: "female song down an octave", key: "E", melodyLow: "B3", melodyHigh: "E5", expectedKey: "E", expectedFits: true},
+		{name: "needs to come down", key: "D", melodyLow: "D3", melodyHigh: "B4", expectedKey: "C", expectedFits: true},
+		{name: "too wide", key: "C", melodyLow: "C2", melodyHigh: "C5", expectedFits: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			melody, err := ParseRange(tt.melodyLow, tt.melodyHigh)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := SuggestKey(tt.key, melody, singer)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Fits != tt.expectedFits {
+				t.Errorf("Expected fits: %v, got fits: %v", tt.expectedFits, got.Fits)
+			}
+			if tt.expectedFits && got.Key != tt.expectedKey {
+				t.Errorf("Expected key %s, got %s (%+d)", tt.expectedKey, got.Key, got.Semitones)
+			}
+		})
+	}
+}
@@ -0,0 +1,552 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rjfeeney/setlist_builder/internal/constants"
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+)
+
+// Store is the part of database.Queries the server uses, so handlers can be tested without a
+// database.
+type Store interface {
+	GetAllTracks(ctx context.Context) ([]database.Track, error)
+	GetTrackByID(ctx context.Context, id int32) (database.Track, error)
+	AddOriginalKey(ctx context.Context, arg database.AddOriginalKeyParams) error
+	GetAllSingers(ctx context.Context) ([]database.GetAllSingersRow, error)
+	GetTracksWithSingers(ctx context.Context) ([]database.GetTracksWithSingersRow, error)
+	GetAllSingerParts(ctx context.Context) ([]database.GetAllSingerPartsRow, error)
+	GetAllTrackRequirements(ctx context.Context) ([]database.GetAllTrackRequirementsRow, error)
+	GetGenreBuckets(ctx context.Context) ([]database.GenreBucket, error)
+	GetSingerAssignments(ctx context.Context, singer string) ([]database.GetSingerAssignmentsRow, error)
+	GetSingersForTrack(ctx context.Context, trackID int32) ([]database.Singer, error)
+	UpsertSinger(ctx context.Context, arg database.UpsertSingerParams) error
+	RemoveSinger(ctx context.Context, arg database.RemoveSingerParams) (int64, error)
+	CreateSetlist(ctx context.Context, arg database.CreateSetlistParams) (database.Setlist, error)
+	GetSetlist(ctx context.Context, id int32) (database.Setlist, error)
+	GetAllSetlists(ctx context.Context) ([]database.Setlist, error)
+	DeleteSetlist(ctx context.Context, id int32) (int64, error)
+}
+
+type BuildFunc func(ctx context.Context, input service.BuildInput) (*service.Setlist, error)
+
+type Server struct {
+	store Store
+	build BuildFunc
+	// builds share the working table, so only one can run at a time
+	buildMu sync.Mutex
+	mux     *http.ServeMux
+}
+
+func New(store Store, build BuildFunc) *Server {
+	s := &Server{
+		store: store,
+		build: build,
+		mux:   http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /api/options", s.handleOptions)
+	s.mux.HandleFunc("GET /api/tracks", s.handleListTracks)
+	s.mux.HandleFunc("GET /api/tracks/{id}", s.handleGetTrack)
+	s.mux.HandleFunc("PUT /api/tracks/{id}/key", s.handleSetKey)
+	s.mux.HandleFunc("PUT /api/tracks/{id}/singers/{singer}", s.handleSetSinger)
+	s.mux.HandleFunc("DELETE /api/tracks/{id}/singers/{singer}", s.handleRemoveSinger)
+	s.mux.HandleFunc("GET /api/singers", s.handleListSingers)
+	s.mux.HandleFunc("POST /api/builds", s.handleBuild)
+	s.mux.HandleFunc("POST /api/validate", s.handleValidate)
+	s.mux.HandleFunc("POST /api/regenerate", s.handleRegenerate)
+	s.mux.HandleFunc("POST /api/swap", s.handleSwap)
+	s.mux.HandleFunc("GET /api/setlists", s.handleListSetlists)
+	s.mux.HandleFunc("POST /api/setlists", s.handleCreateSetlist)
+	s.mux.HandleFunc("GET /api/setlists/{id}", s.handleGetSetlist)
+	s.mux.HandleFunc("DELETE /api/setlists/{id}", s.handleDeleteSetlist)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type singerKey struct {
+	Singer string `json:"singer"`
+	Key    string `json:"key"`
+}
+
+type trackResponse struct {
+	ID                int32       `json:"id"`
+	Name              string      `json:"name"`
+	Artist            string      `json:"artist"`
+	Genre             []string    `json:"genre"`
+	DurationInSeconds int32       `json:"duration_in_seconds"`
+	Year              string      `json:"year"`
+	Explicit          bool        `json:"explicit"`
+	CleanVersion      bool        `json:"clean_version"`
+	Bpm               int32       `json:"bpm"`
+	OriginalKey       string      `json:"original_key"`
+	Singers           []singerKey `json:"singers"`
+}
+
+type assignmentResponse struct {
+	TrackID int32  `json:"track_id"`
+	Song    string `json:"song"`
+	Artist  string `json:"artist"`
+	Singer  string `json:"singer"`
+	Key     string `json:"key"`
+}
+
+type keyRequest struct {
+	Key string `json:"key"`
+}
+
+type setlistRequest struct {
+	Name    string           `json:"name"`
+	Setlist *service.Setlist `json:"setlist"`
+}
+
+type editRequest struct {
+	Setlist  *service.Setlist `json:"setlist"`
+	Set      int              `json:"set"`
+	Position int              `json:"position"`
+}
+
+type editResponse struct {
+	Setlist    *service.Setlist    `json:"setlist"`
+	Violations []service.Violation `json:"violations"`
+}
+
+type setlistResponse struct {
+	ID        int32            `json:"id"`
+	Name      string           `json:"name"`
+	CreatedAt time.Time        `json:"created_at"`
+	Setlist   *service.Setlist `json:"setlist,omitempty"`
+}
+
+func newTrackResponse(track database.Track, singers []database.Singer) trackResponse {
+	response := trackResponse{
+		ID:                track.ID,
+		Name:              track.Name,
+		Artist:            track.Artist,
+		Genre:             track.Genre,
+		DurationInSeconds: track.DurationInSeconds,
+		Year:              track.Year,
+		Explicit:          track.Explicit,
+		CleanVersion:      track.CleanVersion,
+		Bpm:               track.Bpm,
+		OriginalKey:       track.OriginalKey,
+		Singers:           []singerKey{},
+	}
+	if response.Genre == nil {
+		response.Genre = []string{}
+	}
+	for _, singer := range singers {
+		response.Singers = append(response.Singers, singerKey{Singer: singer.Singer, Key: singer.Key})
+	}
+	return response
+}
+
+// handleOptions lists the choices the build form offers.
+func (s *Server) handleOptions(w http.ResponseWriter, r *http.Request) {
+	singers := []string{}
+	for _, singer := range constants.ValidSingers {
+		singers = append(singers, constants.Capitalize(singer))
+	}
+	keys := []string{}
+	for _, key := range constants.ValidKeys {
+		keys = append(keys, constants.Capitalize(key))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"singers":      singers,
+		"keys":         keys,
+		"max_duration": service.MaxDuration,
+	})
+}
+
+func (s *Server) handleListTracks(w http.ResponseWriter, r *http.Request) {
+	tracks, tracksErr := s.store.GetAllTracks(r.Context())
+	if tracksErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to get all tracks: %v", tracksErr))
+		return
+	}
+	singers, singersErr := s.store.GetAllSingers(r.Context())
+	if singersErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to get all singers: %v", singersErr))
+		return
+	}
+	trackSingers := map[int32][]database.Singer{}
+	for _, singer := range singers {
+		trackSingers[singer.TrackID] = append(trackSingers[singer.TrackID], database.Singer{TrackID: singer.TrackID, Singer: singer.Singer, Key: singer.Key})
+	}
+	response := []trackResponse{}
+	for _, track := range tracks {
+		response = append(response, newTrackResponse(track, trackSingers[track.ID]))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// findTrack looks up the {id} path value and writes a 404 if the track isn't in the library.
+// Tracks are found by ID because different artists can have songs with the same title.
+func (s *Server) findTrack(w http.ResponseWriter, r *http.Request) (database.Track, bool) {
+	id, idErr := strconv.Atoi(r.PathValue("id"))
+	if idErr != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid track id %q", r.PathValue("id")))
+		return database.Track{}, false
+	}
+	track, getErr := s.store.GetTrackByID(r.Context(), int32(id))
+	if errors.Is(getErr, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("track %d was not found in the database", id))
+		return track, false
+	} else if getErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("unable to look up track %d: %v", id, getErr))
+		return track, false
+	}
+	return track, true
+}
+
+func (s *Server) handleGetTrack(w http.ResponseWriter, r *http.Request) {
+	track, found := s.findTrack(w, r)
+	if !found {
+		return
+	}
+	singers, singersErr := s.store.GetSingersForTrack(r.Context(), track.ID)
+	if singersErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("unable to get singers for %s: %v", track.Name, singersErr))
+		return
+	}
+	writeJSON(w, http.StatusOK, newTrackResponse(track, singers))
+}
+
+func (s *Server) handleSetKey(w http.ResponseWriter, r *http.Request) {
+	var body keyRequest
+	if !readJSON(w, r, &body) {
+		return
+	}
+	key, keyErr := validateKey(body.Key)
+	if keyErr != nil {
+		writeError(w, http.StatusBadRequest, keyErr)
+		return
+	}
+	track, found := s.findTrack(w, r)
+	if !found {
+		return
+	}
+	params := database.AddOriginalKeyParams{
+		OriginalKey: key,
+		ID:          track.ID,
+	}
+	if addErr := s.store.AddOriginalKey(r.Context(), params); addErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("error adding original key to track: %v", addErr))
+		return
+	}
+	track.OriginalKey = key
+	writeJSON(w, http.StatusOK, newTrackResponse(track, nil))
+}
+
+func (s *Server) handleSetSinger(w http.ResponseWriter, r *http.Request) {
+	var body keyRequest
+	if !readJSON(w, r, &body) {
+		return
+	}
+	singer, singerErr := validateSinger(r.PathValue("singer"))
+	if singerErr != nil {
+		writeError(w, http.StatusBadRequest, singerErr)
+		return
+	}
+	key, keyErr := validateKey(body.Key)
+	if keyErr != nil {
+		writeError(w, http.StatusBadRequest, keyErr)
+		return
+	}
+	track, found := s.findTrack(w, r)
+	if !found {
+		return
+	}
+	params := database.UpsertSingerParams{
+		TrackID: track.ID,
+		Singer:  singer,
+		Key:     key,
+	}
+	if upsertErr := s.store.UpsertSinger(r.Context(), params); upsertErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("error saving singer: %v", upsertErr))
+		return
+	}
+	writeJSON(w, http.StatusOK, assignmentResponse{TrackID: track.ID, Song: track.Name, Artist: track.Artist, Singer: singer, Key: key})
+}
+
+func (s *Server) handleRemoveSinger(w http.ResponseWriter, r *http.Request) {
+	singer, singerErr := validateSinger(r.PathValue("singer"))
+	if singerErr != nil {
+		writeError(w, http.StatusBadRequest, singerErr)
+		return
+	}
+	track, found := s.findTrack(w, r)
+	if !found {
+		return
+	}
+	params := database.RemoveSingerParams{
+		TrackID: track.ID,
+		Singer:  singer,
+	}
+	removed, removeErr := s.store.RemoveSinger(r.Context(), params)
+	if removeErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("error removing singer: %v", removeErr))
+		return
+	}
+	if removed == 0 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s is not assigned to %s", singer, track.Name))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleListSingers(w http.ResponseWriter, r *http.Request) {
+	var assignments []database.GetAllSingersRow
+	var getErr error
+	if singer := r.URL.Query().Get("singer"); singer != "" {
+		validSinger, singerErr := validateSinger(singer)
+		if singerErr != nil {
+			writeError(w, http.StatusBadRequest, singerErr)
+			return
+		}
+		var rows []database.GetSingerAssignmentsRow
+		rows, getErr = s.store.GetSingerAssignments(r.Context(), validSinger)
+		for _, row := range rows {
+			assignments = append(assignments, database.GetAllSingersRow(row))
+		}
+	} else {
+		assignments, getErr = s.store.GetAllSingers(r.Context())
+	}
+	if getErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to get singers: %v", getErr))
+		return
+	}
+	response := []assignmentResponse{}
+	for _, assignment := range assignments {
+		response = append(response, assignmentResponse{
+			TrackID: assignment.TrackID,
+			Song:    assignment.Name,
+			Artist:  assignment.Artist,
+			Singer:  assignment.Singer,
+			Key:     assignment.Key,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleBuild(w http.ResponseWriter, r *http.Request) {
+	var input service.BuildInput
+	if !readJSON(w, r, &input) {
+		return
+	}
+	s.buildMu.Lock()
+	defer s.buildMu.Unlock()
+	setlist, buildErr := s.build(r.Context(), input)
+	if buildErr != nil {
+		writeError(w, http.StatusUnprocessableEntity, buildErr)
+		return
+	}
+	writeJSON(w, http.StatusOK, setlist)
+}
+
+func (s *Server) loadLibrary(w http.ResponseWriter, r *http.Request) (*service.Library, bool) {
+	rows, getErr := s.store.GetTracksWithSingers(r.Context())
+	if getErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("unable to get tracks with singers: %v", getErr))
+		return nil, false
+	}
+	parts, partsErr := s.store.GetAllSingerParts(r.Context())
+	if partsErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("unable to get duet parts: %v", partsErr))
+		return nil, false
+	}
+	requirements, requirementsErr := s.store.GetAllTrackRequirements(r.Context())
+	if requirementsErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("unable to get track requirements: %v", requirementsErr))
+		return nil, false
+	}
+	genres, genresErr := s.store.GetGenreBuckets(r.Context())
+	if genresErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("unable to get genre buckets: %v", genresErr))
+		return nil, false
+	}
+	library := service.NewLibrary(rows)
+	library.AddParts(parts)
+	library.AddRequirements(requirements)
+	library.SetGenreMap(service.NewGenreMap(genres))
+	return library, true
+}
+
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	var setlist service.Setlist
+	if !readJSON(w, r, &setlist) {
+		return
+	}
+	library, loaded := s.loadLibrary(w, r)
+	if !loaded {
+		return
+	}
+	writeJSON(w, http.StatusOK, editResponse{Setlist: &setlist, Violations: service.Validate(&setlist, library)})
+}
+
+// readEdit reads a setlist edit and loads the library to make it with.
+func (s *Server) readEdit(w http.ResponseWriter, r *http.Request) (editRequest, *service.Library, bool) {
+	var body editRequest
+	if !readJSON(w, r, &body) {
+		return body, nil, false
+	}
+	if body.Setlist == nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("setlist is required"))
+		return body, nil, false
+	}
+	library, loaded := s.loadLibrary(w, r)
+	return body, library, loaded
+}
+
+func (s *Server) handleRegenerate(w http.ResponseWriter, r *http.Request) {
+	body, library, ok := s.readEdit(w, r)
+	if !ok {
+		return
+	}
+	if regenErr := service.RegenerateSet(body.Setlist, library, body.Set); regenErr != nil {
+		writeError(w, http.StatusBadRequest, regenErr)
+		return
+	}
+	writeJSON(w, http.StatusOK, editResponse{Setlist: body.Setlist, Violations: service.Validate(body.Setlist, library)})
+}
+
+func (s *Server) handleSwap(w http.ResponseWriter, r *http.Request) {
+	body, library, ok := s.readEdit(w, r)
+	if !ok {
+		return
+	}
+	if _, swapErr := service.SwapSong(body.Setlist, library, body.Set, body.Position); swapErr != nil {
+		writeError(w, http.StatusUnprocessableEntity, swapErr)
+		return
+	}
+	writeJSON(w, http.StatusOK, editResponse{Setlist: body.Setlist, Violations: service.Validate(body.Setlist, library)})
+}
+
+func (s *Server) handleListSetlists(w http.ResponseWriter, r *http.Request) {
+	setlists, getErr := s.store.GetAllSetlists(r.Context())
+	if getErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to get setlists: %v", getErr))
+		return
+	}
+	response := []setlistResponse{}
+	for _, setlist := range setlists {
+		response = append(response, setlistResponse{ID: setlist.ID, Name: setlist.Name, CreatedAt: setlist.CreatedAt})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleCreateSetlist(w http.ResponseWriter, r *http.Request) {
+	var body setlistRequest
+	if !readJSON(w, r, &body) {
+		return
+	}
+	body.Name = strings.TrimSpace(body.Name)
+	if body.Name == "" || body.Setlist == nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("name and setlist are required"))
+		return
+	}
+	data, marshalErr := json.Marshal(body.Setlist)
+	if marshalErr != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid setlist: %v", marshalErr))
+		return
+	}
+	params := database.CreateSetlistParams{
+		Name: body.Name,
+		Data: data,
+	}
+	setlist, createErr := s.store.CreateSetlist(r.Context(), params)
+	if createErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("unable to save setlist: %v", createErr))
+		return
+	}
+	writeJSON(w, http.StatusCreated, setlistResponse{ID: setlist.ID, Name: setlist.Name, CreatedAt: setlist.CreatedAt, Setlist: body.Setlist})
+}
+
+func (s *Server) handleGetSetlist(w http.ResponseWriter, r *http.Request) {
+	id, idErr := strconv.Atoi(r.PathValue("id"))
+	if idErr != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid setlist id %q", r.PathValue("id")))
+		return
+	}
+	setlist, getErr := s.store.GetSetlist(r.Context(), int32(id))
+	if errors.Is(getErr, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("setlist %d not found", id))
+		return
+	} else if getErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("unable to get setlist: %v", getErr))
+		return
+	}
+	var data service.Setlist
+	if unmarshalErr := json.Unmarshal(setlist.Data, &data); unmarshalErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("unable to read setlist: %v", unmarshalErr))
+		return
+	}
+	writeJSON(w, http.StatusOK, setlistResponse{ID: setlist.ID, Name: setlist.Name, CreatedAt: setlist.CreatedAt, Setlist: &data})
+}
+
+func (s *Server) handleDeleteSetlist(w http.ResponseWriter, r *http.Request) {
+	id, idErr := strconv.Atoi(r.PathValue("id"))
+	if idErr != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid setlist id %q", r.PathValue("id")))
+		return
+	}
+	removed, deleteErr := s.store.DeleteSetlist(r.Context(), int32(id))
+	if deleteErr != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("unable to delete setlist: %v", deleteErr))
+		return
+	}
+	if removed == 0 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("setlist %d not found", id))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func validateSinger(singer string) (string, error) {
+	singer = strings.TrimSpace(strings.ToLower(singer))
+	if !slices.Contains(constants.ValidSingers, singer) {
+		return "", fmt.Errorf("invalid singer %q, please choose from: %s", singer, strings.Join(constants.ValidSingers, ", "))
+	}
+	return constants.Capitalize(singer), nil
+}
+
+func validateKey(key string) (string, error) {
+	key = strings.TrimSpace(strings.ToLower(key))
+	if !slices.Contains(constants.ValidKeys, key) {
+		return "", fmt.Errorf("invalid key %q, please choose from: %s", key, strings.Join(constants.ValidKeys, ", "))
+	}
+	return constants.Capitalize(key), nil
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON body: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
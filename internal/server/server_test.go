@@ -0,0 +1,388 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+)
+
+type fakeStore struct {
+	tracks       []database.Track
+	singers      []database.Singer
+	parts        []database.SingerPart
+	requirements []database.TrackRequirement
+	genres       []database.GenreBucket
+	setlists     []database.Setlist
+}
+
+func (f *fakeStore) GetAllTracks(ctx context.Context) ([]database.Track, error) {
+	return f.tracks, nil
+}
+
+func (f *fakeStore) GetTrackByID(ctx context.Context, id int32) (database.Track, error) {
+	for _, track := range f.tracks {
+		if track.ID == id {
+			return track, nil
+		}
+	}
+	return database.Track{}, sql.ErrNoRows
+}
+
+func (f *fakeStore) AddOriginalKey(ctx context.Context, arg database.AddOriginalKeyParams) error {
+	for i := range f.tracks {
+		if f.tracks[i].ID == arg.ID {
+			f.tracks[i].OriginalKey = arg.OriginalKey
+		}
+	}
+	return nil
+}
+
+func (f *fakeStore) GetAllSingers(ctx context.Context) ([]database.GetAllSingersRow, error) {
+	var rows []database.GetAllSingersRow
+	for _, assignment := range f.singers {
+		for _, track := range f.tracks {
+			if track.ID == assignment.TrackID {
+				rows = append(rows, database.GetAllSingersRow{TrackID: track.ID, Name: track.Name, Artist: track.Artist, Singer: assignment.Singer, Key: assignment.Key})
+			}
+		}
+	}
+	return rows, nil
+}
+
+func (f *fakeStore) GetTracksWithSingers(ctx context.Context) ([]database.GetTracksWithSingersRow, error) {
+	var rows []database.GetTracksWithSingersRow
+	for _, assignment := range f.singers {
+		for _, track := range f.tracks {
+			if track.ID == assignment.TrackID {
+				rows = append(rows, database.GetTracksWithSingersRow{
+					ID:                track.ID,
+					Name:              track.Name,
+					Artist:            track.Artist,
+					DurationInSeconds: track.DurationInSeconds,
+					OriginalKey:       track.OriginalKey,
+					Singer:            assignment.Singer,
+					SingerKey:         assignment.Key,
+				})
+			}
+		}
+	}
+	return rows, nil
+}
+
+func (f *fakeStore) GetAllSingerParts(ctx context.Context) ([]database.GetAllSingerPartsRow, error) {
+	var rows []database.GetAllSingerPartsRow
+	for _, part := range f.parts {
+		for _, track := range f.tracks {
+			if track.ID == part.TrackID {
+				rows = append(rows, database.GetAllSingerPartsRow{TrackID: track.ID, Name: track.Name, Artist: track.Artist, Lead: part.Lead, Singer: part.Singer, Role: part.Role})
+			}
+		}
+	}
+	return rows, nil
+}
+
+func (f *fakeStore) GetAllTrackRequirements(ctx context.Context) ([]database.GetAllTrackRequirementsRow, error) {
+	var rows []database.GetAllTrackRequirementsRow
+	for _, requirement := range f.requirements {
+		for _, track := range f.tracks {
+			if track.ID == requirement.TrackID {
+				rows = append(rows, database.GetAllTrackRequirementsRow{TrackID: track.ID, Name: track.Name, Artist: track.Artist, Instrument: requirement.Instrument})
+			}
+		}
+	}
+	return rows, nil
+}
+
+func (f *fakeStore) GetGenreBuckets(ctx context.Context) ([]database.GenreBucket, error) {
+	return f.genres, nil
+}
+
+func (f *fakeStore) GetSingerAssignments(ctx context.Context, singer string) ([]database.GetSingerAssignmentsRow, error) {
+	rows, _ := f.GetAllSingers(ctx)
+	var assignments []database.GetSingerAssignmentsRow
+	for _, row := range rows {
+		if row.Singer == singer {
+			assignments = append(assignments, database.GetSingerAssignmentsRow(row))
+		}
+	}
+	return assignments, nil
+}
+
+func (f *fakeStore) GetSingersForTrack(ctx context.Context, trackID int32) ([]database.Singer, error) {
+	var assignments []database.Singer
+	for _, assignment := range f.singers {
+		if assignment.TrackID == trackID {
+			assignments = append(assignments, assignment)
+		}
+	}
+	return assignments, nil
+}
+
+func (f *fakeStore) UpsertSinger(ctx context.Context, arg database.UpsertSingerParams) error {
+	for i, assignment := range f.singers {
+		if assignment.TrackID == arg.TrackID && assignment.Singer == arg.Singer {
+			f.singers[i].Key = arg.Key
+			return nil
+		}
+	}
+	f.singers = append(f.singers, database.Singer(arg))
+	return nil
+}
+
+func (f *fakeStore) RemoveSinger(ctx context.Context, arg database.RemoveSingerParams) (int64, error) {
+	for i, assignment := range f.singers {
+		if assignment.TrackID == arg.TrackID && assignment.Singer == arg.Singer {
+			f.singers = append(f.singers[:i], f.singers[i+1:]...)
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (f *fakeStore) CreateSetlist(ctx context.Context, arg database.CreateSetlistParams) (database.Setlist, error) {
+	setlist := database.Setlist{
+		ID:        int32(len(f.setlists) + 1),
+		Name:      arg.Name,
+		CreatedAt: time.Now(),
+		Data:      arg.Data,
+	}
+	f.setlists = append(f.setlists, setlist)
+	return setlist, nil
+}
+
+func (f *fakeStore) GetSetlist(ctx context.Context, id int32) (database.Setlist, error) {
+	for _, setlist := range f.setlists {
+		if setlist.ID == id {
+			return setlist, nil
+		}
+	}
+	return database.Setlist{}, sql.ErrNoRows
+}
+
+func (f *fakeStore) GetAllSetlists(ctx context.Context) ([]database.Setlist, error) {
+	return f.setlists, nil
+}
+
+func (f *fakeStore) DeleteSetlist(ctx context.Context, id int32) (int64, error) {
+	for i, setlist := range f.setlists {
+		if setlist.ID == id {
+			f.setlists = append(f.setlists[:i], f.setlists[i+1:]...)
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func newTestServer() (*Server, *fakeStore) {
+	store := &fakeStore{
+		tracks: []database.Track{
+			{ID: 1, Name: "Africa", Artist: "Toto", DurationInSeconds: 295, OriginalKey: "A"},
+			{ID: 2, Name: "Valerie", Artist: "Amy Winehouse", DurationInSeconds: 219, OriginalKey: "Eb"},
+		},
+		singers: []database.Singer{
+			{TrackID: 1, Singer: "Riley", Key: "A"},
+		},
+	}
+	build := func(ctx context.Context, input service.BuildInput) (*service.Setlist, error) {
+		if len(input.Singers) == 0 {
+			return nil, fmt.Errorf("must have at least one singer")
+		}
+		entry := service.SetEntry{Name: "Africa", Artist: "Toto", Singer: "Riley", Key: "A", DurationInSeconds: 295}
+		return &service.Setlist{Sets: []service.Set{{TargetMinutes: input.Duration, Entries: []service.SetEntry{entry}}}}, nil
+	}
+	return New(store, build), store
+}
+
+func doRequest(t *testing.T, srv *Server, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestTracks(t *testing.T) {
+	srv, _ := newTestServer()
+
+	rec := doRequest(t, srv, "GET", "/api/tracks", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	var tracks []trackResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &tracks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tracks) != 2 || len(tracks[0].Singers) != 1 || len(tracks[1].Singers) != 0 {
+		t.Errorf("Unexpected tracks: %+v", tracks)
+	}
+
+	rec = doRequest(t, srv, "GET", "/api/tracks/1", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"singer":"Riley"`) {
+		t.Errorf("Expected Africa with Riley, got %d: %s", rec.Code, rec.Body)
+	}
+
+	rec = doRequest(t, srv, "GET", "/api/tracks/3", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing track, got %d", rec.Code)
+	}
+
+	rec = doRequest(t, srv, "GET", "/api/tracks/Africa", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a track given by name, got %d", rec.Code)
+	}
+}
+
+func TestSetKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "valid key", body: `{"key":"bb"}`, status: http.StatusOK},
+		{name: "invalid key", body: `{"key":"h"}`, status: http.StatusBadRequest},
+		{name: "invalid JSON", body: `{"key":`, status: http.StatusBadRequest},
+		{name: "unknown field", body: `{"tone":"c"}`, status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, store := newTestServer()
+			rec := doRequest(t, srv, "PUT", "/api/tracks/2/key", tt.body)
+			if rec.Code != tt.status {
+				t.Fatalf("Expected %d, got %d: %s", tt.status, rec.Code, rec.Body)
+			}
+			if tt.status == http.StatusOK && store.tracks[1].OriginalKey != "Bb" {
+				t.Errorf("Expected key Bb, got %s", store.tracks[1].OriginalKey)
+			}
+		})
+	}
+}
+
+func TestSingers(t *testing.T) {
+	srv, store := newTestServer()
+
+	rec := doRequest(t, srv, "PUT", "/api/tracks/2/singers/ty", `{"key":"f"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if len(store.singers) != 2 || store.singers[1].Singer != "Ty" || store.singers[1].Key != "F" {
+		t.Errorf("Expected Ty in F to be added, got %+v", store.singers)
+	}
+
+	rec = doRequest(t, srv, "PUT", "/api/tracks/2/singers/nobody", `{"key":"f"}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid singer, got %d", rec.Code)
+	}
+
+	rec = doRequest(t, srv, "GET", "/api/singers?singer=ty", "")
+	var assignments []assignmentResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &assignments); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(assignments) != 1 || assignments[0].Song != "Valerie" {
+		t.Errorf("Expected Valerie for Ty, got %+v", assignments)
+	}
+
+	rec = doRequest(t, srv, "DELETE", "/api/tracks/2/singers/ty", "")
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d: %s", rec.Code, rec.Body)
+	}
+	rec = doRequest(t, srv, "DELETE", "/api/tracks/2/singers/ty", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 removing a singer twice, got %d", rec.Code)
+	}
+}
+
+func TestBuildAndSetlists(t *testing.T) {
+	srv, _ := newTestServer()
+
+	rec := doRequest(t, srv, "POST", "/api/builds", `{"duration":60,"singers":[]}`)
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for a failed build, got %d", rec.Code)
+	}
+
+	rec = doRequest(t, srv, "POST", "/api/builds", `{"duration":60,"singers":["riley"],"requests":[{"name":"Africa"}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	body := fmt.Sprintf(`{"name":"Smith wedding","setlist":%s}`, rec.Body.String())
+	rec = doRequest(t, srv, "POST", "/api/setlists", body)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", rec.Code, rec.Body)
+	}
+
+	rec = doRequest(t, srv, "GET", "/api/setlists/1", "")
+	var saved setlistResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &saved); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved.Name != "Smith wedding" || saved.Setlist == nil || saved.Setlist.Sets[0].Entries[0].Name != "Africa" {
+		t.Errorf("Unexpected saved setlist: %+v", saved)
+	}
+
+	rec = doRequest(t, srv, "DELETE", "/api/setlists/1", "")
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", rec.Code)
+	}
+	rec = doRequest(t, srv, "GET", "/api/setlists/1", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 after deleting, got %d", rec.Code)
+	}
+	rec = doRequest(t, srv, "GET", "/api/setlists/abc", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid id, got %d", rec.Code)
+	}
+}
+
+func TestEdits(t *testing.T) {
+	srv, store := newTestServer()
+	store.singers = append(store.singers, database.Singer{TrackID: 2, Singer: "Ty", Key: "Eb"})
+	setlist := `{"params":{"singers":["Riley","Ty"],"do_not_plays":["Valerie"]},"sets":[{"target_minutes":5,"entries":[{"name":"Valerie","artist":"Amy Winehouse","singer":"Ty","key":"Eb","duration_in_seconds":219}]}]}`
+
+	rec := doRequest(t, srv, "POST", "/api/validate", setlist)
+	var result editResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Violations) != 1 || result.Violations[0].Rule != service.RuleDoNotPlay {
+		t.Errorf("Expected a do-not-play violation, got %+v", result.Violations)
+	}
+
+	rec = doRequest(t, srv, "POST", "/api/swap", fmt.Sprintf(`{"setlist":%s,"set":0,"position":0}`, setlist))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	result = editResponse{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Setlist.Sets[0].Entries[0].Name != "Africa" || len(result.Violations) != 0 {
+		t.Errorf("Expected Valerie to be swapped for Africa, got %+v", result)
+	}
+
+	rec = doRequest(t, srv, "POST", "/api/regenerate", fmt.Sprintf(`{"setlist":%s,"set":1}`, setlist))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 regenerating a set that doesn't exist, got %d", rec.Code)
+	}
+}
+
+func TestServeUI(t *testing.T) {
+	srv, _ := newTestServer()
+	srv.ServeUI()
+	rec := doRequest(t, srv, "GET", "/", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Setlist Builder") {
+		t.Errorf("Expected the UI page, got %d", rec.Code)
+	}
+	rec = doRequest(t, srv, "GET", "/api/options", "")
+	if !strings.Contains(rec.Body.String(), `"Riley"`) {
+		t.Errorf("Expected singers in options, got %s", rec.Body)
+	}
+}
@@ -0,0 +1,16 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed ui
+var uiFiles embed.FS
+
+// ServeUI serves the embedded web UI at the root of the server, alongside the API.
+func (s *Server) ServeUI() {
+	files, _ := fs.Sub(uiFiles, "ui")
+	s.mux.Handle("GET /", http.FileServerFS(files))
+}
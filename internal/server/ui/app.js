@@ -0,0 +1,233 @@
+"use strict";
+
+const state = {
+  setlist: null,
+  violations: [],
+  dragging: null,
+};
+
+const form = document.getElementById("build-form");
+const statusBox = document.getElementById("status");
+
+async function api(method, path, body) {
+  const response = await fetch(path, {
+    method,
+    headers: { "Content-Type": "application/json" },
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+  const data = response.status === 204 ? null : await response.json();
+  if (!response.ok) {
+    throw new Error(data && data.error ? data.error : response.statusText);
+  }
+  return data;
+}
+
+function showStatus(message, isError) {
+  statusBox.textContent = message;
+  statusBox.className = isError ? "error" : "";
+}
+
+// parseLines reads "Artist - Title" lines the same way the CLI reads pasted requests.
+function parseLines(text) {
+  const candidates = [];
+  for (let line of text.split("\n")) {
+    line = line.trim().replace(/^\d+[.)]\s*/, "");
+    if (line === "" || line.startsWith("#")) {
+      continue;
+    }
+    const split = line.indexOf(" - ");
+    if (split === -1) {
+      candidates.push({ name: line, artist: "" });
+    } else {
+      candidates.push({ artist: line.slice(0, split).trim(), name: line.slice(split + 3).trim() });
+    }
+  }
+  return candidates;
+}
+
+function formatDuration(seconds) {
+  const minutes = Math.floor(seconds / 60);
+  const rest = String(seconds % 60).padStart(2, "0");
+  return `${minutes}:${rest}`;
+}
+
+async function loadOptions() {
+  const options = await api("GET", "/api/options");
+  const singers = document.getElementById("singers");
+  for (const singer of options.singers) {
+    const label = document.createElement("label");
+    const input = document.createElement("input");
+    input.type = "checkbox";
+    input.name = "singers";
+    input.value = singer;
+    label.append(input, " " + singer);
+    singers.append(label);
+  }
+  form.elements.duration.max = options.max_duration;
+}
+
+form.addEventListener("submit", async (event) => {
+  event.preventDefault();
+  const input = {
+    duration: Number(form.elements.duration.value),
+    singers: [...form.querySelectorAll("input[name=singers]:checked")].map((el) => el.value),
+    explicit_policy: form.elements.explicit_policy.value,
+    requests: parseLines(form.elements.requests.value),
+    do_not_plays: parseLines(form.elements.do_not_plays.value),
+  };
+  showStatus("Building...");
+  try {
+    state.setlist = await api("POST", "/api/builds", input);
+    await validate();
+    showStatus("");
+  } catch (err) {
+    showStatus(err.message, true);
+  }
+});
+
+async function validate() {
+  const result = await api("POST", "/api/validate", state.setlist);
+  state.violations = result.violations;
+  render();
+}
+
+async function edit(path, set, position) {
+  try {
+    const result = await api("POST", path, { setlist: state.setlist, set, position });
+    state.setlist = result.setlist;
+    state.violations = result.violations;
+    render();
+    showStatus("");
+  } catch (err) {
+    showStatus(err.message, true);
+  }
+}
+
+function moveEntry(fromSet, fromPosition, toSet, toPosition) {
+  const sets = state.setlist.sets;
+  const [entry] = sets[fromSet].entries.splice(fromPosition, 1);
+  if (fromSet === toSet && fromPosition < toPosition) {
+    toPosition--;
+  }
+  sets[toSet].entries.splice(toPosition, 0, entry);
+  validate().catch((err) => showStatus(err.message, true));
+}
+
+function render() {
+  const setlist = state.setlist;
+  document.getElementById("result").hidden = false;
+
+  const warnings = document.getElementById("warnings");
+  warnings.replaceChildren();
+  for (const warning of setlist.warnings || []) {
+    const li = document.createElement("li");
+    li.textContent = warning;
+    warnings.append(li);
+  }
+
+  const problems = new Map();
+  for (const violation of state.violations) {
+    const id = `${violation.set}:${violation.position}`;
+    problems.set(id, [...(problems.get(id) || []), violation.message]);
+  }
+
+  const container = document.getElementById("sets");
+  container.replaceChildren();
+  setlist.sets.forEach((set, setIndex) => {
+    const box = document.createElement("div");
+    box.className = "set";
+
+    const heading = document.createElement("h2");
+    const seconds = set.entries.reduce((total, entry) => total + entry.duration_in_seconds, 0);
+    heading.innerHTML = `<span>Set ${setIndex + 1} <span class="duration">${formatDuration(seconds)} / ${set.target_minutes}:00</span></span>`;
+    const regenerate = document.createElement("button");
+    regenerate.type = "button";
+    regenerate.textContent = "Regenerate set";
+    regenerate.addEventListener("click", () => edit("/api/regenerate", setIndex, -1));
+    heading.append(regenerate);
+    box.append(heading);
+
+    for (const message of problems.get(`${setIndex}:-1`) || []) {
+      const p = document.createElement("p");
+      p.className = "set-violation";
+      p.textContent = message;
+      box.append(p);
+    }
+
+    const list = document.createElement("ol");
+    list.addEventListener("dragover", (event) => event.preventDefault());
+    list.addEventListener("drop", (event) => {
+      event.preventDefault();
+      if (!state.dragging) {
+        return;
+      }
+      const target = event.target.closest("li");
+      const position = target ? Number(target.dataset.position) : set.entries.length;
+      moveEntry(state.dragging.set, state.dragging.position, setIndex, position);
+      state.dragging = null;
+    });
+
+    set.entries.forEach((entry, position) => {
+      const li = document.createElement("li");
+      li.draggable = true;
+      li.dataset.position = position;
+      if (entry.request) {
+        li.classList.add("request");
+      }
+      const name = document.createElement("span");
+      name.className = "name";
+      name.textContent = `${entry.name} - ${entry.singer} - ${entry.key}`;
+      if (entry.clean) {
+        name.textContent += " (clean edit)";
+      }
+      const swap = document.createElement("button");
+      swap.type = "button";
+      swap.textContent = "Swap";
+      swap.addEventListener("click", () => edit("/api/swap", setIndex, position));
+      li.append(swap, name);
+
+      const messages = problems.get(`${setIndex}:${position}`);
+      if (messages) {
+        li.classList.add("violation");
+        const detail = document.createElement("span");
+        detail.className = "problems";
+        detail.textContent = messages.join("; ");
+        li.append(detail);
+      }
+
+      li.addEventListener("dragstart", () => {
+        state.dragging = { set: setIndex, position };
+        li.classList.add("dragging");
+      });
+      li.addEventListener("dragend", () => li.classList.remove("dragging"));
+      list.append(li);
+    });
+    box.append(list);
+    container.append(box);
+  });
+
+  let summary = `Requests included: ${setlist.requests_included}/${setlist.requests_total}`;
+  if (setlist.sets.length > 1) {
+    summary += ` · Breaks between sets: ${setlist.break_minutes} minutes`;
+  }
+  if (state.violations.length === 0) {
+    summary += " · No rule violations";
+  }
+  document.getElementById("summary").textContent = summary;
+}
+
+document.getElementById("save").addEventListener("click", async () => {
+  const name = document.getElementById("setlist-name").value.trim();
+  if (name === "") {
+    showStatus("Enter a name to save the setlist", true);
+    return;
+  }
+  try {
+    const saved = await api("POST", "/api/setlists", { name, setlist: state.setlist });
+    showStatus(`Saved setlist ${saved.id}`);
+  } catch (err) {
+    showStatus(err.message, true);
+  }
+});
+
+loadOptions().catch((err) => showStatus(err.message, true));
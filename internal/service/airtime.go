@@ -0,0 +1,169 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/constants"
+)
+
+// ParseSingerTargets reads airtime targets written as "Riley=40,Ty=40,Bos=20". Singer names are
+// capitalized to match the singers table.
+func ParseSingerTargets(input string) (map[string]float64, error) {
+	targets := map[string]float64{}
+	for _, pair := range strings.Split(input, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		singer, value, found := strings.Cut(pair, "=")
+		singer = strings.TrimSpace(singer)
+		if !found || singer == "" {
+			return nil, fmt.Errorf("invalid target %q, please use singer=percent, e.g. Riley=40", pair)
+		}
+		percent, parseErr := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, "%")), 64)
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid percentage in %q", pair)
+		}
+		targets[constants.Capitalize(strings.ToLower(singer))] = percent
+	}
+	return targets, nil
+}
+
+// CheckTargets makes sure the airtime targets are for singers in the gig and can add up to 100%.
+func (p *BuildParams) CheckTargets() error {
+	if len(p.SingerTargets) == 0 {
+		return nil
+	}
+	total := 0.0
+	for singer, percent := range p.SingerTargets {
+		if !containsFold(p.Singers, singer) {
+			return fmt.Errorf("%s has an airtime target but isn't singing at this gig", singer)
+		}
+		if percent < 0 || percent > 100 {
+			return fmt.Errorf("%s's airtime target of %g%% must be between 0 and 100", singer, percent)
+		}
+		total += percent
+	}
+	if total > 100.001 {
+		return fmt.Errorf("airtime targets add up to %g%%, which is more than 100%%", total)
+	}
+	if len(p.SingerTargets) == len(p.Singers) && total < 99.999 {
+		return fmt.Errorf("airtime targets add up to %g%% but every singer has one, they need to add up to 100%%", total)
+	}
+	if p.TargetTolerance < 0 || p.TargetTolerance > 100 {
+		return fmt.Errorf("airtime tolerance of %g must be between 0 and 100", p.TargetTolerance)
+	}
+	return nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Targets returns the airtime percentage for every singer in the gig, sharing what the targets
+// leave over equally between singers without one. It returns nil when there are no targets.
+func (p *BuildParams) Targets() map[string]float64 {
+	if len(p.SingerTargets) == 0 {
+		return nil
+	}
+	targets := map[string]float64{}
+	left := 100.0
+	untargeted := []string{}
+	for _, singer := range p.Singers {
+		found := false
+		for targetSinger, percent := range p.SingerTargets {
+			if strings.EqualFold(singer, targetSinger) {
+				targets[singer] = percent
+				left -= percent
+				found = true
+			}
+		}
+		if !found {
+			untargeted = append(untargeted, singer)
+		}
+	}
+	for _, singer := range untargeted {
+		targets[singer] = math.Max(left, 0) / float64(len(untargeted))
+	}
+	return targets
+}
+
+// Tolerance is the number of percentage points a share can be off its target.
+func (p *BuildParams) Tolerance() float64 {
+	if p.TargetTolerance == 0 {
+		return DefaultTargetTolerance
+	}
+	return p.TargetTolerance
+}
+
+// SingerAirtime is how much of a set or night one singer sang.
+type SingerAirtime struct {
+	Singer  string  `json:"singer"`
+	Songs   int     `json:"songs"`
+	Seconds int     `json:"seconds"`
+	Share   float64 `json:"share"`
+	// Target is the singer's target share, and HasTarget is false when the build had none.
+	Target    float64 `json:"target"`
+	HasTarget bool    `json:"has_target"`
+}
+
+// OffTarget reports whether the share is further from its target than tolerance allows.
+func (a SingerAirtime) OffTarget(tolerance float64) bool {
+	return a.HasTarget && math.Abs(a.Share-a.Target) > tolerance
+}
+
+// airtime adds up each singer's songs and seconds in entries, splitting duets between their
+// singers. Every singer in the gig is listed, in gig order, followed by anyone else who sang.
+func airtime(entries []SetEntry, params *BuildParams) []SingerAirtime {
+	targets := params.Targets()
+	result := []SingerAirtime{}
+	index := map[string]int{}
+	add := func(singer string) {
+		index[singer] = len(result)
+		target, hasTarget := targets[singer]
+		result = append(result, SingerAirtime{Singer: singer, Target: target, HasTarget: hasTarget})
+	}
+	for _, singer := range params.Singers {
+		add(singer)
+	}
+	total := 0
+	for _, entry := range entries {
+		for _, vocalist := range entry.Vocalists() {
+			if _, ok := index[vocalist]; !ok {
+				add(vocalist)
+			}
+			singer := &result[index[vocalist]]
+			singer.Songs++
+			singer.Seconds += entry.VocalSeconds()
+			total += entry.VocalSeconds()
+		}
+	}
+	for i := range result {
+		if total > 0 {
+			result[i].Share = float64(result[i].Seconds) * 100 / float64(total)
+		}
+	}
+	return result
+}
+
+// Airtime returns each singer's share of set.
+func (s *Setlist) Airtime(set int) []SingerAirtime {
+	return airtime(s.Sets[set].Entries, &s.Params)
+}
+
+// NightAirtime returns each singer's share of the whole setlist.
+func (s *Setlist) NightAirtime() []SingerAirtime {
+	entries := []SetEntry{}
+	for _, set := range s.Sets {
+		entries = append(entries, set.Entries...)
+	}
+	return airtime(entries, &s.Params)
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+func TestParseSingerTargets(t *testing.T) {
+	targets, err := ParseSingerTargets("riley=40, Ty=40%,BOS=20")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(targets) != 3 || targets["Riley"] != 40 || targets["Ty"] != 40 || targets["Bos"] != 20 {
+		t.Errorf("Expected Riley 40, Ty 40 and Bos 20, got %v", targets)
+	}
+	for _, input := range []string{"riley", "=40", "riley=lots"} {
+		if _, err := ParseSingerTargets(input); err == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+}
+
+func TestCheckTargets(t *testing.T) {
+	tests := []struct {
+		name      string
+		targets   map[string]float64
+		expectErr bool
+	}{
+		{name: "no targets"},
+		{name: "all singers", targets: map[string]float64{"Riley": 40, "Ty": 40, "Bos": 20}},
+		{name: "some singers", targets: map[string]float64{"Riley": 50}},
+		{name: "singer not at the gig", targets: map[string]float64{"Jared": 50}, expectErr: true},
+		{name: "over 100", targets: map[string]float64{"Riley": 60, "Ty": 50}, expectErr: true},
+		{name: "every singer under 100", targets: map[string]float64{"Riley": 30, "Ty": 30, "Bos": 30}, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := BuildParams{Singers: []string{"Riley", "Ty", "Bos"}, SingerTargets: tt.targets}
+			if err := params.CheckTargets(); (err != nil) != tt.expectErr {
+				t.Errorf("Expected error: %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestTargetsShareWhatIsLeft(t *testing.T) {
+	params := BuildParams{Singers: []string{"Riley", "Ty", "Bos"}, SingerTargets: map[string]float64{"Riley": 50}}
+	targets := params.Targets()
+	if targets["Riley"] != 50 || targets["Ty"] != 25 || targets["Bos"] != 25 {
+		t.Errorf("Expected Ty and Bos to split the other 50%%, got %v", targets)
+	}
+	if (&BuildParams{Singers: []string{"Riley"}}).Targets() != nil {
+		t.Error("Expected no targets when none are given")
+	}
+}
+
+func TestAirtimeAndSingerShareRule(t *testing.T) {
+	entry := func(name, singer string, seconds int32) SetEntry {
+		return SetEntry{Name: name, Artist: "Artist " + name, Singer: singer, Key: name, DurationInSeconds: seconds}
+	}
+	setlist := &Setlist{
+		Params: BuildParams{Singers: []string{"Riley", "Ty"}, SingerTargets: map[string]float64{"Riley": 50, "Ty": 50}},
+		Sets: []Set{
+			{Entries: []SetEntry{entry("A", "Riley", 300), entry("B", "Ty", 300), entry("C", "Riley", 200), entry("D", "Ty", 200)}},
+			{Entries: []SetEntry{entry("E", "Riley", 300), entry("F", "Riley", 300), entry("G", "Ty", 100), entry("H", "Riley", 300)}},
+		},
+	}
+	first := setlist.Airtime(0)
+	if first[0].Songs != 2 || first[0].Seconds != 500 || first[0].Share != 50 || first[0].OffTarget(10) {
+		t.Errorf("Expected Riley to be on target in set 1, got %+v", first[0])
+	}
+	second := setlist.Airtime(1)
+	if second[0].Share != 90 || !second[0].OffTarget(10) {
+		t.Errorf("Expected Riley to be over target in set 2, got %+v", second[0])
+	}
+	night := setlist.NightAirtime()
+	if night[1].Songs != 3 || night[1].Seconds != 600 {
+		t.Errorf("Expected Ty to sing 3 songs for 600 seconds over the night, got %+v", night[1])
+	}
+
+	shareViolations := 0
+	for _, violation := range Validate(setlist, nil) {
+		if violation.Rule == RuleSingerShare {
+			shareViolations++
+			if violation.Set != 1 {
+				t.Errorf("Expected only set 2 to be off target, got %v", violation)
+			}
+		}
+	}
+	if shareViolations != 2 {
+		t.Errorf("Expected Riley and Ty to both be off target in set 2, got %d violations", shareViolations)
+	}
+	if warnings := airtimeWarnings(setlist); len(warnings) != 4 {
+		t.Errorf("Expected set 2 and night warnings for both singers, got %v", warnings)
+	}
+}
+
+func TestBalancedSingers(t *testing.T) {
+	totals := []database.SumDurationForSingerRow{
+		{Singer: "Riley", TotalDuration: 120 * 60},
+		{Singer: "Ty", TotalDuration: 40 * 60},
+	}
+	params := BuildParams{Singers: []string{"Riley", "Ty"}, Duration: 180}
+	if balancedSingers(params, totals) {
+		t.Error("Expected Ty's 40 minutes not to cover a third of a 180 minute gig")
+	}
+	params.SingerTargets = map[string]float64{"Riley": 80, "Ty": 20}
+	if !balancedSingers(params, totals) {
+		t.Error("Expected Ty's 40 minutes to cover a 20% share")
+	}
+	params = BuildParams{Singers: []string{"Riley", "Ty", "Bos"}, Duration: 90}
+	if balancedSingers(params, totals) {
+		t.Error("Expected Bos with no songs to be unbalanced")
+	}
+}
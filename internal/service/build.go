@@ -0,0 +1,925 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+// MaxDuration is the longest gig in minutes allowed by the band's contract, including breaks.
+const MaxDuration = 180
+
+// MaxSets is the most sets a gig can be split into.
+const MaxSets = 3
+
+// DefaultRequestSpacing is how many songs are played between requests when the build doesn't
+// give a spacing.
+const DefaultRequestSpacing = 3
+
+// DefaultTargetTolerance is how many percentage points a singer's airtime can be off their
+// target when the build doesn't give a tolerance.
+const DefaultTargetTolerance = 10
+
+// CleanEditMark follows songs in a printed setlist that must be performed with the clean edit.
+const CleanEditMark = "(clean edit)"
+
+// Explicit lyrics policies. Clean allows explicit songs only when the band has a clean version,
+// and those songs are marked to be performed with the clean edit.
+const (
+	ExplicitAllow   = "allow"
+	ExplicitClean   = "clean"
+	ExplicitExclude = "exclude"
+)
+
+// ParseExplicitPolicy reads an explicit lyrics policy from a build answer or flag.
+func ParseExplicitPolicy(input string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "allow", "a", "yes", "y", "true":
+		return ExplicitAllow, nil
+	case "clean", "c", "edit", "clean edit", "clean-edit":
+		return ExplicitClean, nil
+	case "exclude", "x", "no", "n", "false":
+		return ExplicitExclude, nil
+	}
+	return "", fmt.Errorf("invalid explicit policy %q, please use allow, clean or exclude", input)
+}
+
+// ExplicitAllowed reports whether a track can be played under the given policy.
+func ExplicitAllowed(policy string, explicit, cleanVersion bool) bool {
+	switch {
+	case !explicit || policy == ExplicitAllow:
+		return true
+	case policy == ExplicitClean:
+		return cleanVersion
+	}
+	return false
+}
+
+// explicitReason says why a track isn't allowed under the given policy.
+func explicitReason(policy string) string {
+	if policy == ExplicitClean {
+		return "track has explicit lyrics and no clean version"
+	}
+	return "track has explicit lyrics"
+}
+
+type BuildParams struct {
+	Requests   []string `json:"requests"`
+	DoNotPlays []string `json:"do_not_plays"`
+	// RequestIDs and DoNotPlayIDs line up with Requests and DoNotPlays and hold the library track
+	// each one matched, so songs with the same title by different artists aren't mixed up. DNPs
+	// that aren't in the library have an ID of 0. Without IDs the names are looked up instead.
+	RequestIDs    []int32  `json:"request_ids,omitempty"`
+	DoNotPlayIDs  []int32  `json:"do_not_play_ids,omitempty"`
+	Singers       []string `json:"singers"`
+	Duration      int32    `json:"duration"`
+	AllowExplicit bool     `json:"allow_explicit"`
+	// ExplicitPolicy is one of ExplicitAllow, ExplicitClean or ExplicitExclude. Setlists saved
+	// before it existed only have AllowExplicit, so Explicit falls back to that.
+	ExplicitPolicy string `json:"explicit_policy,omitempty"`
+	// SingerTargets is the percentage of airtime each singer should get. Singers left out of it
+	// share whatever is left equally, and an empty map means no targets.
+	SingerTargets map[string]float64 `json:"singer_targets,omitempty"`
+	// TargetTolerance is how many percentage points a singer's share of a set or of the night
+	// can be off their target. 0 uses DefaultTargetTolerance.
+	TargetTolerance float64 `json:"target_tolerance,omitempty"`
+	// Lineup is the instruments and players booked for the gig, and songs that need anyone else
+	// are left out. An empty lineup means the full band.
+	Lineup []string `json:"lineup,omitempty"`
+	Mix    MixRules `json:"mix"`
+	// Sets is how many sets the gig is split into, 0 leaves it to the duration. RequestSpacing
+	// is how many songs are played between requests, 0 uses DefaultRequestSpacing.
+	Sets           int `json:"sets,omitempty"`
+	RequestSpacing int `json:"request_spacing,omitempty"`
+	// Profile is the name of the event profile the build started from, if any.
+	Profile string `json:"profile,omitempty"`
+	// StartTime is when the gig starts as "HH:MM", which gives each song a clock time. SongGap
+	// is how many seconds pass between songs. Sets still running at HardStop are flagged, and
+	// TimeWindows keep kinds of songs to part of the night.
+	StartTime   string       `json:"start_time,omitempty"`
+	SongGap     int          `json:"song_gap_seconds,omitempty"`
+	HardStop    string       `json:"hard_stop,omitempty"`
+	TimeWindows []TimeWindow `json:"time_windows,omitempty"`
+}
+
+// Explicit returns the build's explicit lyrics policy.
+func (p *BuildParams) Explicit() string {
+	if p.ExplicitPolicy != "" {
+		return p.ExplicitPolicy
+	}
+	if p.AllowExplicit {
+		return ExplicitAllow
+	}
+	return ExplicitExclude
+}
+
+// SetExplicitPolicy sets the policy and keeps AllowExplicit in step with it.
+func (p *BuildParams) SetExplicitPolicy(policy string) {
+	p.ExplicitPolicy = policy
+	p.AllowExplicit = policy == ExplicitAllow
+}
+
+func (p *BuildParams) AddRequest(name string, id int32) {
+	p.Requests = append(p.Requests, name)
+	p.RequestIDs = append(p.RequestIDs, id)
+}
+
+func (p *BuildParams) AddDoNotPlay(name string, id int32) {
+	p.DoNotPlays = append(p.DoNotPlays, name)
+	p.DoNotPlayIDs = append(p.DoNotPlayIDs, id)
+}
+
+// Contradictions returns songs that are in both the requests and DNP lists.
+func (p *BuildParams) Contradictions() []string {
+	var result []string
+	for i, request := range p.Requests {
+		for j, dnp := range p.DoNotPlays {
+			bothMatched := i < len(p.RequestIDs) && j < len(p.DoNotPlayIDs) && p.RequestIDs[i] != 0 && p.DoNotPlayIDs[j] != 0
+			if (bothMatched && p.RequestIDs[i] == p.DoNotPlayIDs[j]) || (!bothMatched && request == dnp) {
+				result = append(result, request)
+				break
+			}
+		}
+	}
+	return result
+}
+
+func (p *BuildParams) RemoveRequest(name string) {
+	p.Requests, p.RequestIDs = removeName(p.Requests, p.RequestIDs, name)
+}
+
+func (p *BuildParams) RemoveDoNotPlay(name string) {
+	p.DoNotPlays, p.DoNotPlayIDs = removeName(p.DoNotPlays, p.DoNotPlayIDs, name)
+}
+
+func removeName(names []string, ids []int32, name string) ([]string, []int32) {
+	newNames := []string{}
+	newIDs := []int32{}
+	for i, item := range names {
+		if item == name {
+			continue
+		}
+		newNames = append(newNames, item)
+		if i < len(ids) {
+			newIDs = append(newIDs, ids[i])
+		}
+	}
+	return newNames, newIDs
+}
+
+// trackIDs returns the library track for each name, using ids when they line up with the names
+// and looking the names up otherwise. Names that aren't in the library, or that more than one
+// track has, get an ID of 0.
+func trackIDs(ctx context.Context, dbQueries *database.Queries, names []string, ids []int32) []int32 {
+	if len(ids) == len(names) {
+		return ids
+	}
+	result := []int32{}
+	for _, name := range names {
+		track, getErr := TrackFromName(ctx, dbQueries, name)
+		if getErr != nil {
+			result = append(result, 0)
+			continue
+		}
+		result = append(result, track.ID)
+	}
+	return result
+}
+
+// Partner is a vocalist singing alongside the lead on a duet or a song with harmony parts.
+// Role describes their part, e.g. "duet", "harmony" or "second verse".
+type Partner struct {
+	Singer string `json:"singer"`
+	Role   string `json:"role"`
+}
+
+type SetEntry struct {
+	Name   string `json:"name"`
+	Artist string `json:"artist"`
+	// Singer is the lead, and Key is the key they sing it in. Partners are the other vocalists
+	// when the song is a duet.
+	Singer            string    `json:"singer"`
+	Partners          []Partner `json:"partners,omitempty"`
+	Key               string    `json:"key"`
+	DurationInSeconds int32     `json:"duration_in_seconds"`
+	Explicit          bool      `json:"explicit"`
+	Request           bool      `json:"request"`
+	// Clean entries are explicit songs that must be performed with the clean edit.
+	Clean bool `json:"clean,omitempty"`
+	// Locked entries are kept in place when their set is regenerated.
+	Locked bool `json:"locked"`
+}
+
+// String formats an entry the way setlists are printed: "Song - Singer - Key", with duets
+// printed as "Song - Lead & Partner - Key" and songs to perform clean marked "(clean edit)".
+func (e SetEntry) String() string {
+	line := e.Name + " - " + strings.Join(e.Vocalists(), " & ") + " - " + e.Key
+	if e.Clean {
+		line += " " + CleanEditMark
+	}
+	return line
+}
+
+// Vocalists returns the lead singer followed by any partners.
+func (e SetEntry) Vocalists() []string {
+	vocalists := []string{e.Singer}
+	for _, partner := range e.Partners {
+		vocalists = append(vocalists, partner.Singer)
+	}
+	return vocalists
+}
+
+// VocalSeconds is how much of the song counts toward each vocalist's airtime. Duets are split
+// evenly between everyone singing.
+func (e SetEntry) VocalSeconds() int {
+	return int(e.DurationInSeconds) / (len(e.Partners) + 1)
+}
+
+type Set struct {
+	TargetMinutes int32      `json:"target_minutes"`
+	Entries       []SetEntry `json:"entries"`
+}
+
+func (s Set) DurationInSeconds() int {
+	total := 0
+	for _, entry := range s.Entries {
+		total += int(entry.DurationInSeconds)
+	}
+	return total
+}
+
+// Setlist is a finished build. It keeps the parameters it was built with so it can be
+// validated and edited later without asking the questions again.
+type Setlist struct {
+	Params           BuildParams `json:"params"`
+	Sets             []Set       `json:"sets"`
+	RequestsIncluded int         `json:"requests_included"`
+	RequestsTotal    int         `json:"requests_total"`
+	BreakMinutes     int         `json:"break_minutes"`
+	// SingerRuleIgnored is set when the singers can't fill the gig without repeating, in which
+	// case the repeat singer rule isn't enforced.
+	SingerRuleIgnored bool     `json:"singer_rule_ignored"`
+	Warnings          []string `json:"warnings"`
+	// LineupExcluded lists the songs left out because they need players outside the lineup, and
+	// GenreExcluded the songs left out for their genre.
+	LineupExcluded []LineupExclusion `json:"lineup_excluded,omitempty"`
+	GenreExcluded  []GenreExclusion  `json:"genre_excluded,omitempty"`
+}
+
+// SetLengths splits a gig into sets, leaving room for the breaks between them.
+func SetLengths(duration int32) []int32 {
+	if duration > 90 && duration <= 150 {
+		return []int32{duration/2 - 10, duration/2 - 10}
+	} else if duration > 150 && duration <= 180 {
+		return []int32{duration/3 - 10, duration/3 - 10, duration/3 - 10}
+	}
+	return []int32{duration}
+}
+
+// SetLengths splits the gig into the number of sets asked for, leaving it to the duration when
+// no number was given.
+func (p *BuildParams) SetLengths() []int32 {
+	if p.Sets == 0 {
+		return SetLengths(p.Duration)
+	}
+	length := (p.Duration - int32(BreakMinutes(p.Sets)*(p.Sets-1))) / int32(p.Sets)
+	lengths := []int32{}
+	for i := 0; i < p.Sets; i++ {
+		lengths = append(lengths, length)
+	}
+	return lengths
+}
+
+// CheckSets makes sure the number of sets is one the band's breaks are worked out for, that the
+// gig is long enough for that many sets and their breaks, and that the request spacing isn't
+// negative.
+func (p *BuildParams) CheckSets() error {
+	if p.Sets < 0 || p.Sets > MaxSets {
+		return fmt.Errorf("a gig can be split into 1 to %d sets, not %d", MaxSets, p.Sets)
+	}
+	if p.Sets > 1 && p.Duration > 0 {
+		if breaks := BreakMinutes(p.Sets) * (p.Sets - 1); p.Duration-int32(breaks) < int32(p.Sets) {
+			return fmt.Errorf("a %d minute gig is too short for %d sets with %d minutes of breaks", p.Duration, p.Sets, breaks)
+		}
+	}
+	if p.RequestSpacing < 0 {
+		return fmt.Errorf("request spacing of %d can't be negative", p.RequestSpacing)
+	}
+	return nil
+}
+
+func (p *BuildParams) Spacing() int {
+	if p.RequestSpacing == 0 {
+		return DefaultRequestSpacing
+	}
+	return p.RequestSpacing
+}
+
+func BreakMinutes(sets int) int {
+	if sets == 2 {
+		return 20
+	} else if sets == 3 {
+		return 15
+	}
+	return 0
+}
+
+// Builder builds setlists using the working table, so only one build should run against a
+// database at a time. Progress and rejected tracks are written to out.
+type Builder struct {
+	db    *sql.DB
+	out   io.Writer
+	trace *Trace
+}
+
+func NewBuilder(db *sql.DB, out io.Writer) *Builder {
+	if out == nil {
+		out = io.Discard
+	}
+	return &Builder{db: db, out: out}
+}
+
+// Trace returns how the last build picked its songs.
+func (b *Builder) Trace() *Trace {
+	return b.trace
+}
+
+type setState struct {
+	entries             []SetEntry
+	lastKey             string
+	secondToLastKey     string
+	lastSingers         []string
+	secondToLastSingers []string
+	usedArtists         map[string]bool
+	totalDuration       int
+	maxDuration         int
+	singerSeconds       map[string]int
+	lastBuckets         []string
+	genreCounts         map[string]int
+	// startSeconds is when the set starts, in seconds after the start of the gig.
+	startSeconds int
+}
+
+// nextStart is when the next song added to the set would start, in seconds after the start of
+// the gig.
+func (s *setState) nextStart(gap int) int {
+	return s.startSeconds + s.totalDuration + gap*len(s.entries)
+}
+
+type buildRun struct {
+	params     BuildParams
+	addedSongs map[int32]bool
+	balanced   bool
+	// targets and tolerance are the singers' airtime targets, nil when the build has none.
+	targets       map[string]float64
+	tolerance     float64
+	singerSeconds map[string]int
+	nightSeconds  int
+	// genres is only loaded when the build has mix rules or time windows. decadeCounts, fastSongs
+	// and songs count the songs added so far for the decade shares and energy target.
+	genres       *GenreMap
+	decadeCounts map[string]int
+	fastSongs    int
+	songs        int
+}
+
+// overTarget explains why adding seconds more for singer would take them past their airtime
+// target for the set or the night, or returns "" if it wouldn't.
+func (r *buildRun) overTarget(state *setState, singer string, seconds int) string {
+	target, ok := r.targets[singer]
+	if !ok {
+		return ""
+	}
+	limit := (target + r.tolerance) / 100
+	if float64(state.singerSeconds[singer]+seconds) > limit*float64(state.maxDuration) {
+		return fmt.Sprintf("%s would go over their %g%% share of the set", singer, target)
+	}
+	if float64(r.singerSeconds[singer]+seconds) > limit*float64(r.nightSeconds) {
+		return fmt.Sprintf("%s would go over their %g%% share of the night", singer, target)
+	}
+	return ""
+}
+
+// behindTarget is how many seconds singer is short of their airtime target for the night so far.
+func (r *buildRun) behindTarget(singer string) float64 {
+	return r.targets[singer]/100*float64(r.nightSeconds) - float64(r.singerSeconds[singer])
+}
+
+func (b *Builder) Build(ctx context.Context, params BuildParams) (*Setlist, error) {
+	dbQueries := database.New(b.db)
+	if clearErr := dbQueries.ClearWorking(ctx); clearErr != nil {
+		return nil, fmt.Errorf("failed to clear working table at start of build: %v", clearErr)
+	}
+	defer dbQueries.ClearWorking(context.Background())
+
+	if scheduleErr := params.CheckSchedule(); scheduleErr != nil {
+		return nil, scheduleErr
+	}
+	requestIDs := trackIDs(ctx, dbQueries, params.Requests, params.RequestIDs)
+	// requests holds the index of each request that hasn't been placed yet
+	requests := []int{}
+	isRequest := map[int32]bool{}
+	for i, id := range requestIDs {
+		requests = append(requests, i)
+		isRequest[id] = true
+	}
+	setlist := &Setlist{Params: params, RequestsTotal: len(params.Requests)}
+	if targetsErr := params.CheckTargets(); targetsErr != nil {
+		return nil, targetsErr
+	}
+	if mixErr := params.Mix.Check(); mixErr != nil {
+		return nil, mixErr
+	}
+	if setsErr := params.CheckSets(); setsErr != nil {
+		return nil, setsErr
+	}
+	run := &buildRun{
+		params:        params,
+		addedSongs:    map[int32]bool{},
+		balanced:      true,
+		targets:       params.Targets(),
+		tolerance:     params.Tolerance(),
+		singerSeconds: map[string]int{},
+		decadeCounts:  map[string]int{},
+	}
+	if !params.Mix.Empty() || len(params.TimeWindows) > 0 {
+		genres, genresErr := LoadGenreMap(ctx, dbQueries)
+		if genresErr != nil {
+			return nil, genresErr
+		}
+		run.genres = genres
+	}
+	countTillRequest := 0
+	setLengths := params.SetLengths()
+	for _, length := range setLengths {
+		run.nightSeconds += int(length) * 60
+	}
+	b.trace = &Trace{Params: params}
+
+	durationChecks, durationChecksErr := dbQueries.SumDurationForSinger(ctx, params.Singers)
+	if durationChecksErr != nil {
+		fmt.Fprintf(b.out, "Unable to check total durations for singers: %v\n\n", durationChecksErr)
+	}
+	if !balancedSingers(params, durationChecks) {
+		run.balanced = false
+		setlist.SingerRuleIgnored = true
+		warning := "Singers chosen may not be able to complete entire set balanced, repeat singer rule will be ignored"
+		setlist.Warnings = append(setlist.Warnings, warning)
+		fmt.Fprintln(b.out, "")
+		fmt.Fprintln(b.out, warning)
+		fmt.Fprintln(b.out, "")
+	}
+
+	fmt.Fprintln(b.out, "Set Lengths:")
+	for i, setLength := range setLengths {
+		fmt.Fprintf(b.out, "Set %d - %d minutes\n", i+1, setLength)
+	}
+	fmt.Fprintln(b.out, "")
+	fmt.Fprintln(b.out, "Fetching tracks from DB...")
+	workingTracks, tracksErr := dbQueries.GetAllTracks(ctx)
+	if tracksErr != nil {
+		return nil, fmt.Errorf("unable to get tracks in database: %v", tracksErr)
+	}
+	fmt.Fprintln(b.out, "✅ Tracks fetched.")
+	for _, workingTrack := range workingTracks {
+		workingParams := database.AddTrackToWorkingParams{
+			TrackID:           workingTrack.ID,
+			Name:              workingTrack.Name,
+			Artist:            workingTrack.Artist,
+			Genre:             workingTrack.Genre,
+			DurationInSeconds: int32(workingTrack.DurationInSeconds),
+			Year:              workingTrack.Year,
+			Explicit:          workingTrack.Explicit,
+			Bpm:               int32(workingTrack.Bpm),
+			OriginalKey:       workingTrack.OriginalKey,
+			CleanVersion:      workingTrack.CleanVersion,
+		}
+		addErr := dbQueries.AddTrackToWorking(ctx, workingParams)
+		if addErr != nil {
+			return nil, fmt.Errorf("error adding track to working table: %v", addErr)
+		}
+	}
+	fmt.Fprintln(b.out, "✅ Tracks added to working table.")
+	fmt.Fprintln(b.out, "")
+	dnpIDs := trackIDs(ctx, dbQueries, params.DoNotPlays, params.DoNotPlayIDs)
+	for i, dnp := range params.DoNotPlays {
+		_, workingErr := dbQueries.GetWorking(ctx, dnpIDs[i])
+		if workingErr == nil {
+			removeErr := dbQueries.RemoveFromWorking(ctx, dnpIDs[i])
+			if removeErr != nil {
+				return nil, fmt.Errorf("error removing dnp track from working table: %v", removeErr)
+			}
+		} else if workingErr == sql.ErrNoRows {
+			fmt.Fprintf(b.out, "%s not found in database, skipping...\n", dnp)
+			fmt.Fprintln(b.out, "")
+		}
+	}
+	fmt.Fprintln(b.out, "✅ DNP's remove from working table.")
+	excluded, excludeErr := excludeForLineup(ctx, dbQueries, params, workingTracks)
+	if excludeErr != nil {
+		return nil, excludeErr
+	}
+	genreExcluded, genreErr := excludeForGenres(ctx, dbQueries, params, run.genres, workingTracks)
+	if genreErr != nil {
+		return nil, genreErr
+	}
+	// excludedReason explains why a track was left out before building, or returns ""
+	excludedReason := func(id int32) string {
+		if exclusion, ok := excluded[id]; ok {
+			return fmt.Sprintf("needs %s, who isn't on this gig", strings.Join(exclusion.Missing, " & "))
+		}
+		if exclusion, ok := genreExcluded[id]; ok {
+			return fmt.Sprintf("is %s, which is excluded from this gig", strings.Join(exclusion.Genres, " & "))
+		}
+		return ""
+	}
+	for _, track := range workingTracks {
+		if exclusion, ok := excluded[track.ID]; ok {
+			setlist.LineupExcluded = append(setlist.LineupExcluded, exclusion)
+		}
+		if exclusion, ok := genreExcluded[track.ID]; ok {
+			setlist.GenreExcluded = append(setlist.GenreExcluded, exclusion)
+		}
+		if reason := excludedReason(track.ID); reason != "" {
+			fmt.Fprintf(b.out, "Skipping %s: %s\n", track.Name, reason)
+		}
+	}
+	if len(excluded) > 0 {
+		fmt.Fprintf(b.out, "✅ %d song(s) needing players outside the lineup removed from working table.\n", len(excluded))
+	}
+	if len(genreExcluded) > 0 {
+		fmt.Fprintf(b.out, "✅ %d song(s) in excluded genres removed from working table.\n", len(genreExcluded))
+	}
+	for i := 0; i < len(requests); {
+		reason := excludedReason(requestIDs[requests[i]])
+		if reason == "" {
+			i++
+			continue
+		}
+		warning := fmt.Sprintf("Request %s %s, so it was left out.", params.Requests[requests[i]], reason)
+		setlist.Warnings = append(setlist.Warnings, warning)
+		fmt.Fprintln(b.out, warning)
+		requests = removeIndex(requests, i)
+	}
+	setStart := 0
+	for _, set := range setLengths {
+		workTracks, workTracksErr := dbQueries.GetAllWorking(ctx)
+		if workTracksErr != nil {
+			return nil, fmt.Errorf("unable to load working table: %v", workTracksErr)
+		}
+		state := &setState{
+			usedArtists:   map[string]bool{},
+			maxDuration:   int(set * 60),
+			singerSeconds: map[string]int{},
+			genreCounts:   map[string]int{},
+			startSeconds:  setStart,
+		}
+		margin := 180
+		target := int(set) * 60
+		maxStaleRounds := 5
+		staleRounds := 0
+		for state.totalDuration < target-margin && staleRounds < maxStaleRounds {
+			loopMadeProgress := false
+			if len(workTracks) == 0 {
+				return nil, fmt.Errorf("working tracks is empty")
+			}
+			rand.Shuffle(len(workTracks), func(i, j int) {
+				workTracks[i], workTracks[j] = workTracks[j], workTracks[i]
+			})
+			if len(params.Mix.MinDecadeShare) > 0 {
+				// songs from decades short of their share get tried first
+				sort.SliceStable(workTracks, func(i, j int) bool {
+					return params.Mix.decadeBehind(Decade(workTracks[i].Year), run.decadeCounts, run.songs) && !params.Mix.decadeBehind(Decade(workTracks[j].Year), run.decadeCounts, run.songs)
+				})
+			}
+			if params.Mix.fastBehind(run.fastSongs, run.songs) {
+				// fast songs get tried first while the night is short of its energy target
+				sort.SliceStable(workTracks, func(i, j int) bool {
+					return workTracks[i].Bpm >= FastBPM && workTracks[j].Bpm < FastBPM
+				})
+			}
+			slot := &SlotTrace{Set: len(setlist.Sets), Position: len(state.entries)}
+			if countTillRequest < params.Spacing() || len(requests) == 0 {
+				for i := 0; i < len(workTracks); i++ {
+					track := workTracks[i]
+					if b.tryAddTrackToSet(ctx, dbQueries, run, state, slot, track, isRequest[track.TrackID]) {
+						countTillRequest++
+						loopMadeProgress = true
+						if isRequest[track.TrackID] {
+							fmt.Fprintln(b.out, "✅ Request added")
+							setlist.RequestsIncluded++
+							countTillRequest = 0
+						}
+						break
+					}
+				}
+			} else {
+				slot.Requests = true
+				requestAdded := false
+				for i := 0; i < len(requests); {
+					request := params.Requests[requests[i]]
+					track, getErr := dbQueries.GetWorking(ctx, requestIDs[requests[i]])
+					if getErr != nil {
+						fmt.Fprintf(b.out, "Request %s not found in DB (possibly due to being already added), removing from request list...\n", request)
+						slot.reject(request, "", RuleRepeatSong, "request was no longer available (it may have already been added)")
+						requests = removeIndex(requests, i)
+						continue
+					}
+					if b.tryAddTrackToSet(ctx, dbQueries, run, state, slot, track, true) {
+						requests = removeIndex(requests, i)
+						fmt.Fprintln(b.out, "✅ Request added")
+						countTillRequest = 0
+						loopMadeProgress = true
+						setlist.RequestsIncluded++
+						requestAdded = true
+						break
+					} else {
+						fmt.Fprintf(b.out, "❌ Request %s failed validation at current position. Will try again later.\n", request)
+						i++
+					}
+				}
+				if !requestAdded && len(requests) > 0 {
+					fmt.Fprintln(b.out, "⚠️ No requests passed at this point. Keeping them for next opportunity.")
+				}
+			}
+			b.trace.Slots = append(b.trace.Slots, *slot)
+			if loopMadeProgress {
+				staleRounds = 0
+			} else {
+				staleRounds++
+				countTillRequest = 0
+				fmt.Fprintf(b.out, "Failed to add any requests at this specific spot, will attempt in %d more songs...\n", params.Spacing())
+			}
+		}
+		if state.totalDuration < target {
+			underfill := target - state.totalDuration
+			warning := fmt.Sprintf("Set %d is underfilled by %d minutes and %d seconds.", len(setlist.Sets)+1, underfill/60, underfill%60)
+			setlist.Warnings = append(setlist.Warnings, warning)
+			fmt.Fprintln(b.out, "")
+			fmt.Fprintf(b.out, "Warning: %s\n", warning)
+			fmt.Fprintln(b.out, "")
+		}
+		setlist.Sets = append(setlist.Sets, Set{TargetMinutes: set, Entries: state.entries})
+		setStart += state.totalDuration + params.SongGap*max(0, len(state.entries)-1) + BreakMinutes(len(setLengths))*60
+	}
+	setlist.BreakMinutes = BreakMinutes(len(setlist.Sets))
+	for i, times := range setlist.Schedule() {
+		if len(times) == 0 {
+			continue
+		}
+		if over := params.hardStopOverrun(times[len(times)-1].End); over > 0 {
+			warning := fmt.Sprintf("Set %d runs %d minutes and %d seconds past the %s hard stop.", i+1, over/60, over%60, params.HardStop)
+			setlist.Warnings = append(setlist.Warnings, warning)
+			fmt.Fprintf(b.out, "Warning: %s\n", warning)
+		}
+	}
+	for _, warning := range params.Mix.decadeWarnings(run.decadeCounts, run.songs) {
+		warning = "Across the night, " + warning + "."
+		setlist.Warnings = append(setlist.Warnings, warning)
+		fmt.Fprintf(b.out, "Warning: %s\n", warning)
+	}
+	if warning := params.Mix.fastWarning(run.fastSongs, run.songs); warning != "" {
+		warning = "Across the night, " + warning + "."
+		setlist.Warnings = append(setlist.Warnings, warning)
+		fmt.Fprintf(b.out, "Warning: %s\n", warning)
+	}
+	for _, warning := range airtimeWarnings(setlist) {
+		setlist.Warnings = append(setlist.Warnings, warning)
+		fmt.Fprintf(b.out, "Warning: %s\n", warning)
+	}
+	if saveErr := saveTrace(ctx, b.db, setlist, b.trace); saveErr != nil {
+		fmt.Fprintf(b.out, "Unable to save build trace: %v\n", saveErr)
+	}
+	return setlist, nil
+}
+
+func (b *Builder) tryAddTrackToSet(ctx context.Context, dbQueries *database.Queries, run *buildRun, state *setState, slot *SlotTrace, track database.Working, request bool) bool {
+	if state.usedArtists[track.Artist] {
+		fmt.Fprintf(b.out, "Rejected %s: artist %s already used\n", track.Name, track.Artist)
+		slot.reject(track.Name, track.Artist, RuleRepeatArtist, fmt.Sprintf("artist %s already used in this set", track.Artist))
+		return false
+	}
+	if run.addedSongs[track.TrackID] {
+		fmt.Fprintf(b.out, "Rejected %s: song already added\n", track.Name)
+		slot.reject(track.Name, track.Artist, RuleRepeatSong, "song already added")
+		return false
+	}
+
+	if state.totalDuration+int(track.DurationInSeconds) > state.maxDuration+300 {
+		fmt.Fprintf(b.out, "Rejected %s: would exceed maxDuration (%d + %d > %d)\n", track.Name, state.totalDuration, track.DurationInSeconds, state.maxDuration+300)
+		slot.reject(track.Name, track.Artist, RuleSetLength, "too long for the time left in the set")
+		return false
+	}
+	if policy := run.params.Explicit(); !ExplicitAllowed(policy, track.Explicit, track.CleanVersion) {
+		fmt.Fprintf(b.out, "Rejected %s: %s\n", track.Name, explicitReason(policy))
+		slot.reject(track.Name, track.Artist, RuleExplicit, explicitReason(policy))
+		return false
+	}
+
+	buckets := run.genres.Buckets(track.Genre)
+	if run.params.Mix.NoRepeatGenre {
+		if genre := sharedBucket(buckets, state.lastBuckets); genre != "" {
+			fmt.Fprintf(b.out, "Rejected %s: another %s song was just played\n", track.Name, genre)
+			slot.reject(track.Name, track.Artist, RuleRepeatGenre, fmt.Sprintf("another %s song was just played", genre))
+			return false
+		}
+	}
+	if genre := run.params.Mix.overLimit(buckets, state.genreCounts); genre != "" {
+		limit := run.params.Mix.MaxGenrePerSet[genre]
+		fmt.Fprintf(b.out, "Rejected %s: set already has %d %s song(s)\n", track.Name, limit, genre)
+		slot.reject(track.Name, track.Artist, RuleGenreLimit, fmt.Sprintf("the set already has %d %s song(s)", limit, genre))
+		return false
+	}
+	start := state.nextStart(run.params.SongGap)
+	if window, outside := run.params.outsideWindow(start, track.Bpm, track.Explicit, buckets); outside {
+		fmt.Fprintf(b.out, "Rejected %s: would start at %s, but %s\n", track.Name, run.params.Clock(start), window.Rule())
+		slot.reject(track.Name, track.Artist, RuleTimeWindow, fmt.Sprintf("it would start at %s, but %s", run.params.Clock(start), window.Rule()))
+		return false
+	}
+
+	singers := run.params.Singers
+	params := database.GetSingerCombosParams{
+		TrackID: track.TrackID,
+		Column2: singers,
+	}
+	combos, combosErr := dbQueries.GetSingerCombos(ctx, params)
+	if combosErr != nil {
+		fmt.Fprintf(b.out, "unable to get singer/key combo for %s: %v.", track.Name, combosErr)
+		return false
+	}
+	if len(combos) == 0 {
+		slot.reject(track.Name, track.Artist, RuleSinger, "none of the singers for this gig have a key for it")
+	}
+	parts, partsErr := dbQueries.GetSingerParts(ctx, track.TrackID)
+	if partsErr != nil {
+		fmt.Fprintf(b.out, "unable to get duet parts for %s: %v.", track.Name, partsErr)
+		return false
+	}
+	partners := partnersByLead(parts)
+	if run.targets != nil {
+		// try the singers furthest behind their airtime target first
+		sort.SliceStable(combos, func(i, j int) bool {
+			return run.behindTarget(combos[i].Singer) > run.behindTarget(combos[j].Singer)
+		})
+	}
+	comboRule := ""
+	comboReasons := []string{}
+	for _, combo := range combos {
+		for _, singer := range singers {
+			if combo.Singer == singer {
+				fmt.Fprintf(b.out, "Matched singer: %s - %s\n", singer, combo.Key)
+				break
+			}
+		}
+		entry := SetEntry{
+			Name:              track.Name,
+			Artist:            track.Artist,
+			Singer:            combo.Singer,
+			Partners:          partners[combo.Singer],
+			Key:               combo.Key,
+			DurationInSeconds: track.DurationInSeconds,
+			Explicit:          track.Explicit,
+			Clean:             track.Explicit && run.params.Explicit() == ExplicitClean,
+			Request:           request,
+		}
+		if missing := missingSingers(entry, singers); len(missing) > 0 {
+			fmt.Fprintf(b.out, "Rejected %s: %s isn't singing at this gig\n", track.Name, strings.Join(missing, " & "))
+			if comboRule == "" {
+				comboRule = RuleSinger
+			}
+			comboReasons = append(comboReasons, fmt.Sprintf("%s sings it with %s, who isn't singing at this gig", combo.Singer, strings.Join(missing, " & ")))
+			continue
+		}
+		track.Singer = sql.NullString{String: combo.Singer, Valid: true}
+		track.SingerKey = sql.NullString{String: combo.Key, Valid: true}
+		if state.lastKey != "" && track.SingerKey.String == state.lastKey && track.SingerKey.String == state.secondToLastKey {
+			fmt.Fprintf(b.out, "Rejected %s: same key (%s) as last two tracks\n", track.Name, track.SingerKey.String)
+			comboRule = RuleRepeatKey
+			comboReasons = append(comboReasons, fmt.Sprintf("%s in %s would be the same key as the last two songs", combo.Singer, combo.Key))
+			continue
+		}
+		if len(singers) != 1 && run.balanced {
+			if repeated := repeatedSinger(entry, state.lastSingers, state.secondToLastSingers); repeated != "" {
+				fmt.Fprintf(b.out, "Rejected %s: same singer (%s) for last two tracks\n", track.Name, repeated)
+				if comboRule == "" {
+					comboRule = RuleRepeatSinger
+				}
+				comboReasons = append(comboReasons, fmt.Sprintf("%s already sang the last two songs", repeated))
+				continue
+			}
+		}
+		overReason := ""
+		for _, vocalist := range entry.Vocalists() {
+			if overReason = run.overTarget(state, vocalist, entry.VocalSeconds()); overReason != "" {
+				break
+			}
+		}
+		if overReason != "" {
+			fmt.Fprintf(b.out, "Rejected %s: %s\n", track.Name, overReason)
+			if comboRule == "" {
+				comboRule = RuleSingerShare
+			}
+			comboReasons = append(comboReasons, overReason)
+			continue
+		}
+		addSingerParams := database.AddSingerToWorkingParams{
+			Singer:    track.Singer,
+			SingerKey: track.SingerKey,
+			TrackID:   track.TrackID,
+		}
+		addErr := dbQueries.AddSingerToWorking(ctx, addSingerParams)
+		if addErr != nil {
+			fmt.Fprintf(b.out, "unable to add singer/key combo to working table for %s: %v", track.Name, addErr)
+			continue
+		}
+		state.secondToLastKey = state.lastKey
+		state.lastKey = track.SingerKey.String
+		state.secondToLastSingers = state.lastSingers
+		state.lastSingers = entry.Vocalists()
+		state.totalDuration += int(track.DurationInSeconds)
+		for _, vocalist := range entry.Vocalists() {
+			state.singerSeconds[vocalist] += entry.VocalSeconds()
+			run.singerSeconds[vocalist] += entry.VocalSeconds()
+		}
+		state.usedArtists[track.Artist] = true
+		state.lastBuckets = buckets
+		for _, bucket := range buckets {
+			state.genreCounts[bucket]++
+		}
+		run.decadeCounts[Decade(track.Year)]++
+		if track.Bpm >= FastBPM {
+			run.fastSongs++
+		}
+		run.songs++
+		run.addedSongs[track.TrackID] = true
+		state.entries = append(state.entries, entry)
+		fmt.Fprintf(b.out, "✅ Added track: %s by %s [%s]\n", track.Name, track.Artist, track.SingerKey.String)
+		if slot != nil {
+			chosen := state.entries[len(state.entries)-1]
+			slot.Chosen = &chosen
+		}
+		dbQueries.RemoveFromWorking(ctx, track.TrackID)
+		return true
+	}
+	fmt.Fprintf(b.out, "Rejected: unable to find singer/key combo for %s that does not violate conditions.\n", track.Name)
+	if len(comboReasons) > 0 {
+		slot.reject(track.Name, track.Artist, comboRule, strings.Join(comboReasons, "; "))
+	}
+	return false
+}
+
+// balancedSingers reports whether every singer has enough songs to take their share of the gig
+// without singing back to back. Each singer needs two thirds of their share (a third of the gig
+// for each of two singers sharing it equally). totals are the singers' song durations.
+func balancedSingers(params BuildParams, totals []database.SumDurationForSingerRow) bool {
+	if len(params.Singers) < 2 {
+		return true
+	}
+	targets := params.Targets()
+	seconds := map[string]int64{}
+	for _, total := range totals {
+		seconds[total.Singer] = total.TotalDuration
+	}
+	for _, singer := range params.Singers {
+		share := 100 / float64(len(params.Singers))
+		if target, ok := targets[singer]; ok {
+			share = target
+		}
+		if seconds[singer]/60 < int64(float64(params.Duration)*share/100*2/3) {
+			return false
+		}
+	}
+	return true
+}
+
+// airtimeWarnings lists the sets, and the night, where a singer's share is off their target.
+func airtimeWarnings(setlist *Setlist) []string {
+	if len(setlist.Params.SingerTargets) == 0 {
+		return nil
+	}
+	tolerance := setlist.Params.Tolerance()
+	warnings := []string{}
+	for i := range setlist.Sets {
+		for _, singer := range setlist.Airtime(i) {
+			if singer.OffTarget(tolerance) {
+				warnings = append(warnings, fmt.Sprintf("%s sang %.0f%% of set %d, their target is %g%%.", singer.Singer, singer.Share, i+1, singer.Target))
+			}
+		}
+	}
+	for _, singer := range setlist.NightAirtime() {
+		if singer.OffTarget(tolerance) {
+			warnings = append(warnings, fmt.Sprintf("%s sang %.0f%% of the night, their target is %g%%.", singer.Singer, singer.Share, singer.Target))
+		}
+	}
+	return warnings
+}
+
+func removeIndex(s []int, index int) []int {
+	return append(s[:index], s[index+1:]...)
+}
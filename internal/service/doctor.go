@@ -0,0 +1,172 @@
+package service
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/constants"
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+// SingerCombo is how much material a group of singers has between them.
+type SingerCombo struct {
+	Singers []string
+	// Songs and the durations count each track once, however many of the singers can sing it.
+	// CleanSeconds includes explicit songs the band has a clean version of.
+	Songs           int
+	DurationSeconds int
+	CleanSeconds    int
+	// Balanced is false when one of the singers has less than a third of the gig, in which
+	// case builds ignore the repeat singer rule.
+	Balanced bool
+}
+
+// FitsGig reports whether the combo has enough songs to fill minutes of playing time, with
+// or without explicit songs.
+func (c SingerCombo) FitsGig(minutes int, allowExplicit bool) bool {
+	if allowExplicit {
+		return c.DurationSeconds >= minutes*60
+	}
+	return c.CleanSeconds >= minutes*60
+}
+
+// HealthReport is the result of auditing the library for gaps that get in the way of builds.
+type HealthReport struct {
+	Tracks          int
+	MissingKey      []database.Track
+	MissingBpm      []database.Track
+	NoSingers       []database.Track
+	InvalidKeys     []database.GetAllSingersRow
+	UnknownSingers  []database.GetAllSingersRow
+	SingerTotals    []database.SumDurationForSingerRow
+	Genres          map[string]int
+	NoGenre         int
+	Decades         map[string]int
+	NoYear          int
+	Duplicates      [][]database.Track
+	OrphanedWorking int
+	Combos          []SingerCombo
+	// LongestGigMinutes is the playing time of the longest gig allowed, which each combo is
+	// checked against.
+	LongestGigMinutes int
+}
+
+// Problems counts the issues in the report that need fixing. Coverage numbers aren't counted.
+func (r *HealthReport) Problems() int {
+	problems := len(r.MissingKey) + len(r.MissingBpm) + len(r.NoSingers) + len(r.InvalidKeys) + len(r.UnknownSingers) + len(r.Duplicates)
+	if r.OrphanedWorking > 0 {
+		problems++
+	}
+	return problems
+}
+
+// PlayingMinutes is the music in a gig of duration minutes once breaks are taken out.
+func PlayingMinutes(duration int32) int {
+	total := 0
+	for _, length := range SetLengths(duration) {
+		total += int(length)
+	}
+	return total
+}
+
+// Diagnose audits the library. totals are the per singer sums for every singer, and working
+// is the number of rows left in the working table, which should be empty outside of a build.
+func Diagnose(tracks []database.Track, singers []database.GetAllSingersRow, totals []database.SumDurationForSingerRow, working int) *HealthReport {
+	report := &HealthReport{
+		Tracks:            len(tracks),
+		SingerTotals:      totals,
+		Genres:            map[string]int{},
+		Decades:           map[string]int{},
+		Duplicates:        FindDuplicates(tracks),
+		OrphanedWorking:   working,
+		LongestGigMinutes: PlayingMinutes(MaxDuration),
+	}
+	singersByTrack := map[int32][]string{}
+	for _, singer := range singers {
+		singersByTrack[singer.TrackID] = append(singersByTrack[singer.TrackID], singer.Singer)
+		if !slices.Contains(constants.ValidKeys, strings.ToLower(singer.Key)) {
+			report.InvalidKeys = append(report.InvalidKeys, singer)
+		}
+		if !slices.Contains(constants.ValidSingers, strings.ToLower(singer.Singer)) {
+			report.UnknownSingers = append(report.UnknownSingers, singer)
+		}
+	}
+	for _, track := range tracks {
+		if track.OriginalKey == "" {
+			report.MissingKey = append(report.MissingKey, track)
+		}
+		if track.Bpm == 0 {
+			report.MissingBpm = append(report.MissingBpm, track)
+		}
+		if len(singersByTrack[track.ID]) == 0 {
+			report.NoSingers = append(report.NoSingers, track)
+		}
+		if len(track.Genre) == 0 {
+			report.NoGenre++
+		}
+		for _, genre := range track.Genre {
+			report.Genres[strings.ToLower(genre)]++
+		}
+		if decade := Decade(track.Year); decade != "" {
+			report.Decades[decade]++
+		} else {
+			report.NoYear++
+		}
+	}
+	report.Combos = singerCombos(tracks, singersByTrack, totals)
+	return report
+}
+
+// Decade turns a year like "1985" into "1980s", or "" when the year is unknown.
+func Decade(year string) string {
+	if len(year) < 4 {
+		return ""
+	}
+	for _, digit := range year[:3] {
+		if digit < '0' || digit > '9' {
+			return ""
+		}
+	}
+	return year[:3] + "0s"
+}
+
+// singerCombos works out the material for every group of singers that have songs.
+func singerCombos(tracks []database.Track, singersByTrack map[int32][]string, totals []database.SumDurationForSingerRow) []SingerCombo {
+	names := []string{}
+	singerSeconds := map[string]int64{}
+	for _, total := range totals {
+		names = append(names, total.Singer)
+		singerSeconds[total.Singer] = total.TotalDuration
+	}
+	sort.Strings(names)
+	combos := []SingerCombo{}
+	// every non-empty subset of the singers, as a bit mask over names
+	for mask := 1; mask < 1<<len(names); mask++ {
+		combo := SingerCombo{Balanced: true}
+		for i, name := range names {
+			if mask&(1<<i) != 0 {
+				combo.Singers = append(combo.Singers, name)
+			}
+		}
+		// the same check builds make before deciding to ignore the repeat singer rule
+		for _, name := range combo.Singers {
+			if len(combo.Singers) > 1 && singerSeconds[name]/60 < MaxDuration/3 {
+				combo.Balanced = false
+			}
+		}
+		for _, track := range tracks {
+			if !slices.ContainsFunc(singersByTrack[track.ID], func(singer string) bool { return slices.Contains(combo.Singers, singer) }) {
+				continue
+			}
+			combo.Songs++
+			combo.DurationSeconds += int(track.DurationInSeconds)
+			if ExplicitAllowed(ExplicitClean, track.Explicit, track.CleanVersion) {
+				combo.CleanSeconds += int(track.DurationInSeconds)
+			}
+		}
+		combos = append(combos, combo)
+	}
+	slices.SortStableFunc(combos, func(a, b SingerCombo) int { return len(a.Singers) - len(b.Singers) })
+	return combos
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+func TestDiagnose(t *testing.T) {
+	tracks := []database.Track{
+		{ID: 1, Name: "Africa", Artist: "Toto", Genre: []string{"soft rock"}, Year: "1982", DurationInSeconds: 3600, Bpm: 93, OriginalKey: "B"},
+		{ID: 2, Name: "Valerie", Artist: "Amy Winehouse", Genre: []string{"soul", "Soft Rock"}, Year: "2007", DurationInSeconds: 3600, Bpm: 0, OriginalKey: "Eb"},
+		{ID: 3, Name: "Pink Pony Club", Artist: "Chappell Roan", Year: "", DurationInSeconds: 3600, Bpm: 120, OriginalKey: "", Explicit: true},
+	}
+	singers := []database.GetAllSingersRow{
+		{TrackID: 1, Name: "Africa", Artist: "Toto", Singer: "Riley", Key: "B"},
+		{TrackID: 2, Name: "Valerie", Artist: "Amy Winehouse", Singer: "Riley", Key: "H"},
+		{TrackID: 2, Name: "Valerie", Artist: "Amy Winehouse", Singer: "Ty", Key: "Eb"},
+		{TrackID: 3, Name: "Pink Pony Club", Artist: "Chappell Roan", Singer: "Sam", Key: "F"},
+	}
+	totals := []database.SumDurationForSingerRow{
+		{Singer: "Riley", SongCount: 2, TotalDuration: 7200, CleanDuration: 7200},
+		{Singer: "Ty", SongCount: 1, TotalDuration: 3600, CleanDuration: 3600},
+	}
+	report := Diagnose(tracks, singers, totals, 4)
+
+	if len(report.MissingKey) != 1 || report.MissingKey[0].ID != 3 {
+		t.Errorf("Expected Pink Pony Club to be missing a key, got %v", report.MissingKey)
+	}
+	if len(report.MissingBpm) != 1 || report.MissingBpm[0].ID != 2 {
+		t.Errorf("Expected Valerie to be missing a BPM, got %v", report.MissingBpm)
+	}
+	if len(report.NoSingers) != 0 {
+		t.Errorf("Expected every track to have singers, got %v", report.NoSingers)
+	}
+	if len(report.InvalidKeys) != 1 || report.InvalidKeys[0].Key != "H" {
+		t.Errorf("Expected the key H to be invalid, got %v", report.InvalidKeys)
+	}
+	if len(report.UnknownSingers) != 1 || report.UnknownSingers[0].Singer != "Sam" {
+		t.Errorf("Expected Sam to be an unknown singer, got %v", report.UnknownSingers)
+	}
+	if report.Genres["soft rock"] != 2 || report.NoGenre != 1 {
+		t.Errorf("Expected 2 soft rock songs and 1 with no genre, got %v and %d", report.Genres, report.NoGenre)
+	}
+	if report.Decades["1980s"] != 1 || report.Decades["2000s"] != 1 || report.NoYear != 1 {
+		t.Errorf("Expected one song each from the 1980s and 2000s and one with no year, got %v and %d", report.Decades, report.NoYear)
+	}
+	if report.Problems() != 5 {
+		t.Errorf("Expected 5 problems, got %d", report.Problems())
+	}
+
+	if len(report.Combos) != 3 {
+		t.Fatalf("Expected 3 singer combos, got %v", report.Combos)
+	}
+	both := report.Combos[2]
+	if len(both.Singers) != 2 || both.Songs != 2 || both.DurationSeconds != 7200 {
+		t.Errorf("Expected Riley and Ty to share 2 songs and 120 minutes, got %+v", both)
+	}
+	if !both.Balanced {
+		t.Errorf("Expected Riley and Ty to be balanced, got %+v", both)
+	}
+	if both.CleanSeconds != 7200 {
+		t.Errorf("Expected all 120 minutes to be clean, got %+v", both)
+	}
+	if both.FitsGig(report.LongestGigMinutes, true) {
+		t.Errorf("Expected 120 minutes not to fill a %d minute gig", report.LongestGigMinutes)
+	}
+
+	tracks[0].Explicit = true
+	if riley := Diagnose(tracks, singers, totals, 4).Combos[0]; riley.CleanSeconds != 3600 {
+		t.Errorf("Expected explicit Africa not to count as clean, got %+v", riley)
+	}
+	tracks[0].CleanVersion = true
+	if riley := Diagnose(tracks, singers, totals, 4).Combos[0]; riley.CleanSeconds != 7200 {
+		t.Errorf("Expected Africa's clean version to count as clean, got %+v", riley)
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+// DefaultRole is the part a partner sings when none is given.
+const DefaultRole = "duet"
+
+// partnersByLead groups a track's duet parts by the lead singer they go with.
+func partnersByLead(parts []database.SingerPart) map[string][]Partner {
+	partners := map[string][]Partner{}
+	for _, part := range parts {
+		partners[part.Lead] = append(partners[part.Lead], Partner{Singer: part.Singer, Role: part.Role})
+	}
+	return partners
+}
+
+// missingSingers returns the vocalists on entry that aren't among singers.
+func missingSingers(entry SetEntry, singers []string) []string {
+	missing := []string{}
+	for _, vocalist := range entry.Vocalists() {
+		if !slices.Contains(singers, vocalist) {
+			missing = append(missing, vocalist)
+		}
+	}
+	return missing
+}
+
+// repeatedSinger returns a vocalist on entry who also sang both of the last two songs, or "" if
+// there isn't one.
+func repeatedSinger(entry SetEntry, last, secondToLast []string) string {
+	for _, vocalist := range entry.Vocalists() {
+		if slices.Contains(last, vocalist) && slices.Contains(secondToLast, vocalist) {
+			return vocalist
+		}
+	}
+	return ""
+}
+
+// samePartners reports whether two entries have the same vocalists alongside the lead, whatever
+// their roles.
+func samePartners(a, b []Partner) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, partner := range a {
+		if !slices.ContainsFunc(b, func(other Partner) bool { return strings.EqualFold(other.Singer, partner.Singer) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseVocalists splits singers written the way entries are printed, e.g. "Riley & Ty", into
+// the lead and their partners. Partners read this way have no role.
+func ParseVocalists(singers string) (string, []Partner) {
+	names := strings.Split(singers, "&")
+	var partners []Partner
+	for _, name := range names[1:] {
+		if name = strings.TrimSpace(name); name != "" {
+			partners = append(partners, Partner{Singer: name})
+		}
+	}
+	return strings.TrimSpace(names[0]), partners
+}
+
+func partnerNames(partners []Partner) string {
+	names := []string{}
+	for _, partner := range partners {
+		names = append(names, partner.Singer)
+	}
+	return strings.Join(names, " & ")
+}
+
+// AddParts adds duet parts to the library, so entries made from it include their partners.
+func (l *Library) AddParts(parts []database.GetAllSingerPartsRow) {
+	for _, part := range parts {
+		track, found := l.Track(part.Name, part.Artist)
+		if !found {
+			continue
+		}
+		if track.Parts == nil {
+			track.Parts = map[string][]Partner{}
+		}
+		track.Parts[part.Lead] = append(track.Parts[part.Lead], Partner{Singer: part.Singer, Role: part.Role})
+	}
+}
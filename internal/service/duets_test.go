@@ -0,0 +1,122 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+func TestParseVocalists(t *testing.T) {
+	lead, partners := ParseVocalists(" Riley & Ty &Bos ")
+	if lead != "Riley" || !reflect.DeepEqual(partners, []Partner{{Singer: "Ty"}, {Singer: "Bos"}}) {
+		t.Errorf("Expected Riley leading Ty and Bos, got %s and %v", lead, partners)
+	}
+	lead, partners = ParseVocalists("Riley")
+	if lead != "Riley" || partners != nil {
+		t.Errorf("Expected Riley alone, got %s and %v", lead, partners)
+	}
+}
+
+func TestDuetEntry(t *testing.T) {
+	entry := SetEntry{Name: "Shallow", Singer: "Riley", Partners: []Partner{{Singer: "Ty", Role: "duet"}}, Key: "G", DurationInSeconds: 215}
+	if entry.String() != "Shallow - Riley & Ty - G" {
+		t.Errorf("Expected duets to print both singers, got %q", entry.String())
+	}
+	if entry.VocalSeconds() != 107 {
+		t.Errorf("Expected the duet to be split between two singers, got %d seconds each", entry.VocalSeconds())
+	}
+
+	file, readErr := ReadSetlistFile(strings.NewReader("Set 1:\n1: "+entry.String()+"\n"), "text")
+	if readErr != nil {
+		t.Fatalf("unexpected error: %v", readErr)
+	}
+	read := file.Setlist.Sets[0].Entries[0]
+	if read.Singer != "Riley" || len(read.Partners) != 1 || read.Partners[0].Singer != "Ty" {
+		t.Errorf("Expected the printed duet to read back as Riley with Ty, got %+v", read)
+	}
+}
+
+func duetLibrary() *Library {
+	library := NewLibrary([]database.GetTracksWithSingersRow{
+		{Name: "Shallow", Artist: "Lady Gaga", DurationInSeconds: 240, Singer: "Riley", SingerKey: "G"},
+		{Name: "Valerie", Artist: "Amy Winehouse", DurationInSeconds: 240, Singer: "Riley", SingerKey: "Eb"},
+		{Name: "Africa", Artist: "Toto", DurationInSeconds: 240, Singer: "Ty", SingerKey: "A"},
+		{Name: "Dreams", Artist: "Fleetwood Mac", DurationInSeconds: 240, Singer: "Bos", SingerKey: "C"},
+	})
+	library.AddParts([]database.GetAllSingerPartsRow{
+		{Name: "Shallow", Artist: "Lady Gaga", Lead: "Riley", Singer: "Ty", Role: "duet"},
+	})
+	return library
+}
+
+func TestLibraryEntryIncludesPartners(t *testing.T) {
+	library := duetLibrary()
+	track, _ := library.Track("Shallow", "Lady Gaga")
+	entry, found := track.Entry("Riley", ExplicitAllow)
+	if !found || len(entry.Partners) != 1 || entry.Partners[0] != (Partner{Singer: "Ty", Role: "duet"}) {
+		t.Errorf("Expected Riley's Shallow to include Ty's duet part, got %+v", entry)
+	}
+}
+
+func TestValidateDuets(t *testing.T) {
+	library := duetLibrary()
+	entry := func(name, singer string) SetEntry {
+		track, _ := library.Track(name, map[string]string{"Shallow": "Lady Gaga", "Valerie": "Amy Winehouse", "Africa": "Toto", "Dreams": "Fleetwood Mac"}[name])
+		entry, _ := track.Entry(singer, ExplicitAllow)
+		return entry
+	}
+	rules := func(singers []string, entries ...SetEntry) []string {
+		setlist := &Setlist{Params: BuildParams{Singers: singers}, Sets: []Set{{Entries: entries}}}
+		found := []string{}
+		for _, violation := range Validate(setlist, library) {
+			found = append(found, violation.Rule)
+		}
+		return found
+	}
+
+	if found := rules([]string{"Riley", "Bos"}, entry("Shallow", "Riley")); !reflect.DeepEqual(found, []string{RuleSinger}) {
+		t.Errorf("Expected a duet without Ty at the gig to break the singer rule, got %v", found)
+	}
+	// Riley and Ty each sing two in a row, with the duet counting for both of them
+	found := rules([]string{"Riley", "Ty", "Bos"}, entry("Valerie", "Riley"), entry("Shallow", "Riley"), entry("Africa", "Ty"))
+	if len(found) != 0 {
+		t.Errorf("Expected no violations, got %v", found)
+	}
+	found = rules([]string{"Riley", "Ty", "Bos"}, entry("Dreams", "Bos"), entry("Africa", "Ty"), entry("Shallow", "Riley"), entry("Valerie", "Riley"))
+	if len(found) != 0 {
+		t.Errorf("Expected Ty's duet after Africa to be fine with Bos before it, got %v", found)
+	}
+	found = rules([]string{"Riley", "Ty", "Bos"}, entry("Africa", "Ty"), entry("Shallow", "Riley"), SetEntry{Name: "Dreams", Artist: "Fleetwood Mac", Singer: "Bos", Partners: []Partner{{Singer: "Ty"}}, Key: "C", DurationInSeconds: 240})
+	if !reflect.DeepEqual(found, []string{RuleSinger, RuleRepeatSinger}) {
+		t.Errorf("Expected an unknown partner and Ty singing three in a row, got %v", found)
+	}
+
+	solo := entry("Shallow", "Riley")
+	solo.Partners = nil
+	if found := rules([]string{"Riley", "Ty"}, solo); !reflect.DeepEqual(found, []string{RuleSinger}) {
+		t.Errorf("Expected Shallow without Ty to break the singer rule, got %v", found)
+	}
+}
+
+func TestDuetAirtime(t *testing.T) {
+	setlist := &Setlist{
+		Params: BuildParams{Singers: []string{"Riley", "Ty"}},
+		Sets: []Set{{Entries: []SetEntry{
+			{Name: "Shallow", Singer: "Riley", Partners: []Partner{{Singer: "Ty", Role: "duet"}}, DurationInSeconds: 240},
+			{Name: "Africa", Singer: "Ty", DurationInSeconds: 240},
+		}}},
+	}
+	airtime := setlist.Airtime(0)
+	if airtime[0].Songs != 1 || airtime[0].Seconds != 120 || airtime[0].Share != 25 {
+		t.Errorf("Expected Riley to get half of Shallow, got %+v", airtime[0])
+	}
+	if airtime[1].Songs != 2 || airtime[1].Seconds != 360 || airtime[1].Share != 75 {
+		t.Errorf("Expected Ty to get half of Shallow and all of Africa, got %+v", airtime[1])
+	}
+	stats := ComputeStats([]*Setlist{setlist}, nil)
+	if len(stats.Singers) != 2 || stats.Singers[0].Singer != "Ty" || stats.Singers[0].Songs != 2 {
+		t.Errorf("Expected stats to count the duet for both singers, got %+v", stats.Singers)
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+func TestExplicitPolicy(t *testing.T) {
+	for input, expected := range map[string]string{"Y": ExplicitAllow, "clean edit": ExplicitClean, "c": ExplicitClean, "exclude": ExplicitExclude, "n": ExplicitExclude} {
+		if policy, parseErr := ParseExplicitPolicy(input); parseErr != nil || policy != expected {
+			t.Errorf("Expected %q to be %s, got %s (%v)", input, expected, policy, parseErr)
+		}
+	}
+	if _, parseErr := ParseExplicitPolicy("sometimes"); parseErr == nil {
+		t.Error("Expected an error for an unknown policy")
+	}
+
+	tests := []struct {
+		policy       string
+		explicit     bool
+		cleanVersion bool
+		allowed      bool
+	}{
+		{ExplicitExclude, false, false, true},
+		{ExplicitExclude, true, true, false},
+		{ExplicitClean, true, false, false},
+		{ExplicitClean, true, true, true},
+		{ExplicitAllow, true, false, true},
+	}
+	for _, test := range tests {
+		if allowed := ExplicitAllowed(test.policy, test.explicit, test.cleanVersion); allowed != test.allowed {
+			t.Errorf("Expected %s with explicit %v and clean version %v to be allowed %v", test.policy, test.explicit, test.cleanVersion, test.allowed)
+		}
+	}
+
+	params := BuildParams{AllowExplicit: true}
+	if params.Explicit() != ExplicitAllow {
+		t.Errorf("Expected older setlists allowing explicit songs to use the allow policy, got %s", params.Explicit())
+	}
+	params.SetExplicitPolicy(ExplicitClean)
+	if params.Explicit() != ExplicitClean || params.AllowExplicit {
+		t.Errorf("Expected the clean policy without allowing explicit songs, got %+v", params)
+	}
+
+	allow := true
+	profile := &Profile{AllowExplicit: &allow}
+	if profile.Field("explicit") != ExplicitAllow {
+		t.Errorf("Expected an older profile allowing explicit songs to use the allow policy, got %q", profile.Field("explicit"))
+	}
+	if setErr := profile.Set("explicit", "clean"); setErr != nil || profile.Explicit() != ExplicitClean || profile.AllowExplicit != nil {
+		t.Errorf("Expected the clean policy to replace the older setting, got %q (%v)", profile.Explicit(), setErr)
+	}
+}
+
+func TestValidateCleanEdits(t *testing.T) {
+	library := NewLibrary([]database.GetTracksWithSingersRow{
+		{Name: "Hey Ya!", Artist: "OutKast", Explicit: true, CleanVersion: true, DurationInSeconds: 240, Singer: "Ty", SingerKey: "G"},
+		{Name: "Gold Digger", Artist: "Kanye West", Explicit: true, DurationInSeconds: 240, Singer: "Riley", SingerKey: "A"},
+	})
+	rules := func(entries ...SetEntry) []Violation {
+		setlist := &Setlist{Params: BuildParams{Singers: []string{"Riley", "Ty"}, ExplicitPolicy: ExplicitClean}, Sets: []Set{{Entries: entries}}}
+		return Validate(setlist, library)
+	}
+
+	heyYa, _ := library.Tracks[0].Entry("Ty", ExplicitClean)
+	if !heyYa.Clean || heyYa.String() != "Hey Ya! - Ty - G (clean edit)" {
+		t.Errorf("Expected Hey Ya! to be marked as a clean edit, got %q", heyYa)
+	}
+	if violations := rules(heyYa); len(violations) != 0 {
+		t.Errorf("Expected a clean edit to be allowed, got %v", violations)
+	}
+	heyYa.Clean = false
+	if violations := rules(heyYa); len(violations) != 1 || violations[0].Rule != RuleExplicit {
+		t.Errorf("Expected an explicit song not marked as a clean edit to break the explicit rule, got %v", violations)
+	}
+	goldDigger, _ := library.Tracks[1].Entry("Riley", ExplicitClean)
+	if violations := rules(goldDigger); len(violations) != 1 || violations[0].Rule != RuleExplicit {
+		t.Errorf("Expected an explicit song without a clean version to break the explicit rule, got %v", violations)
+	}
+	if plain, _ := library.Tracks[0].Entry("Ty", ExplicitAllow); plain.Clean {
+		t.Error("Expected songs not to be marked clean when explicit songs are allowed")
+	}
+}
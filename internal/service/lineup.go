@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+// LineupExclusion is a song left out of a build because it needs players who weren't booked.
+type LineupExclusion struct {
+	Name    string   `json:"name"`
+	Artist  string   `json:"artist"`
+	Missing []string `json:"missing"`
+}
+
+// ParseInstruments reads a comma separated list of instruments or players, e.g. "horns, Keys",
+// lowercased with blanks and repeats removed.
+func ParseInstruments(input string) []string {
+	instruments := []string{}
+	for _, instrument := range strings.Split(input, ",") {
+		instrument = NormalizeInstrument(instrument)
+		if instrument != "" && !slices.Contains(instruments, instrument) {
+			instruments = append(instruments, instrument)
+		}
+	}
+	return instruments
+}
+
+// NormalizeInstrument is how instruments are stored, so "Second Guitar " and "second guitar"
+// match.
+func NormalizeInstrument(instrument string) string {
+	return strings.Join(strings.Fields(strings.ToLower(instrument)), " ")
+}
+
+// MissingPlayers returns the requirements that aren't in the lineup. A build without a lineup
+// has the full band, so nothing is ever missing.
+func (p *BuildParams) MissingPlayers(requirements []string) []string {
+	if len(p.Lineup) == 0 {
+		return nil
+	}
+	missing := []string{}
+	for _, requirement := range requirements {
+		if !slices.Contains(p.Lineup, requirement) {
+			missing = append(missing, requirement)
+		}
+	}
+	return missing
+}
+
+// requirementsByTrack groups every track's requirements by track ID.
+func requirementsByTrack(rows []database.GetAllTrackRequirementsRow) map[int32][]string {
+	requirements := map[int32][]string{}
+	for _, row := range rows {
+		requirements[row.TrackID] = append(requirements[row.TrackID], row.Instrument)
+	}
+	return requirements
+}
+
+// excludeForLineup removes songs needing players outside the lineup from the working table and
+// returns them, keyed by track ID.
+func excludeForLineup(ctx context.Context, dbQueries *database.Queries, params BuildParams, tracks []database.Track) (map[int32]LineupExclusion, error) {
+	excluded := map[int32]LineupExclusion{}
+	if len(params.Lineup) == 0 {
+		return excluded, nil
+	}
+	rows, getErr := dbQueries.GetAllTrackRequirements(ctx)
+	if getErr != nil {
+		return nil, fmt.Errorf("unable to get track requirements: %v", getErr)
+	}
+	requirements := requirementsByTrack(rows)
+	for _, track := range tracks {
+		missing := params.MissingPlayers(requirements[track.ID])
+		if len(missing) == 0 {
+			continue
+		}
+		if removeErr := dbQueries.RemoveFromWorking(ctx, track.ID); removeErr != nil {
+			return nil, fmt.Errorf("error removing %s from working table: %v", track.Name, removeErr)
+		}
+		excluded[track.ID] = LineupExclusion{Name: track.Name, Artist: track.Artist, Missing: missing}
+	}
+	return excluded, nil
+}
+
+// AddRequirements adds the players each track needs to the library.
+func (l *Library) AddRequirements(rows []database.GetAllTrackRequirementsRow) {
+	for _, row := range rows {
+		if track, found := l.Track(row.Name, row.Artist); found {
+			track.Requirements = append(track.Requirements, row.Instrument)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+func TestParseInstruments(t *testing.T) {
+	instruments := ParseInstruments(" Horns, second  Guitar,,horns ")
+	if !reflect.DeepEqual(instruments, []string{"horns", "second guitar"}) {
+		t.Errorf("Expected horns and second guitar, got %v", instruments)
+	}
+}
+
+func TestMissingPlayers(t *testing.T) {
+	fullBand := BuildParams{}
+	if missing := fullBand.MissingPlayers([]string{"horns"}); len(missing) != 0 {
+		t.Errorf("Expected nothing to be missing without a lineup, got %v", missing)
+	}
+	reduced := BuildParams{Lineup: []string{"guitar", "bass", "drums", "keys"}}
+	if missing := reduced.MissingPlayers([]string{"horns", "keys", "second guitar"}); !reflect.DeepEqual(missing, []string{"horns", "second guitar"}) {
+		t.Errorf("Expected horns and second guitar to be missing, got %v", missing)
+	}
+}
+
+func TestValidateLineup(t *testing.T) {
+	library := NewLibrary([]database.GetTracksWithSingersRow{
+		{Name: "September", Artist: "Earth, Wind & Fire", DurationInSeconds: 215, Singer: "Riley", SingerKey: "A"},
+		{Name: "Africa", Artist: "Toto", DurationInSeconds: 295, Singer: "Ty", SingerKey: "A"},
+	})
+	library.AddRequirements([]database.GetAllTrackRequirementsRow{
+		{Name: "September", Artist: "Earth, Wind & Fire", Instrument: "horns"},
+	})
+	setlist := &Setlist{
+		Params: BuildParams{Singers: []string{"Riley", "Ty"}, Lineup: []string{"guitar", "bass", "drums"}},
+		Sets: []Set{{Entries: []SetEntry{
+			{Name: "September", Artist: "Earth, Wind & Fire", Singer: "Riley", Key: "A", DurationInSeconds: 215},
+			{Name: "Africa", Artist: "Toto", Singer: "Ty", Key: "A", DurationInSeconds: 295},
+		}}},
+	}
+	violations := Validate(setlist, library)
+	if len(violations) != 1 || violations[0].Rule != RuleLineup || violations[0].Position != 0 {
+		t.Errorf("Expected September to break the lineup rule, got %v", violations)
+	}
+	setlist.Params.Lineup = append(setlist.Params.Lineup, "horns")
+	if violations := Validate(setlist, library); len(violations) != 0 {
+		t.Errorf("Expected no violations once horns are booked, got %v", violations)
+	}
+}
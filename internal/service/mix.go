@@ -0,0 +1,394 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+// GenreMap turns Spotify's genres into the band's own genre buckets. A genre with its own row
+// uses that bucket. Otherwise the longest mapped genre found in it as whole words wins, with ties
+// going to the one nearest the end, so "contemporary country" is country and "country rock" is
+// rock. Genres nothing matches are their own bucket. A nil GenreMap puts every genre in its own
+// bucket.
+type GenreMap struct {
+	buckets map[string]string
+}
+
+func NewGenreMap(rows []database.GenreBucket) *GenreMap {
+	m := &GenreMap{buckets: map[string]string{}}
+	for _, row := range rows {
+		m.buckets[NormalizeGenre(row.Genre)] = NormalizeGenre(row.Bucket)
+	}
+	return m
+}
+
+func LoadGenreMap(ctx context.Context, dbQueries *database.Queries) (*GenreMap, error) {
+	rows, getErr := dbQueries.GetGenreBuckets(ctx)
+	if getErr != nil {
+		return nil, fmt.Errorf("unable to get genre buckets: %v", getErr)
+	}
+	return NewGenreMap(rows), nil
+}
+
+// NormalizeGenre lowercases a genre and collapses its spaces.
+func NormalizeGenre(genre string) string {
+	return strings.Join(strings.Fields(strings.ToLower(genre)), " ")
+}
+
+// Bucket returns the bucket for one genre, and whether a mapping matched it.
+func (m *GenreMap) Bucket(genre string) (string, bool) {
+	genre = NormalizeGenre(genre)
+	if m == nil {
+		return genre, false
+	}
+	if bucket, ok := m.buckets[genre]; ok {
+		return bucket, true
+	}
+	words := strings.Fields(genre)
+	best, bestWords, bestEnd := "", 0, -1
+	for mapped, bucket := range m.buckets {
+		mappedWords := strings.Fields(mapped)
+		for start := 0; start+len(mappedWords) <= len(words); start++ {
+			if !slices.Equal(words[start:start+len(mappedWords)], mappedWords) {
+				continue
+			}
+			end := start + len(mappedWords)
+			if len(mappedWords) > bestWords || (len(mappedWords) == bestWords && end > bestEnd) {
+				best, bestWords, bestEnd = bucket, len(mappedWords), end
+			}
+		}
+	}
+	if best == "" {
+		return genre, false
+	}
+	return best, true
+}
+
+// Buckets returns the buckets a track's genres fall into, without repeats.
+func (m *GenreMap) Buckets(genres []string) []string {
+	buckets := []string{}
+	for _, genre := range genres {
+		if bucket, _ := m.Bucket(genre); bucket != "" && !slices.Contains(buckets, bucket) {
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets
+}
+
+// MixRules limit the genres and decades in a build. Genres are buckets from the GenreMap.
+type MixRules struct {
+	// MinDecadeShare is the smallest percentage of the night's songs each decade should have,
+	// e.g. {"2000s": 30}.
+	MinDecadeShare map[string]float64 `json:"min_decade_share,omitempty"`
+	// MaxGenrePerSet caps how many songs from a genre each set can have, e.g. {"country": 2}.
+	MaxGenrePerSet map[string]int `json:"max_genre_per_set,omitempty"`
+	// NoRepeatGenre keeps songs that share a genre from being played back to back.
+	NoRepeatGenre bool `json:"no_repeat_genre,omitempty"`
+	// ExcludeGenres leaves every song in these genres out of the build.
+	ExcludeGenres []string `json:"exclude_genres,omitempty"`
+	// MinFastShare is the energy target, the smallest percentage of the night's songs that are
+	// fast (FastBPM and up), e.g. 40 for a dance floor.
+	MinFastShare float64 `json:"min_fast_share,omitempty"`
+}
+
+func (m MixRules) Empty() bool {
+	return len(m.MinDecadeShare) == 0 && len(m.MaxGenrePerSet) == 0 && !m.NoRepeatGenre && len(m.ExcludeGenres) == 0 && m.MinFastShare == 0
+}
+
+// normalized returns a copy of the rules with genre names normalized, for rules typed in by hand.
+func (m MixRules) normalized() MixRules {
+	normalized := MixRules{NoRepeatGenre: m.NoRepeatGenre, MinFastShare: m.MinFastShare}
+	for decade, share := range m.MinDecadeShare {
+		if normalized.MinDecadeShare == nil {
+			normalized.MinDecadeShare = map[string]float64{}
+		}
+		normalized.MinDecadeShare[strings.ToLower(strings.TrimSpace(decade))] = share
+	}
+	for genre, limit := range m.MaxGenrePerSet {
+		if normalized.MaxGenrePerSet == nil {
+			normalized.MaxGenrePerSet = map[string]int{}
+		}
+		normalized.MaxGenrePerSet[NormalizeGenre(genre)] = limit
+	}
+	if len(m.ExcludeGenres) > 0 {
+		normalized.ExcludeGenres = ParseGenres(strings.Join(m.ExcludeGenres, ","))
+	}
+	return normalized
+}
+
+var decadePattern = regexp.MustCompile(`^\d{3}0s$`)
+
+// Check makes sure decades look like "1980s", shares are percentages that fit in one night and
+// genre limits aren't negative.
+func (m MixRules) Check() error {
+	if m.MinFastShare < 0 || m.MinFastShare > 100 {
+		return fmt.Errorf("the fast song share of %g%% must be between 0 and 100", m.MinFastShare)
+	}
+	total := 0.0
+	for decade, share := range m.MinDecadeShare {
+		if !decadePattern.MatchString(decade) {
+			return fmt.Errorf("invalid decade %s, please use the form 1980s", decade)
+		}
+		if share < 0 || share > 100 {
+			return fmt.Errorf("the %s share of %g%% must be between 0 and 100", decade, share)
+		}
+		total += share
+	}
+	if total > 100.001 {
+		return fmt.Errorf("decade shares add up to %g%%, which is more than 100%%", total)
+	}
+	for genre, limit := range m.MaxGenrePerSet {
+		if limit < 0 {
+			return fmt.Errorf("the %s limit of %d can't be negative", genre, limit)
+		}
+	}
+	return nil
+}
+
+// excluded returns the buckets that are excluded from the build.
+func (m MixRules) excluded(buckets []string) []string {
+	excluded := []string{}
+	for _, bucket := range buckets {
+		if slices.Contains(m.ExcludeGenres, bucket) {
+			excluded = append(excluded, bucket)
+		}
+	}
+	return excluded
+}
+
+// overLimit returns a bucket that already has its limit of songs in counts, or "".
+func (m MixRules) overLimit(buckets []string, counts map[string]int) string {
+	for _, bucket := range buckets {
+		if limit, ok := m.MaxGenrePerSet[bucket]; ok && counts[bucket] >= limit {
+			return bucket
+		}
+	}
+	return ""
+}
+
+// sharedBucket returns a bucket found in both lists, or "".
+func sharedBucket(a, b []string) string {
+	for _, bucket := range a {
+		if slices.Contains(b, bucket) {
+			return bucket
+		}
+	}
+	return ""
+}
+
+// ParseDecadeShares reads decade shares written as "2000s=30,1980s=20". A bare year like
+// "2000" is read as its decade.
+func ParseDecadeShares(input string) (map[string]float64, error) {
+	shares := map[string]float64{}
+	for _, pair := range strings.Split(input, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		decade, value, found := strings.Cut(pair, "=")
+		decade = strings.ToLower(strings.TrimSpace(decade))
+		if !strings.HasSuffix(decade, "s") {
+			decade = Decade(decade)
+		}
+		if !found || !decadePattern.MatchString(decade) {
+			return nil, fmt.Errorf("invalid decade share %q, please use decade=percent, e.g. 2000s=30", pair)
+		}
+		share, parseErr := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, "%")), 64)
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid percentage in %q", pair)
+		}
+		shares[decade] = share
+	}
+	return shares, nil
+}
+
+// ParseGenreLimits reads per set genre limits written as "country=2,metal=1".
+func ParseGenreLimits(input string) (map[string]int, error) {
+	limits := map[string]int{}
+	for _, pair := range strings.Split(input, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		genre, value, found := strings.Cut(pair, "=")
+		genre = NormalizeGenre(genre)
+		if !found || genre == "" {
+			return nil, fmt.Errorf("invalid genre limit %q, please use genre=songs, e.g. country=2", pair)
+		}
+		limit, parseErr := strconv.Atoi(strings.TrimSpace(value))
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid number of songs in %q", pair)
+		}
+		limits[genre] = limit
+	}
+	return limits, nil
+}
+
+// ParseGenres reads a comma separated list of genres.
+func ParseGenres(input string) []string {
+	genres := []string{}
+	for _, genre := range strings.Split(input, ",") {
+		if genre = NormalizeGenre(genre); genre != "" && !slices.Contains(genres, genre) {
+			genres = append(genres, genre)
+		}
+	}
+	return genres
+}
+
+// GenreExclusion is a song left out of a build because of its genre.
+type GenreExclusion struct {
+	Name   string   `json:"name"`
+	Artist string   `json:"artist"`
+	Genres []string `json:"genres"`
+}
+
+// excludeForGenres removes songs in excluded genres from the working table and returns them,
+// keyed by track ID.
+func excludeForGenres(ctx context.Context, dbQueries *database.Queries, params BuildParams, genres *GenreMap, tracks []database.Track) (map[int32]GenreExclusion, error) {
+	excluded := map[int32]GenreExclusion{}
+	if len(params.Mix.ExcludeGenres) == 0 {
+		return excluded, nil
+	}
+	for _, track := range tracks {
+		buckets := params.Mix.excluded(genres.Buckets(track.Genre))
+		if len(buckets) == 0 {
+			continue
+		}
+		if removeErr := dbQueries.RemoveFromWorking(ctx, track.ID); removeErr != nil {
+			return nil, fmt.Errorf("error removing %s from working table: %v", track.Name, removeErr)
+		}
+		excluded[track.ID] = GenreExclusion{Name: track.Name, Artist: track.Artist, Genres: buckets}
+	}
+	return excluded, nil
+}
+
+// decadeBehind reports whether another song from decade would help it toward its share, given
+// how many songs each decade has so far.
+func (m MixRules) decadeBehind(decade string, counts map[string]int, songs int) bool {
+	share, ok := m.MinDecadeShare[decade]
+	return ok && float64(counts[decade]) < share/100*float64(songs+1)
+}
+
+// decadeWarnings lists the decades that ended up short of their share, given how many songs
+// came from each.
+func (m MixRules) decadeWarnings(counts map[string]int, songs int) []string {
+	warnings := []string{}
+	decades := []string{}
+	for decade := range m.MinDecadeShare {
+		decades = append(decades, decade)
+	}
+	sort.Strings(decades)
+	for _, decade := range decades {
+		share := 0.0
+		if songs > 0 {
+			share = float64(counts[decade]) * 100 / float64(songs)
+		}
+		if share < m.MinDecadeShare[decade] {
+			warnings = append(warnings, fmt.Sprintf("only %.0f%% of the songs are from the %s, at least %g%% was wanted", share, decade, m.MinDecadeShare[decade]))
+		}
+	}
+	return warnings
+}
+
+// ParseFastShare reads the energy target, a percentage of fast songs like "40" or "40%".
+func ParseFastShare(input string) (float64, error) {
+	share, parseErr := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(input), "%")), 64)
+	if parseErr != nil || share < 0 || share > 100 {
+		return 0, fmt.Errorf("invalid fast song share %q, please use a percentage from 0 to 100", input)
+	}
+	return share, nil
+}
+
+// fastBehind reports whether another fast song would help toward the energy target, given how
+// many of the songs so far were fast.
+func (m MixRules) fastBehind(fast, songs int) bool {
+	return m.MinFastShare > 0 && float64(fast) < m.MinFastShare/100*float64(songs+1)
+}
+
+// fastWarning describes the night falling short of its energy target, or returns "".
+func (m MixRules) fastWarning(fast, songs int) string {
+	if m.MinFastShare == 0 {
+		return ""
+	}
+	share := 0.0
+	if songs > 0 {
+		share = float64(fast) * 100 / float64(songs)
+	}
+	if share >= m.MinFastShare {
+		return ""
+	}
+	return fmt.Sprintf("only %.0f%% of the songs are fast (%d BPM and up), at least %g%% was wanted", share, FastBPM, m.MinFastShare)
+}
+
+// SetGenreMap sets the genre buckets used when validating genre rules.
+func (l *Library) SetGenreMap(genres *GenreMap) {
+	l.genres = genres
+}
+
+// buckets returns the genre buckets for a library track, or nil if it isn't in the library.
+func (l *Library) buckets(name, artist string) []string {
+	track, found := l.Track(name, artist)
+	if !found {
+		return nil
+	}
+	return l.genres.Buckets(track.Genre)
+}
+
+// genreLimitViolations returns a violation for each genre with more songs in a set than its limit.
+func genreLimitViolations(mix MixRules, library *Library, set Set, i int) []Violation {
+	violations := []Violation{}
+	counts := map[string]int{}
+	for _, entry := range set.Entries {
+		for _, bucket := range library.buckets(entry.Name, entry.Artist) {
+			counts[bucket]++
+		}
+	}
+	genres := []string{}
+	for genre := range mix.MaxGenrePerSet {
+		genres = append(genres, genre)
+	}
+	sort.Strings(genres)
+	for _, genre := range genres {
+		if limit := mix.MaxGenrePerSet[genre]; counts[genre] > limit {
+			message := fmt.Sprintf("set has %d %s songs, the limit is %d", counts[genre], genre, limit)
+			violations = append(violations, Violation{Set: i, Position: -1, Rule: RuleGenreLimit, Message: message})
+		}
+	}
+	return violations
+}
+
+// decadeCounts counts the setlist's songs from each decade, using the library's years. Songs
+// missing from the library still count toward the total.
+func (l *Library) decadeCounts(setlist *Setlist) (map[string]int, int) {
+	counts := map[string]int{}
+	songs := 0
+	for _, set := range setlist.Sets {
+		for _, entry := range set.Entries {
+			songs++
+			if track, found := l.Track(entry.Name, entry.Artist); found {
+				counts[Decade(track.Year)]++
+			}
+		}
+	}
+	return counts, songs
+}
+
+// fastCount counts the setlist's fast songs, using the library's BPMs.
+func (l *Library) fastCount(setlist *Setlist) int {
+	fast := 0
+	for _, set := range setlist.Sets {
+		for _, entry := range set.Entries {
+			if track, found := l.Track(entry.Name, entry.Artist); found && track.Bpm >= FastBPM {
+				fast++
+			}
+		}
+	}
+	return fast
+}
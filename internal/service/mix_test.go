@@ -0,0 +1,166 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+func testGenreMap() *GenreMap {
+	return NewGenreMap([]database.GenreBucket{
+		{Genre: "country", Bucket: "country"},
+		{Genre: "rock", Bucket: "rock"},
+		{Genre: "pop", Bucket: "pop"},
+		{Genre: "dance pop", Bucket: "pop"},
+		{Genre: "hip hop", Bucket: "hip hop"},
+	})
+}
+
+func TestGenreBucket(t *testing.T) {
+	genres := testGenreMap()
+	tests := []struct {
+		genre  string
+		bucket string
+		mapped bool
+	}{
+		{"Country", "country", true},
+		{"contemporary country", "country", true},
+		{"country rock", "rock", true},
+		{"dance pop", "pop", true},
+		{"pop  rap", "pop", true},
+		{"southern hip hop", "hip hop", true},
+		{"poprock", "poprock", false},
+		{"yacht rock", "rock", true},
+		{"soft jazz", "soft jazz", false},
+	}
+	for _, test := range tests {
+		bucket, mapped := genres.Bucket(test.genre)
+		if bucket != test.bucket || mapped != test.mapped {
+			t.Errorf("Expected %q to be %q (mapped %v), got %q (mapped %v)", test.genre, test.bucket, test.mapped, bucket, mapped)
+		}
+	}
+	if buckets := genres.Buckets([]string{"pop", "dance pop", "country rock"}); !reflect.DeepEqual(buckets, []string{"pop", "rock"}) {
+		t.Errorf("Expected pop and rock once each, got %v", buckets)
+	}
+	var empty *GenreMap
+	if bucket, mapped := empty.Bucket("Dance Pop"); bucket != "dance pop" || mapped {
+		t.Errorf("Expected a nil map to keep the genre as its own bucket, got %q", bucket)
+	}
+}
+
+func TestParseMixRules(t *testing.T) {
+	shares, sharesErr := ParseDecadeShares("2000s=30, 1985=20%")
+	if sharesErr != nil || !reflect.DeepEqual(shares, map[string]float64{"2000s": 30, "1980s": 20}) {
+		t.Errorf("Expected 2000s at 30 and 1980s at 20, got %v (%v)", shares, sharesErr)
+	}
+	if _, badErr := ParseDecadeShares("noughties=30"); badErr == nil {
+		t.Error("Expected an error for a decade that isn't a year")
+	}
+	limits, limitsErr := ParseGenreLimits("Country=2,hip hop=1")
+	if limitsErr != nil || !reflect.DeepEqual(limits, map[string]int{"country": 2, "hip hop": 1}) {
+		t.Errorf("Expected country at 2 and hip hop at 1, got %v (%v)", limits, limitsErr)
+	}
+	if _, badErr := ParseGenreLimits("country"); badErr == nil {
+		t.Error("Expected an error for a genre without a limit")
+	}
+	if genres := ParseGenres(" Metal,, country ,metal"); !reflect.DeepEqual(genres, []string{"metal", "country"}) {
+		t.Errorf("Expected metal and country, got %v", genres)
+	}
+}
+
+func TestCheckMixRules(t *testing.T) {
+	if checkErr := (MixRules{MinDecadeShare: map[string]float64{"2000s": 60, "1990s": 50}}).Check(); checkErr == nil {
+		t.Error("Expected shares over 100% to be rejected")
+	}
+	if checkErr := (MixRules{MaxGenrePerSet: map[string]int{"country": -1}}).Check(); checkErr == nil {
+		t.Error("Expected a negative genre limit to be rejected")
+	}
+	if checkErr := (MixRules{MinDecadeShare: map[string]float64{"2000s": 30}, MaxGenrePerSet: map[string]int{"country": 0}}).Check(); checkErr != nil {
+		t.Errorf("unexpected error: %v", checkErr)
+	}
+}
+
+func TestDecadeShares(t *testing.T) {
+	mix := MixRules{MinDecadeShare: map[string]float64{"2000s": 30}}
+	if !mix.decadeBehind("2000s", map[string]int{"2000s": 0}, 2) {
+		t.Error("Expected the 2000s to be behind with none of three songs")
+	}
+	if mix.decadeBehind("2000s", map[string]int{"2000s": 1}, 2) {
+		t.Error("Expected the 2000s to be on track with one of three songs")
+	}
+	if mix.decadeBehind("1980s", map[string]int{}, 2) {
+		t.Error("Expected decades without a share never to be behind")
+	}
+	if warnings := mix.decadeWarnings(map[string]int{"2000s": 1}, 5); len(warnings) != 1 {
+		t.Errorf("Expected a warning for 20%% from the 2000s, got %v", warnings)
+	}
+	if warnings := mix.decadeWarnings(map[string]int{"2000s": 2}, 5); len(warnings) != 0 {
+		t.Errorf("Expected no warnings for 40%% from the 2000s, got %v", warnings)
+	}
+}
+
+func TestFastShare(t *testing.T) {
+	if share, parseErr := ParseFastShare(" 40% "); parseErr != nil || share != 40 {
+		t.Errorf("Expected 40, got %g (%v)", share, parseErr)
+	}
+	if _, parseErr := ParseFastShare("150"); parseErr == nil {
+		t.Error("Expected a share over 100% to be rejected")
+	}
+	mix := MixRules{MinFastShare: 50}
+	if !mix.fastBehind(0, 1) {
+		t.Error("Expected the night to be behind with none of two songs fast")
+	}
+	if mix.fastBehind(1, 1) {
+		t.Error("Expected the night to be on track with one of two songs fast")
+	}
+	if (MixRules{}).fastBehind(0, 10) {
+		t.Error("Expected a build without an energy target never to be behind")
+	}
+	if warning := mix.fastWarning(1, 4); warning == "" {
+		t.Error("Expected a warning for 25% fast songs")
+	}
+	if warning := mix.fastWarning(2, 4); warning != "" {
+		t.Errorf("Expected no warning for 50%% fast songs, got %s", warning)
+	}
+}
+
+func TestValidateMix(t *testing.T) {
+	library := NewLibrary([]database.GetTracksWithSingersRow{
+		{Name: "Wagon Wheel", Artist: "Darius Rucker", Genre: []string{"contemporary country"}, Year: "2013", DurationInSeconds: 240, Singer: "Riley", SingerKey: "A"},
+		{Name: "Friends In Low Places", Artist: "Garth Brooks", Genre: []string{"country"}, Year: "1990", DurationInSeconds: 240, Singer: "Ty", SingerKey: "A"},
+		{Name: "Africa", Artist: "Toto", Genre: []string{"soft rock"}, Year: "1982", DurationInSeconds: 240, Singer: "Riley", SingerKey: "B"},
+		{Name: "Hey Ya!", Artist: "OutKast", Genre: []string{"hip hop"}, Year: "2003", DurationInSeconds: 240, Singer: "Ty", SingerKey: "G"},
+	})
+	library.SetGenreMap(testGenreMap())
+	entries := []SetEntry{
+		{Name: "Wagon Wheel", Artist: "Darius Rucker", Singer: "Riley", Key: "A", DurationInSeconds: 240},
+		{Name: "Friends In Low Places", Artist: "Garth Brooks", Singer: "Ty", Key: "A", DurationInSeconds: 240},
+		{Name: "Africa", Artist: "Toto", Singer: "Riley", Key: "B", DurationInSeconds: 240},
+		{Name: "Hey Ya!", Artist: "OutKast", Singer: "Ty", Key: "G", DurationInSeconds: 240},
+	}
+	rules := func(mix MixRules) []Violation {
+		setlist := &Setlist{Params: BuildParams{Singers: []string{"Riley", "Ty"}, Mix: mix}, Sets: []Set{{Entries: entries}}}
+		return Validate(setlist, library)
+	}
+
+	if violations := rules(MixRules{}); len(violations) != 0 {
+		t.Errorf("Expected no violations without mix rules, got %v", violations)
+	}
+	violations := rules(MixRules{NoRepeatGenre: true})
+	if len(violations) != 1 || violations[0].Rule != RuleRepeatGenre || violations[0].Position != 1 {
+		t.Errorf("Expected the second country song to break the repeat genre rule, got %v", violations)
+	}
+	violations = rules(MixRules{MaxGenrePerSet: map[string]int{"country": 1}})
+	if len(violations) != 1 || violations[0].Rule != RuleGenreLimit || violations[0].Position != -1 {
+		t.Errorf("Expected the set to be over its country limit, got %v", violations)
+	}
+	violations = rules(MixRules{ExcludeGenres: []string{"hip hop"}})
+	if len(violations) != 1 || violations[0].Rule != RuleExcludedGenre || violations[0].Position != 3 {
+		t.Errorf("Expected Hey Ya! to be in an excluded genre, got %v", violations)
+	}
+	violations = rules(MixRules{MinDecadeShare: map[string]float64{"2000s": 25, "1970s": 10}})
+	if len(violations) != 1 || violations[0].Rule != RuleDecadeShare {
+		t.Errorf("Expected only the 1970s to be short of their share, got %v", violations)
+	}
+}
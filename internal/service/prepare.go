@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/constants"
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/sources"
+)
+
+// BuildInput holds the answers to the build questions before requests and DNPs have been
+// matched against the library.
+type BuildInput struct {
+	Duration      int32    `json:"duration"`
+	Singers       []string `json:"singers"`
+	AllowExplicit bool     `json:"allow_explicit"`
+	// ExplicitPolicy is allow, clean or exclude, and wins over AllowExplicit when given.
+	ExplicitPolicy string              `json:"explicit_policy,omitempty"`
+	Requests       []sources.Candidate `json:"requests"`
+	DoNotPlays     []sources.Candidate `json:"do_not_plays"`
+	// Lineup is the instruments and players booked, empty for the full band.
+	Lineup []string `json:"lineup,omitempty"`
+	// Mix limits the genres and decades played.
+	Mix MixRules `json:"mix"`
+	// Profile names an event profile whose settings fill in anything not given.
+	Profile string `json:"profile,omitempty"`
+	// StartTime, SongGap, HardStop and TimeWindows give the songs clock times, see BuildParams.
+	StartTime   string       `json:"start_time,omitempty"`
+	SongGap     int          `json:"song_gap_seconds,omitempty"`
+	HardStop    string       `json:"hard_stop,omitempty"`
+	TimeWindows []TimeWindow `json:"time_windows,omitempty"`
+}
+
+// LibraryDurationSeconds is the combined length of every track, the upper bound on how long a
+// gig the library can fill.
+func LibraryDurationSeconds(ctx context.Context, dbQueries *database.Queries) (int, error) {
+	tracks, tracksErr := dbQueries.GetAllTracks(ctx)
+	if tracksErr != nil {
+		return 0, fmt.Errorf("failed to get all tracks: %v", tracksErr)
+	}
+	total := 0
+	for _, track := range tracks {
+		total += int(track.DurationInSeconds)
+	}
+	return total, nil
+}
+
+// MatchTrack finds the library track for a request or DNP. Spotify names match exactly, other
+// sources fall back to a case-insensitive match, or a title-only match when no artist was given,
+// which fails when more than one artist has the title.
+func MatchTrack(ctx context.Context, dbQueries *database.Queries, candidate sources.Candidate) (database.Track, error) {
+	if candidate.Artist == "" {
+		return TrackFromName(ctx, dbQueries, candidate.Name)
+	}
+	params := database.GetTrackParams{
+		Name:   candidate.Name,
+		Artist: candidate.Artist,
+	}
+	track, getErr := dbQueries.GetTrack(ctx, params)
+	if getErr != sql.ErrNoRows {
+		return track, getErr
+	}
+	findParams := database.FindTrackParams{
+		Name:   candidate.Name,
+		Artist: candidate.Artist,
+	}
+	return dbQueries.FindTrack(ctx, findParams)
+}
+
+// ResolveRequests matches requests against the library and drops any that can't be played:
+// duplicates, songs the band doesn't know, explicit songs the explicit policy doesn't allow and
+// songs none of the singers have a key for. The reason each request was skipped is returned
+// with it.
+func ResolveRequests(ctx context.Context, dbQueries *database.Queries, candidates []sources.Candidate, singers []string, explicitPolicy string) (requests []database.Track, skipped []string) {
+	requests = []database.Track{}
+	for _, candidate := range candidates {
+		track, requestCheckErr := MatchTrack(ctx, dbQueries, candidate)
+		if requestCheckErr == sql.ErrNoRows {
+			skipped = append(skipped, fmt.Sprintf("Song %s was not found in the database, meaning it is not one of the songs that the band is able to perform", candidate.Name))
+			continue
+		} else if requestCheckErr != nil {
+			skipped = append(skipped, fmt.Sprintf("Unable to find track %s due to error: %v", candidate.Name, requestCheckErr))
+			continue
+		}
+		if slices.ContainsFunc(requests, func(request database.Track) bool { return request.ID == track.ID }) {
+			skipped = append(skipped, fmt.Sprintf("Request %s has already been added to the requests list", candidate.Name))
+			continue
+		}
+		explicit := track.Explicit || (candidate.ExplicitKnown && candidate.Explicit)
+		if !ExplicitAllowed(explicitPolicy, explicit, track.CleanVersion) {
+			if explicitPolicy == ExplicitClean {
+				skipped = append(skipped, fmt.Sprintf("Request %s has explicit lyrics and the band has no clean version of it", track.Name))
+			} else {
+				skipped = append(skipped, fmt.Sprintf("Request %s has explicit lyrics, and the 'No Explicit Lyrics' rule has been turned on", track.Name))
+			}
+			continue
+		}
+		comboParams := database.GetSingerCombosParams{
+			TrackID: track.ID,
+			Column2: singers,
+		}
+		combos, combosErr := dbQueries.GetSingerCombos(ctx, comboParams)
+		if combosErr != nil {
+			skipped = append(skipped, fmt.Sprintf("unable to get singer/key combo for %s: %v", track.Name, combosErr))
+			continue
+		}
+		if len(combos) == 0 {
+			skipped = append(skipped, fmt.Sprintf("No valid singers found for track %s", track.Name))
+			continue
+		}
+		requests = append(requests, track)
+	}
+	return requests, skipped
+}
+
+// ResolveDoNotPlays returns the names of DNPs and the library tracks they matched. DNPs don't
+// have to be in the library, those get an ID of 0.
+func ResolveDoNotPlays(ctx context.Context, dbQueries *database.Queries, candidates []sources.Candidate) (doNotPlays []string, ids []int32) {
+	doNotPlays = []string{}
+	ids = []int32{}
+	for _, candidate := range candidates {
+		track, matchErr := MatchTrack(ctx, dbQueries, candidate)
+		if matchErr == nil {
+			doNotPlays = append(doNotPlays, track.Name)
+			ids = append(ids, track.ID)
+		} else {
+			doNotPlays = append(doNotPlays, candidate.Name)
+			ids = append(ids, 0)
+		}
+	}
+	return doNotPlays, ids
+}
+
+// Prepare turns build answers into build parameters without asking any questions, for callers
+// like the API server. Songs in both the requests and DNP lists are treated as DNPs.
+func (b *Builder) Prepare(ctx context.Context, input BuildInput) (BuildParams, []string, error) {
+	dbQueries := database.New(b.db)
+	warnings := []string{}
+	params := BuildParams{
+		Requests:      []string{},
+		DoNotPlays:    []string{},
+		Duration:      input.Duration,
+		AllowExplicit: input.AllowExplicit,
+	}
+	if input.ExplicitPolicy != "" {
+		policy, policyErr := ParseExplicitPolicy(input.ExplicitPolicy)
+		if policyErr != nil {
+			return params, nil, policyErr
+		}
+		params.SetExplicitPolicy(policy)
+	}
+	var profile *Profile
+	if input.Profile != "" {
+		var profileErr error
+		profile, profileErr = LoadProfile(ctx, dbQueries, input.Profile)
+		if profileErr == sql.ErrNoRows {
+			return params, nil, fmt.Errorf("no profile named %s", NormalizeProfileName(input.Profile))
+		} else if profileErr != nil {
+			return params, nil, profileErr
+		}
+		if params.Duration == 0 {
+			params.Duration = profile.Duration
+		}
+		if len(input.Singers) == 0 {
+			input.Singers = profile.Singers
+		}
+	}
+	if params.Duration <= 0 {
+		return params, nil, fmt.Errorf("duration must be a positive number of minutes")
+	}
+	if params.Duration > MaxDuration {
+		warnings = append(warnings, fmt.Sprintf("Maximum duration per the band's contract is 3 hours (%d minutes) including breaks, duration has been set to %d minutes", MaxDuration, MaxDuration))
+		params.Duration = MaxDuration
+	}
+	libraryDuration, libraryErr := LibraryDurationSeconds(ctx, dbQueries)
+	if libraryErr != nil {
+		return params, nil, libraryErr
+	}
+	if libraryDuration < int(params.Duration)*60 {
+		return params, nil, fmt.Errorf("set duration exceeds total duration of all songs in database, please add more songs before attempting to build a setlist this long")
+	}
+
+	if len(input.Singers) == 0 {
+		return params, nil, fmt.Errorf("must have at least one singer")
+	}
+	for _, singer := range input.Singers {
+		singer = strings.TrimSpace(strings.ToLower(singer))
+		if !slices.Contains(constants.ValidSingers, singer) {
+			return params, nil, fmt.Errorf("invalid singer %s, please choose from: %s", singer, strings.Join(constants.ValidSingers, ", "))
+		}
+		singer = constants.Capitalize(singer)
+		if !slices.Contains(params.Singers, singer) {
+			params.Singers = append(params.Singers, singer)
+		}
+	}
+
+	for _, instrument := range input.Lineup {
+		instrument = NormalizeInstrument(instrument)
+		if instrument != "" && !slices.Contains(params.Lineup, instrument) {
+			params.Lineup = append(params.Lineup, instrument)
+		}
+	}
+
+	params.Mix = input.Mix.normalized()
+	if profile != nil {
+		profile.Apply(&params)
+	}
+	if mixErr := params.Mix.Check(); mixErr != nil {
+		return params, nil, mixErr
+	}
+	if setsErr := params.CheckSets(); setsErr != nil {
+		return params, nil, setsErr
+	}
+	params.StartTime = input.StartTime
+	params.SongGap = input.SongGap
+	params.HardStop = input.HardStop
+	params.TimeWindows = input.TimeWindows
+	if scheduleErr := params.CheckSchedule(); scheduleErr != nil {
+		return params, nil, scheduleErr
+	}
+
+	requests, skipped := ResolveRequests(ctx, dbQueries, input.Requests, params.Singers, params.Explicit())
+	warnings = append(warnings, skipped...)
+	for _, track := range requests {
+		params.AddRequest(track.Name, track.ID)
+	}
+	doNotPlays, ids := ResolveDoNotPlays(ctx, dbQueries, input.DoNotPlays)
+	for i, dnp := range doNotPlays {
+		params.AddDoNotPlay(dnp, ids[i])
+	}
+	for _, contradiction := range params.Contradictions() {
+		warnings = append(warnings, fmt.Sprintf("%s is in both the 'Requests' and the 'Do Not Play' lists, it has been treated as a 'Do Not Play'", contradiction))
+		params.RemoveRequest(contradiction)
+	}
+	return params, warnings, nil
+}
+
+// BuildFromInput prepares and builds a setlist in one step. Warnings from preparing the build
+// are included in the setlist's warnings.
+func (b *Builder) BuildFromInput(ctx context.Context, input BuildInput) (*Setlist, error) {
+	params, warnings, prepareErr := b.Prepare(ctx, input)
+	if prepareErr != nil {
+		return nil, prepareErr
+	}
+	setlist, buildErr := b.Build(ctx, params)
+	if buildErr != nil {
+		return nil, buildErr
+	}
+	setlist.Warnings = append(warnings, setlist.Warnings...)
+	return setlist, nil
+}
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/constants"
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+// Profile is a named set of build settings for a type of event, like a wedding or a bar gig.
+// Anything left at its zero value is asked for or left to the build's defaults, and anything
+// given on the command line wins over the profile.
+type Profile struct {
+	Name        string `json:"-"`
+	Description string `json:"description,omitempty"`
+	// Duration is the default gig length in minutes, 0 asks for it.
+	Duration int32 `json:"duration,omitempty"`
+	Sets     int   `json:"sets,omitempty"`
+	// ExplicitPolicy is the event's explicit lyrics policy, blank asks for it at each build.
+	// Profiles saved before there was a clean edit policy only have AllowExplicit.
+	ExplicitPolicy  string             `json:"explicit_policy,omitempty"`
+	AllowExplicit   *bool              `json:"allow_explicit,omitempty"`
+	Singers         []string           `json:"singers,omitempty"`
+	SingerTargets   map[string]float64 `json:"singer_targets,omitempty"`
+	TargetTolerance float64            `json:"target_tolerance,omitempty"`
+	Lineup          []string           `json:"lineup,omitempty"`
+	Mix             MixRules           `json:"mix"`
+	RequestSpacing  int                `json:"request_spacing,omitempty"`
+}
+
+// ProfileFields are the settings a profile can change, in the order they are shown.
+var ProfileFields = []string{
+	"description", "duration", "sets", "explicit", "singers", "targets", "tolerance", "lineup",
+	"request-spacing", "min-decade", "max-genre", "no-repeat-genre", "exclude-genres", "min-fast",
+}
+
+// NormalizeProfileName is how profile names are stored, so "Wedding" and "wedding" match.
+func NormalizeProfileName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func profileFromRow(row database.Profile) (*Profile, error) {
+	profile := &Profile{}
+	if unmarshalErr := json.Unmarshal(row.Settings, profile); unmarshalErr != nil {
+		return nil, fmt.Errorf("unable to read the %s profile: %v", row.Name, unmarshalErr)
+	}
+	profile.Name = row.Name
+	return profile, nil
+}
+
+// LoadProfile returns the profile with the given name, or sql.ErrNoRows if there isn't one.
+func LoadProfile(ctx context.Context, dbQueries *database.Queries, name string) (*Profile, error) {
+	row, getErr := dbQueries.GetProfile(ctx, NormalizeProfileName(name))
+	if getErr == sql.ErrNoRows {
+		return nil, getErr
+	} else if getErr != nil {
+		return nil, fmt.Errorf("unable to get the %s profile: %v", name, getErr)
+	}
+	return profileFromRow(row)
+}
+
+func LoadProfiles(ctx context.Context, dbQueries *database.Queries) ([]*Profile, error) {
+	rows, getErr := dbQueries.GetAllProfiles(ctx)
+	if getErr != nil {
+		return nil, fmt.Errorf("unable to get profiles: %v", getErr)
+	}
+	profiles := []*Profile{}
+	for _, row := range rows {
+		profile, readErr := profileFromRow(row)
+		if readErr != nil {
+			return nil, readErr
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+// SaveProfile checks a profile and creates or replaces it.
+func SaveProfile(ctx context.Context, dbQueries *database.Queries, profile *Profile) error {
+	profile.Name = NormalizeProfileName(profile.Name)
+	if profile.Name == "" {
+		return fmt.Errorf("profile name can't be blank")
+	}
+	if checkErr := profile.Check(); checkErr != nil {
+		return checkErr
+	}
+	settings, marshalErr := json.Marshal(profile)
+	if marshalErr != nil {
+		return fmt.Errorf("unable to save the %s profile: %v", profile.Name, marshalErr)
+	}
+	params := database.UpsertProfileParams{
+		Name:     profile.Name,
+		Settings: settings,
+	}
+	if upsertErr := dbQueries.UpsertProfile(ctx, params); upsertErr != nil {
+		return fmt.Errorf("unable to save the %s profile: %v", profile.Name, upsertErr)
+	}
+	return nil
+}
+
+// Check makes sure the profile's settings would make a valid build.
+func (p *Profile) Check() error {
+	if p.Duration < 0 || p.Duration > MaxDuration {
+		return fmt.Errorf("duration must be between 1 and %d minutes", MaxDuration)
+	}
+	params := BuildParams{
+		Duration:        p.Duration,
+		Singers:         p.Singers,
+		SingerTargets:   p.SingerTargets,
+		TargetTolerance: p.TargetTolerance,
+		Sets:            p.Sets,
+		RequestSpacing:  p.RequestSpacing,
+	}
+	if setsErr := params.CheckSets(); setsErr != nil {
+		return setsErr
+	}
+	// targets can only be checked against singers once the singers are known
+	if len(p.Singers) > 0 {
+		if targetsErr := params.CheckTargets(); targetsErr != nil {
+			return targetsErr
+		}
+	}
+	return p.Mix.Check()
+}
+
+// Apply fills in the build settings the params don't already have. The profile's explicit
+// policy always applies, so a corporate profile stays clean.
+func (p *Profile) Apply(params *BuildParams) {
+	params.Profile = p.Name
+	if params.Duration == 0 {
+		params.Duration = p.Duration
+	}
+	if len(params.Singers) == 0 {
+		params.Singers = slices.Clone(p.Singers)
+	}
+	if policy := p.Explicit(); policy != "" {
+		params.SetExplicitPolicy(policy)
+	}
+	if params.Sets == 0 {
+		params.Sets = p.Sets
+	}
+	if len(params.SingerTargets) == 0 && len(p.SingerTargets) > 0 {
+		params.SingerTargets = map[string]float64{}
+		for singer, target := range p.SingerTargets {
+			params.SingerTargets[singer] = target
+		}
+		params.TargetTolerance = p.TargetTolerance
+	}
+	if len(params.Lineup) == 0 {
+		params.Lineup = slices.Clone(p.Lineup)
+	}
+	if params.Mix.Empty() {
+		params.Mix = p.Mix.normalized()
+	}
+	if params.RequestSpacing == 0 {
+		params.RequestSpacing = p.RequestSpacing
+	}
+}
+
+// Explicit returns the profile's explicit lyrics policy, or "" when it's asked at each build.
+func (p *Profile) Explicit() string {
+	if p.ExplicitPolicy != "" {
+		return p.ExplicitPolicy
+	}
+	if p.AllowExplicit == nil {
+		return ""
+	} else if *p.AllowExplicit {
+		return ExplicitAllow
+	}
+	return ExplicitExclude
+}
+
+// Field returns a profile setting as text, in the form Set reads it.
+func (p *Profile) Field(field string) string {
+	switch field {
+	case "description":
+		return p.Description
+	case "duration":
+		if p.Duration == 0 {
+			return ""
+		}
+		return strconv.Itoa(int(p.Duration))
+	case "sets":
+		if p.Sets == 0 {
+			return ""
+		}
+		return strconv.Itoa(p.Sets)
+	case "explicit":
+		return p.Explicit()
+	case "singers":
+		return strings.Join(p.Singers, ",")
+	case "targets":
+		return formatShares(p.SingerTargets)
+	case "tolerance":
+		if p.TargetTolerance == 0 {
+			return ""
+		}
+		return strconv.FormatFloat(p.TargetTolerance, 'g', -1, 64)
+	case "lineup":
+		return strings.Join(p.Lineup, ",")
+	case "request-spacing":
+		if p.RequestSpacing == 0 {
+			return ""
+		}
+		return strconv.Itoa(p.RequestSpacing)
+	case "min-decade":
+		return formatShares(p.Mix.MinDecadeShare)
+	case "max-genre":
+		limits := map[string]float64{}
+		for genre, limit := range p.Mix.MaxGenrePerSet {
+			limits[genre] = float64(limit)
+		}
+		return formatShares(limits)
+	case "no-repeat-genre":
+		if !p.Mix.NoRepeatGenre {
+			return ""
+		}
+		return "true"
+	case "exclude-genres":
+		return strings.Join(p.Mix.ExcludeGenres, ",")
+	case "min-fast":
+		if p.Mix.MinFastShare == 0 {
+			return ""
+		}
+		return strconv.FormatFloat(p.Mix.MinFastShare, 'g', -1, 64)
+	}
+	return ""
+}
+
+// formatShares writes a map as "key=value" pairs sorted by key, the form the parsers read.
+func formatShares(shares map[string]float64) string {
+	keys := []string{}
+	for key := range shares {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := []string{}
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+strconv.FormatFloat(shares[key], 'g', -1, 64))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Set validates value and stores it in the given profile setting. A blank value clears it.
+func (p *Profile) Set(field, value string) error {
+	value = strings.TrimSpace(value)
+	number := func() (int, error) {
+		if value == "" {
+			return 0, nil
+		}
+		n, convErr := strconv.Atoi(value)
+		if convErr != nil || n < 0 {
+			return 0, fmt.Errorf("invalid %s %q, please use a whole number", field, value)
+		}
+		return n, nil
+	}
+	switch field {
+	case "description":
+		p.Description = value
+	case "duration":
+		minutes, numberErr := number()
+		if numberErr != nil {
+			return numberErr
+		}
+		p.Duration = int32(minutes)
+	case "sets":
+		sets, numberErr := number()
+		if numberErr != nil {
+			return numberErr
+		}
+		p.Sets = sets
+	case "explicit":
+		p.AllowExplicit = nil
+		p.ExplicitPolicy = ""
+		if value == "" {
+			return nil
+		}
+		policy, parseErr := ParseExplicitPolicy(value)
+		if parseErr != nil {
+			return fmt.Errorf("invalid explicit policy %q, please use allow, clean, exclude or leave it blank to ask", value)
+		}
+		p.ExplicitPolicy = policy
+	case "singers":
+		singers := []string{}
+		for _, singer := range strings.Split(value, ",") {
+			singer = strings.TrimSpace(strings.ToLower(singer))
+			if singer == "" {
+				continue
+			}
+			if !slices.Contains(constants.ValidSingers, singer) {
+				return fmt.Errorf("invalid singer %s, please choose from: %s", singer, strings.Join(constants.ValidSingers, ", "))
+			}
+			if singer = constants.Capitalize(singer); !slices.Contains(singers, singer) {
+				singers = append(singers, singer)
+			}
+		}
+		p.Singers = singers
+	case "targets":
+		targets, parseErr := ParseSingerTargets(value)
+		if parseErr != nil {
+			return parseErr
+		}
+		p.SingerTargets = targets
+	case "tolerance":
+		if value == "" {
+			p.TargetTolerance = 0
+			return nil
+		}
+		tolerance, parseErr := strconv.ParseFloat(value, 64)
+		if parseErr != nil || tolerance < 0 {
+			return fmt.Errorf("invalid tolerance %q, please use a number of percentage points", value)
+		}
+		p.TargetTolerance = tolerance
+	case "lineup":
+		p.Lineup = ParseInstruments(value)
+	case "request-spacing":
+		spacing, numberErr := number()
+		if numberErr != nil {
+			return numberErr
+		}
+		p.RequestSpacing = spacing
+	case "min-decade":
+		shares, parseErr := ParseDecadeShares(value)
+		if parseErr != nil {
+			return parseErr
+		}
+		p.Mix.MinDecadeShare = shares
+	case "max-genre":
+		limits, parseErr := ParseGenreLimits(value)
+		if parseErr != nil {
+			return parseErr
+		}
+		p.Mix.MaxGenrePerSet = limits
+	case "no-repeat-genre":
+		switch strings.ToLower(value) {
+		case "true", "yes", "y":
+			p.Mix.NoRepeatGenre = true
+		case "", "false", "no", "n":
+			p.Mix.NoRepeatGenre = false
+		default:
+			return fmt.Errorf("invalid no-repeat-genre value %q, please use true or false", value)
+		}
+	case "exclude-genres":
+		p.Mix.ExcludeGenres = ParseGenres(value)
+	case "min-fast":
+		if value == "" {
+			p.Mix.MinFastShare = 0
+			return nil
+		}
+		share, parseErr := ParseFastShare(value)
+		if parseErr != nil {
+			return parseErr
+		}
+		p.Mix.MinFastShare = share
+	default:
+		return fmt.Errorf("unknown profile setting %s", field)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProfileSettings(t *testing.T) {
+	profile := &Profile{Name: "wedding"}
+	settings := map[string]string{
+		"duration":        "150",
+		"sets":            "3",
+		"explicit":        "clean",
+		"singers":         "riley, TY",
+		"targets":         "Riley=60,Ty=40",
+		"request-spacing": "1",
+		"min-decade":      "2000s=30",
+		"max-genre":       "Country=2",
+		"no-repeat-genre": "true",
+		"exclude-genres":  "metal",
+		"min-fast":        "40%",
+	}
+	for field, value := range settings {
+		if setErr := profile.Set(field, value); setErr != nil {
+			t.Fatalf("unexpected error setting %s: %v", field, setErr)
+		}
+	}
+	if checkErr := profile.Check(); checkErr != nil {
+		t.Errorf("unexpected error: %v", checkErr)
+	}
+	expected := map[string]string{
+		"duration":        "150",
+		"sets":            "3",
+		"explicit":        "clean",
+		"singers":         "Riley,Ty",
+		"targets":         "Riley=60,Ty=40",
+		"request-spacing": "1",
+		"min-decade":      "2000s=30",
+		"max-genre":       "country=2",
+		"no-repeat-genre": "true",
+		"exclude-genres":  "metal",
+		"min-fast":        "40",
+	}
+	for field, value := range expected {
+		if got := profile.Field(field); got != value {
+			t.Errorf("Expected %s to be %q, got %q", field, value, got)
+		}
+	}
+
+	if setErr := profile.Set("explicit", ""); setErr != nil || profile.AllowExplicit != nil {
+		t.Errorf("Expected a blank explicit policy to be asked at each build, got %v (%v)", profile.AllowExplicit, setErr)
+	}
+	if setErr := profile.Set("singers", "nobody"); setErr == nil {
+		t.Error("Expected an error for a singer who isn't in the band")
+	}
+	if setErr := profile.Set("sets", "4"); setErr != nil || profile.Check() == nil {
+		t.Error("Expected four sets to fail the profile check")
+	}
+}
+
+func TestProfileApply(t *testing.T) {
+	clean := false
+	profile := &Profile{
+		Name:           "corporate",
+		Duration:       120,
+		Sets:           1,
+		AllowExplicit:  &clean,
+		Singers:        []string{"Riley", "Ty"},
+		Lineup:         []string{"guitar", "keys"},
+		Mix:            MixRules{NoRepeatGenre: true},
+		RequestSpacing: 2,
+	}
+	params := BuildParams{Duration: 90, AllowExplicit: true, Lineup: []string{"guitar"}}
+	profile.Apply(&params)
+	if params.Profile != "corporate" || params.Duration != 90 || params.AllowExplicit {
+		t.Errorf("Expected the given duration to stay and the clean policy to apply, got %+v", params)
+	}
+	if !reflect.DeepEqual(params.Singers, []string{"Riley", "Ty"}) || !reflect.DeepEqual(params.Lineup, []string{"guitar"}) {
+		t.Errorf("Expected the profile's singers and the given lineup, got %v and %v", params.Singers, params.Lineup)
+	}
+	if params.Sets != 1 || params.RequestSpacing != 2 || !params.Mix.NoRepeatGenre {
+		t.Errorf("Expected the profile's set structure and mix rules, got %+v", params)
+	}
+	params.Singers[0] = "Bos"
+	if profile.Singers[0] != "Riley" {
+		t.Error("Expected applying a profile not to share its singers with the params")
+	}
+}
+
+func TestSetStructure(t *testing.T) {
+	params := BuildParams{Duration: 150}
+	if lengths := params.SetLengths(); !reflect.DeepEqual(lengths, []int32{65, 65}) {
+		t.Errorf("Expected the duration to decide on two sets, got %v", lengths)
+	}
+	params.Sets = 3
+	if lengths := params.SetLengths(); !reflect.DeepEqual(lengths, []int32{40, 40, 40}) {
+		t.Errorf("Expected three sets around two 15 minute breaks, got %v", lengths)
+	}
+	params.Sets = 1
+	if lengths := params.SetLengths(); !reflect.DeepEqual(lengths, []int32{150}) {
+		t.Errorf("Expected one long set, got %v", lengths)
+	}
+	params = BuildParams{Duration: 20, Sets: 3}
+	if setsErr := params.CheckSets(); setsErr == nil {
+		t.Errorf("Expected a 20 minute gig to be too short for three sets, got %v", params.SetLengths())
+	}
+	params = BuildParams{Duration: 33, Sets: 3}
+	if setsErr := params.CheckSets(); setsErr != nil {
+		t.Errorf("unexpected error: %v", setsErr)
+	}
+	if params.Spacing() != DefaultRequestSpacing {
+		t.Errorf("Expected the default request spacing, got %d", params.Spacing())
+	}
+}
@@ -0,0 +1,624 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+// Rule names used in violations.
+const (
+	RuleDoNotPlay     = "do-not-play"
+	RuleRepeatSong    = "repeat-song"
+	RuleRepeatArtist  = "repeat-artist"
+	RuleExplicit      = "explicit"
+	RuleSinger        = "singer"
+	RuleRepeatKey     = "repeat-key"
+	RuleRepeatSinger  = "repeat-singer"
+	RuleSetLength     = "set-length"
+	RuleSingerShare   = "singer-share"
+	RuleLineup        = "lineup"
+	RuleExcludedGenre = "excluded-genre"
+	RuleRepeatGenre   = "repeat-genre"
+	RuleGenreLimit    = "genre-limit"
+	RuleDecadeShare   = "decade-share"
+	RuleEnergy        = "energy"
+	RuleTimeWindow    = "time-window"
+	RuleHardStop      = "hard-stop"
+)
+
+// SetOverrunSeconds is how far past its target a set may run, and SetMarginSeconds is how far
+// short of it a build stops filling a set.
+const (
+	SetOverrunSeconds = 300
+	SetMarginSeconds  = 180
+)
+
+// Violation is a band rule broken by a setlist. Set and Position are zero-based, and Position
+// is -1 when the rule applies to the whole set.
+type Violation struct {
+	Set      int    `json:"set"`
+	Position int    `json:"position"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Position < 0 {
+		return fmt.Sprintf("Set %d: %s", v.Set+1, v.Message)
+	}
+	return fmt.Sprintf("Set %d, song %d: %s", v.Set+1, v.Position+1, v.Message)
+}
+
+// LibraryTrack is a track along with the key each singer sings it in.
+type LibraryTrack struct {
+	ID                int32             `json:"id"`
+	Name              string            `json:"name"`
+	Artist            string            `json:"artist"`
+	Genre             []string          `json:"genre"`
+	DurationInSeconds int32             `json:"duration_in_seconds"`
+	Year              string            `json:"year"`
+	Explicit          bool              `json:"explicit"`
+	Bpm               int32             `json:"bpm"`
+	OriginalKey       string            `json:"original_key"`
+	CleanVersion      bool              `json:"clean_version"`
+	Keys              map[string]string `json:"keys"`
+	// Parts are the partners on each lead singer's arrangement, for duets.
+	Parts map[string][]Partner `json:"parts,omitempty"`
+	// Requirements are the instruments or players the song can't be played without.
+	Requirements []string `json:"requirements,omitempty"`
+}
+
+// Library is an in-memory copy of every track that has singers, used to check and edit a
+// setlist without going through the working table.
+type Library struct {
+	Tracks []*LibraryTrack
+	byID   map[string]*LibraryTrack
+	genres *GenreMap
+}
+
+func trackID(name, artist string) string {
+	return name + "\x00" + artist
+}
+
+func NewLibrary(rows []database.GetTracksWithSingersRow) *Library {
+	library := &Library{byID: map[string]*LibraryTrack{}}
+	for _, row := range rows {
+		track, found := library.byID[trackID(row.Name, row.Artist)]
+		if !found {
+			track = &LibraryTrack{
+				ID:                row.ID,
+				Name:              row.Name,
+				Artist:            row.Artist,
+				Genre:             row.Genre,
+				DurationInSeconds: row.DurationInSeconds,
+				Year:              row.Year,
+				Explicit:          row.Explicit,
+				Bpm:               row.Bpm,
+				OriginalKey:       row.OriginalKey,
+				CleanVersion:      row.CleanVersion,
+				Keys:              map[string]string{},
+			}
+			library.byID[trackID(row.Name, row.Artist)] = track
+			library.Tracks = append(library.Tracks, track)
+		}
+		track.Keys[row.Singer] = row.SingerKey
+	}
+	return library
+}
+
+func LoadLibrary(ctx context.Context, dbQueries *database.Queries) (*Library, error) {
+	rows, getErr := dbQueries.GetTracksWithSingers(ctx)
+	if getErr != nil {
+		return nil, fmt.Errorf("unable to get tracks with singers: %v", getErr)
+	}
+	parts, partsErr := dbQueries.GetAllSingerParts(ctx)
+	if partsErr != nil {
+		return nil, fmt.Errorf("unable to get duet parts: %v", partsErr)
+	}
+	requirements, requirementsErr := dbQueries.GetAllTrackRequirements(ctx)
+	if requirementsErr != nil {
+		return nil, fmt.Errorf("unable to get track requirements: %v", requirementsErr)
+	}
+	genres, genresErr := LoadGenreMap(ctx, dbQueries)
+	if genresErr != nil {
+		return nil, genresErr
+	}
+	library := NewLibrary(rows)
+	library.AddParts(parts)
+	library.AddRequirements(requirements)
+	library.SetGenreMap(genres)
+	return library, nil
+}
+
+func (l *Library) Track(name, artist string) (*LibraryTrack, bool) {
+	track, found := l.byID[trackID(name, artist)]
+	return track, found
+}
+
+// Entry returns the set entry for this track with singer as the lead, if the singer has a key
+// for it. Duets include their partners, and explicit songs are marked clean under the clean
+// edit policy.
+func (t *LibraryTrack) Entry(singer, policy string) (SetEntry, bool) {
+	key, found := t.Keys[singer]
+	if !found {
+		return SetEntry{}, false
+	}
+	return SetEntry{
+		Name:              t.Name,
+		Artist:            t.Artist,
+		Singer:            singer,
+		Partners:          slices.Clone(t.Parts[singer]),
+		Key:               key,
+		DurationInSeconds: t.DurationInSeconds,
+		Explicit:          t.Explicit,
+		Clean:             t.Explicit && policy == ExplicitClean,
+	}, true
+}
+
+// checkPosition returns the rules broken by the entry at position j of a set, looking only at
+// the entries before it. Songs repeated across sets and set lengths are checked by Validate.
+func checkPosition(setlist *Setlist, library *Library, entries []SetEntry, j int) []Violation {
+	entry := entries[j]
+	params := setlist.Params
+	var violations []Violation
+	add := func(rule, message string, args ...any) {
+		violations = append(violations, Violation{Position: j, Rule: rule, Message: fmt.Sprintf(message, args...)})
+	}
+
+	var id int32
+	if library != nil {
+		if track, found := library.Track(entry.Name, entry.Artist); found {
+			id = track.ID
+		}
+	}
+	if params.isDoNotPlay(entry.Name, id) {
+		add(RuleDoNotPlay, "%s is on the 'Do Not Play' list", entry.Name)
+	}
+	cleanVersion := false
+	if library != nil {
+		if track, found := library.Track(entry.Name, entry.Artist); found {
+			cleanVersion = track.CleanVersion
+		}
+	}
+	if policy := params.Explicit(); !ExplicitAllowed(policy, entry.Explicit, cleanVersion) {
+		if policy == ExplicitClean {
+			add(RuleExplicit, "%s has explicit lyrics and no clean version", entry.Name)
+		} else {
+			add(RuleExplicit, "%s has explicit lyrics", entry.Name)
+		}
+	} else if policy == ExplicitClean && entry.Explicit && !entry.Clean {
+		add(RuleExplicit, "%s has explicit lyrics and isn't marked as a clean edit", entry.Name)
+	}
+	for _, singer := range missingSingers(entry, params.Singers) {
+		add(RuleSinger, "%s is not one of the singers for this gig", singer)
+	}
+	if slices.Contains(params.Singers, entry.Singer) && library != nil {
+		if track, found := library.Track(entry.Name, entry.Artist); !found || track.Keys[entry.Singer] == "" {
+			add(RuleSinger, "%s does not have a key for %s", entry.Singer, entry.Name)
+		} else if !strings.EqualFold(track.Keys[entry.Singer], entry.Key) {
+			add(RuleSinger, "%s sings %s in %s, not %s", entry.Singer, entry.Name, track.Keys[entry.Singer], entry.Key)
+		} else if parts := track.Parts[entry.Singer]; !samePartners(parts, entry.Partners) {
+			if len(parts) == 0 {
+				add(RuleSinger, "%s sings %s without a partner, not with %s", entry.Singer, entry.Name, partnerNames(entry.Partners))
+			} else {
+				add(RuleSinger, "%s sings %s with %s", entry.Singer, entry.Name, partnerNames(parts))
+			}
+		}
+	}
+	if library != nil {
+		if track, found := library.Track(entry.Name, entry.Artist); found {
+			if missing := params.MissingPlayers(track.Requirements); len(missing) > 0 {
+				add(RuleLineup, "%s needs %s, who isn't on this gig", entry.Name, strings.Join(missing, " & "))
+			}
+		}
+		buckets := library.buckets(entry.Name, entry.Artist)
+		if excluded := params.Mix.excluded(buckets); len(excluded) > 0 {
+			add(RuleExcludedGenre, "%s is %s, which is excluded from this gig", entry.Name, strings.Join(excluded, " & "))
+		}
+		if params.Mix.NoRepeatGenre && j >= 1 {
+			if genre := sharedBucket(buckets, library.buckets(entries[j-1].Name, entries[j-1].Artist)); genre != "" {
+				add(RuleRepeatGenre, "another %s song was just played", genre)
+			}
+		}
+	}
+	for k := 0; k < j; k++ {
+		if entry.Artist != "" && entries[k].Artist == entry.Artist {
+			add(RuleRepeatArtist, "artist %s is already used in this set", entry.Artist)
+			break
+		}
+	}
+	if j >= 2 && entry.Key == entries[j-1].Key && entry.Key == entries[j-2].Key {
+		add(RuleRepeatKey, "same key (%s) as the last two songs", entry.Key)
+	}
+	if len(params.Singers) != 1 && !setlist.SingerRuleIgnored && j >= 2 {
+		if repeated := repeatedSinger(entry, entries[j-1].Vocalists(), entries[j-2].Vocalists()); repeated != "" {
+			add(RuleRepeatSinger, "same singer (%s) as the last two songs", repeated)
+		}
+	}
+	return violations
+}
+
+// Validate checks a setlist against the band rules used when building it. The library is
+// optional; without it singers aren't checked for having a key for their songs.
+func Validate(setlist *Setlist, library *Library) []Violation {
+	violations := []Violation{}
+	seen := []SetEntry{}
+	for i, set := range setlist.Sets {
+		for j, entry := range set.Entries {
+			for _, violation := range checkPosition(setlist, library, set.Entries, j) {
+				violation.Set = i
+				violations = append(violations, violation)
+			}
+			if slices.ContainsFunc(seen, func(other SetEntry) bool { return sameSong(other.Name, other.Artist, entry.Name, entry.Artist) }) {
+				violations = append(violations, Violation{Set: i, Position: j, Rule: RuleRepeatSong, Message: fmt.Sprintf("%s is already in the setlist", entry.Name)})
+			}
+			seen = append(seen, entry)
+		}
+		if len(setlist.Params.SingerTargets) > 0 && len(set.Entries) > 0 {
+			for _, singer := range setlist.Airtime(i) {
+				if singer.OffTarget(setlist.Params.Tolerance()) {
+					message := fmt.Sprintf("%s sings %.0f%% of the set, their target is %g%%", singer.Singer, singer.Share, singer.Target)
+					violations = append(violations, Violation{Set: i, Position: -1, Rule: RuleSingerShare, Message: message})
+				}
+			}
+		}
+		if library != nil && len(setlist.Params.Mix.MaxGenrePerSet) > 0 {
+			violations = append(violations, genreLimitViolations(setlist.Params.Mix, library, set, i)...)
+		}
+		// sets without a target (read from a file with no duration given) can't be too long or short
+		if set.TargetMinutes == 0 {
+			continue
+		}
+		target := int(set.TargetMinutes) * 60
+		if duration := set.DurationInSeconds(); duration > target+SetOverrunSeconds {
+			over := duration - target
+			message := fmt.Sprintf("set runs %d minutes and %d seconds over its %d minute target", over/60, over%60, set.TargetMinutes)
+			violations = append(violations, Violation{Set: i, Position: -1, Rule: RuleSetLength, Message: message})
+		} else if duration < target-SetMarginSeconds {
+			under := target - duration
+			message := fmt.Sprintf("set runs %d minutes and %d seconds short of its %d minute target", under/60, under%60, set.TargetMinutes)
+			violations = append(violations, Violation{Set: i, Position: -1, Rule: RuleSetLength, Message: message})
+		}
+	}
+	if library != nil && len(setlist.Params.Mix.MinDecadeShare) > 0 && len(setlist.Sets) > 0 {
+		// decade shares are for the whole night, so they're reported against the last set
+		counts, songs := library.decadeCounts(setlist)
+		for _, warning := range setlist.Params.Mix.decadeWarnings(counts, songs) {
+			violations = append(violations, Violation{Set: len(setlist.Sets) - 1, Position: -1, Rule: RuleDecadeShare, Message: "across the night, " + warning})
+		}
+	}
+	if library != nil && setlist.Params.Mix.MinFastShare > 0 && len(setlist.Sets) > 0 {
+		_, songs := library.decadeCounts(setlist)
+		if warning := setlist.Params.Mix.fastWarning(library.fastCount(setlist), songs); warning != "" {
+			violations = append(violations, Violation{Set: len(setlist.Sets) - 1, Position: -1, Rule: RuleEnergy, Message: "across the night, " + warning})
+		}
+	}
+	return append(violations, scheduleViolations(setlist, library)...)
+}
+
+// sameSong reports whether two songs are the same, by title and artist. A blank artist, as in
+// setlists read from files, matches any artist.
+func sameSong(name, artist, otherName, otherArtist string) bool {
+	if !strings.EqualFold(name, otherName) {
+		return false
+	}
+	return artist == "" || otherArtist == "" || strings.EqualFold(artist, otherArtist)
+}
+
+// isDoNotPlay reports whether a song is on the DNP list. DNPs matched to a library track are
+// compared by ID so a DNP doesn't catch another artist's song with the same title, and the rest
+// by name.
+func (p *BuildParams) isDoNotPlay(name string, id int32) bool {
+	for i, dnp := range p.DoNotPlays {
+		if id != 0 && i < len(p.DoNotPlayIDs) && p.DoNotPlayIDs[i] != 0 {
+			if p.DoNotPlayIDs[i] == id {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(dnp, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRequest reports whether a song was requested, comparing by ID the same way as isDoNotPlay.
+func (p *BuildParams) isRequest(name string, id int32) bool {
+	for i, request := range p.Requests {
+		if id != 0 && i < len(p.RequestIDs) && p.RequestIDs[i] != 0 {
+			if p.RequestIDs[i] == id {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(request, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Setlist) contains(name, artist string) bool {
+	for _, set := range s.Sets {
+		for _, entry := range set.Entries {
+			if sameSong(entry.Name, entry.Artist, name, artist) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Setlist) checkIndex(set, position int) error {
+	if set < 0 || set >= len(s.Sets) {
+		return fmt.Errorf("set %d does not exist", set+1)
+	}
+	if position < -1 || position >= len(s.Sets[set].Entries) {
+		return fmt.Errorf("set %d does not have a song %d", set+1, position+1)
+	}
+	return nil
+}
+
+// Alternatives returns songs from the library that could replace the song at position without
+// breaking any rule it doesn't already break, in random order. A limit of 0 returns them all.
+func Alternatives(setlist *Setlist, library *Library, set, position, limit int) ([]SetEntry, error) {
+	if err := setlist.checkIndex(set, position); err != nil {
+		return nil, err
+	}
+	if position < 0 {
+		return nil, fmt.Errorf("a song position is required")
+	}
+	entries := setlist.Sets[set].Entries
+	current := entries[position]
+	violations := Validate(setlist, library)
+	allowed := len(violations)
+	for _, violation := range violations {
+		if violation.Set == set && violation.Position == position {
+			allowed--
+		}
+	}
+
+	alternatives := []SetEntry{}
+	for _, i := range rand.Perm(len(library.Tracks)) {
+		track := library.Tracks[i]
+		if setlist.contains(track.Name, track.Artist) {
+			continue
+		}
+		for _, singer := range shuffled(setlist.Params.Singers) {
+			entry, found := track.Entry(singer, setlist.Params.Explicit())
+			if !found {
+				continue
+			}
+			setlist.Sets[set].Entries[position] = entry
+			fits := len(Validate(setlist, library)) <= allowed
+			setlist.Sets[set].Entries[position] = current
+			if fits {
+				alternatives = append(alternatives, entry)
+				break
+			}
+		}
+		if limit > 0 && len(alternatives) == limit {
+			break
+		}
+	}
+	return alternatives, nil
+}
+
+// ReplaceSong puts entry in place of the song at position, keeping the request count right.
+func ReplaceSong(setlist *Setlist, set, position int, entry SetEntry) error {
+	if err := setlist.checkIndex(set, position); err != nil {
+		return err
+	}
+	if position < 0 {
+		return fmt.Errorf("a song position is required")
+	}
+	current := setlist.Sets[set].Entries[position]
+	if current.Locked {
+		return fmt.Errorf("%s is locked", current.Name)
+	}
+	if current.Request {
+		setlist.RequestsIncluded--
+	}
+	if entry.Request {
+		setlist.RequestsIncluded++
+	}
+	setlist.Sets[set].Entries[position] = entry
+	return nil
+}
+
+// SwapSong replaces the song at position with a random alternative.
+func SwapSong(setlist *Setlist, library *Library, set, position int) (SetEntry, error) {
+	alternatives, altErr := Alternatives(setlist, library, set, position, 1)
+	if altErr != nil {
+		return SetEntry{}, altErr
+	}
+	current := setlist.Sets[set].Entries[position]
+	if current.Locked {
+		return SetEntry{}, fmt.Errorf("%s is locked", current.Name)
+	}
+	if len(alternatives) == 0 {
+		return SetEntry{}, fmt.Errorf("no song in the library can replace %s without breaking a rule", current.Name)
+	}
+	return alternatives[0], ReplaceSong(setlist, set, position, alternatives[0])
+}
+
+// BestEntry picks the singer for a track that breaks the fewest rules at position.
+func BestEntry(setlist *Setlist, library *Library, set, position int, track *LibraryTrack) (SetEntry, error) {
+	if err := setlist.checkIndex(set, position); err != nil {
+		return SetEntry{}, err
+	}
+	if position < 0 {
+		return SetEntry{}, fmt.Errorf("a song position is required")
+	}
+	current := setlist.Sets[set].Entries[position]
+	var best SetEntry
+	fewest := -1
+	for _, singer := range setlist.Params.Singers {
+		entry, found := track.Entry(singer, setlist.Params.Explicit())
+		if !found {
+			continue
+		}
+		setlist.Sets[set].Entries[position] = entry
+		violations := len(Validate(setlist, library))
+		setlist.Sets[set].Entries[position] = current
+		if fewest < 0 || violations < fewest {
+			best = entry
+			fewest = violations
+		}
+	}
+	if fewest < 0 {
+		return SetEntry{}, fmt.Errorf("none of the singers for this gig have a key for %s", track.Name)
+	}
+	return best, nil
+}
+
+// MoveSong moves a song to another position, in the same set or a different one.
+func MoveSong(setlist *Setlist, fromSet, fromPosition, toSet, toPosition int) error {
+	if err := setlist.checkIndex(fromSet, fromPosition); err != nil {
+		return err
+	}
+	if fromPosition < 0 {
+		return fmt.Errorf("a song position is required")
+	}
+	if toSet < 0 || toSet >= len(setlist.Sets) {
+		return fmt.Errorf("set %d does not exist", toSet+1)
+	}
+	entry := setlist.Sets[fromSet].Entries[fromPosition]
+	setlist.Sets[fromSet].Entries = slices.Delete(setlist.Sets[fromSet].Entries, fromPosition, fromPosition+1)
+	toPosition = max(0, min(toPosition, len(setlist.Sets[toSet].Entries)))
+	setlist.Sets[toSet].Entries = slices.Insert(setlist.Sets[toSet].Entries, toPosition, entry)
+	return nil
+}
+
+// RegenerateSet rebuilds one set in place, leaving the others alone. Locked songs keep their
+// positions. Requests in the set are kept if they still fit, placed every few songs the same
+// way a build places them.
+func RegenerateSet(setlist *Setlist, library *Library, set int) error {
+	if err := setlist.checkIndex(set, -1); err != nil {
+		return err
+	}
+	old := setlist.Sets[set].Entries
+	locked := map[int]SetEntry{}
+	var requests []*LibraryTrack
+	for i, entry := range old {
+		if entry.Locked {
+			locked[i] = entry
+			continue
+		}
+		if !entry.Request {
+			continue
+		}
+		setlist.RequestsIncluded--
+		if track, found := library.Track(entry.Name, entry.Artist); found {
+			requests = append(requests, track)
+		}
+	}
+	setlist.Sets[set].Entries = []SetEntry{}
+	isLocked := func(track *LibraryTrack) bool {
+		for _, entry := range locked {
+			if sameSong(entry.Name, entry.Artist, track.Name, track.Artist) {
+				return true
+			}
+		}
+		return false
+	}
+
+	pool := []*LibraryTrack{}
+	for _, i := range rand.Perm(len(library.Tracks)) {
+		track := library.Tracks[i]
+		if !setlist.contains(track.Name, track.Artist) && !isLocked(track) {
+			pool = append(pool, track)
+		}
+	}
+	target := int(setlist.Sets[set].TargetMinutes) * 60
+	margin := SetMarginSeconds
+	countTillRequest := 0
+	placed := 0
+	for setlist.Sets[set].DurationInSeconds() < target-margin || placed < len(locked) {
+		if entry, found := locked[len(setlist.Sets[set].Entries)]; found {
+			setlist.Sets[set].Entries = append(setlist.Sets[set].Entries, entry)
+			placed++
+			continue
+		}
+		added := false
+		if countTillRequest >= setlist.Params.Spacing() || len(pool) == 0 {
+			for i, track := range requests {
+				if appendTrack(setlist, library, set, track, true) {
+					requests = append(requests[:i], requests[i+1:]...)
+					setlist.RequestsIncluded++
+					countTillRequest = 0
+					added = true
+					break
+				}
+			}
+		}
+		if !added && setlist.Sets[set].DurationInSeconds() < target-margin {
+			for i, track := range pool {
+				if appendTrack(setlist, library, set, track, false) {
+					pool = append(pool[:i], pool[i+1:]...)
+					countTillRequest++
+					added = true
+					break
+				}
+			}
+		}
+		if added {
+			continue
+		}
+		if placed == len(locked) {
+			break
+		}
+		// nothing else fits, so the remaining locked songs close out the set in their old order
+		for i := len(setlist.Sets[set].Entries); i < len(old); i++ {
+			if entry, found := locked[i]; found {
+				setlist.Sets[set].Entries = append(setlist.Sets[set].Entries, entry)
+				placed++
+			}
+		}
+	}
+	for _, track := range requests {
+		setlist.Warnings = append(setlist.Warnings, fmt.Sprintf("Request %s no longer fit when set %d was regenerated", track.Name, set+1))
+	}
+	if duration := setlist.Sets[set].DurationInSeconds(); duration < target {
+		underfill := target - duration
+		setlist.Warnings = append(setlist.Warnings, fmt.Sprintf("Set %d is underfilled by %d minutes and %d seconds.", set+1, underfill/60, underfill%60))
+	}
+	return nil
+}
+
+// appendTrack adds a track to the end of a set with the first singer that doesn't break a rule.
+func appendTrack(setlist *Setlist, library *Library, set int, track *LibraryTrack, request bool) bool {
+	entries := setlist.Sets[set].Entries
+	maxDuration := int(setlist.Sets[set].TargetMinutes)*60 + SetOverrunSeconds
+	if setlist.Sets[set].DurationInSeconds()+int(track.DurationInSeconds) > maxDuration {
+		return false
+	}
+	for _, singer := range shuffled(setlist.Params.Singers) {
+		entry, found := track.Entry(singer, setlist.Params.Explicit())
+		if !found {
+			continue
+		}
+		entry.Request = request
+		candidate := append(entries, entry)
+		if len(checkPosition(setlist, library, candidate, len(candidate)-1)) == 0 {
+			setlist.Sets[set].Entries = candidate
+			return true
+		}
+	}
+	return false
+}
+
+func shuffled(list []string) []string {
+	result := make([]string, len(list))
+	copy(result, list)
+	rand.Shuffle(len(result), func(i, j int) {
+		result[i], result[j] = result[j], result[i]
+	})
+	return result
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+func testLibrary(count int) *Library {
+	rows := []database.GetTracksWithSingersRow{}
+	keys := []string{"A", "C", "E", "G"}
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("Song %d", i)
+		artist := fmt.Sprintf("Artist %d", i)
+		rows = append(rows, database.GetTracksWithSingersRow{Name: name, Artist: artist, DurationInSeconds: 240, Singer: "Riley", SingerKey: keys[i%len(keys)]})
+		rows = append(rows, database.GetTracksWithSingersRow{Name: name, Artist: artist, DurationInSeconds: 240, Singer: "Ty", SingerKey: keys[(i+1)%len(keys)]})
+	}
+	return NewLibrary(rows)
+}
+
+func TestValidate(t *testing.T) {
+	entry := func(name, artist, singer, key string) SetEntry {
+		return SetEntry{Name: name, Artist: artist, Singer: singer, Key: key, DurationInSeconds: 240}
+	}
+	tests := []struct {
+		name     string
+		entries  []SetEntry
+		expected []string
+	}{
+		{
+			name: "valid",
+			entries: []SetEntry{
+				entry("A", "Artist A", "Riley", "C"),
+				entry("B", "Artist B", "Ty", "C"),
+				entry("C", "Artist C", "Riley", "D"),
+			},
+		},
+		{
+			name: "repeated key and singer",
+			entries: []SetEntry{
+				entry("A", "Artist A", "Riley", "C"),
+				entry("B", "Artist B", "Riley", "C"),
+				entry("C", "Artist C", "Riley", "C"),
+			},
+			expected: []string{RuleRepeatKey, RuleRepeatSinger},
+		},
+		{
+			name: "repeated artist and song",
+			entries: []SetEntry{
+				entry("A", "Artist A", "Riley", "C"),
+				entry("A", "Artist A", "Ty", "D"),
+			},
+			expected: []string{RuleRepeatArtist, RuleRepeatSong},
+		},
+		{
+			name: "unknown artists aren't repeats",
+			entries: []SetEntry{
+				entry("A", "", "Riley", "C"),
+				entry("B", "", "Ty", "D"),
+			},
+		},
+		{
+			name: "dnp, explicit and unknown singer",
+			entries: []SetEntry{
+				{Name: "Nope", Artist: "Artist A", Singer: "Jared", Key: "C", Explicit: true, DurationInSeconds: 600},
+			},
+			expected: []string{RuleDoNotPlay, RuleExplicit, RuleSinger},
+		},
+		{
+			name: "set too short",
+			entries: []SetEntry{
+				entry("A", "Artist A", "Riley", "C"),
+			},
+			expected: []string{RuleSetLength},
+		},
+		{
+			name: "set too long",
+			entries: []SetEntry{
+				{Name: "Long", Artist: "Artist A", Singer: "Riley", Key: "C", DurationInSeconds: 1000},
+			},
+			expected: []string{RuleSetLength},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setlist := &Setlist{
+				Params: BuildParams{Singers: []string{"Riley", "Ty"}, DoNotPlays: []string{"nope"}},
+				Sets:   []Set{{TargetMinutes: 10, Entries: tt.entries}},
+			}
+			violations := Validate(setlist, nil)
+			if len(violations) != len(tt.expected) {
+				t.Fatalf("Expected %d violations, got %v", len(tt.expected), violations)
+			}
+			for i, rule := range tt.expected {
+				if violations[i].Rule != rule {
+					t.Errorf("Expected violation %d to be %s, got %s", i, rule, violations[i].Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestRegenerateAndSwap(t *testing.T) {
+	library := testLibrary(30)
+	setlist := &Setlist{
+		Params: BuildParams{Singers: []string{"Riley", "Ty"}},
+		Sets:   []Set{{TargetMinutes: 40}, {TargetMinutes: 40}},
+	}
+	for i := range setlist.Sets {
+		if err := RegenerateSet(setlist, library, i); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if duration := setlist.Sets[i].DurationInSeconds(); duration < 37*60 {
+			t.Errorf("Expected set %d to be filled, got %d seconds", i+1, duration)
+		}
+	}
+	if violations := Validate(setlist, library); len(violations) != 0 {
+		t.Fatalf("Expected no violations after regenerating, got %v", violations)
+	}
+
+	old := setlist.Sets[1].Entries[2]
+	replacement, swapErr := SwapSong(setlist, library, 1, 2)
+	if swapErr != nil {
+		t.Fatalf("unexpected error: %v", swapErr)
+	}
+	if replacement.Name == old.Name || !reflect.DeepEqual(setlist.Sets[1].Entries[2], replacement) {
+		t.Errorf("Expected %s to be replaced, got %+v", old.Name, setlist.Sets[1].Entries[2])
+	}
+	if violations := Validate(setlist, library); len(violations) != 0 {
+		t.Errorf("Expected no violations after swapping, got %v", violations)
+	}
+
+	if _, swapErr := SwapSong(setlist, library, 2, 0); swapErr == nil {
+		t.Errorf("Expected an error swapping in a set that doesn't exist")
+	}
+}
+
+func TestRegenerateKeepsLockedSongs(t *testing.T) {
+	library := testLibrary(30)
+	setlist := &Setlist{
+		Params: BuildParams{Singers: []string{"Riley", "Ty"}},
+		Sets:   []Set{{TargetMinutes: 30}},
+	}
+	if err := RegenerateSet(setlist, library, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	setlist.Sets[0].Entries[1].Locked = true
+	setlist.Sets[0].Entries[4].Locked = true
+	first := setlist.Sets[0].Entries[1]
+	second := setlist.Sets[0].Entries[4]
+	if err := RegenerateSet(setlist, library, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(setlist.Sets[0].Entries[1], first) || !reflect.DeepEqual(setlist.Sets[0].Entries[4], second) {
+		t.Errorf("Expected locked songs to keep their positions, got %+v", setlist.Sets[0].Entries)
+	}
+	if _, swapErr := SwapSong(setlist, library, 0, 1); swapErr == nil {
+		t.Errorf("Expected an error swapping a locked song")
+	}
+}
+
+func TestMoveSong(t *testing.T) {
+	setlist := &Setlist{Sets: []Set{
+		{Entries: []SetEntry{{Name: "A"}, {Name: "B"}, {Name: "C"}}},
+		{Entries: []SetEntry{{Name: "D"}}},
+	}}
+	if err := MoveSong(setlist, 0, 0, 0, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := MoveSong(setlist, 0, 1, 1, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names := func(entries []SetEntry) string {
+		result := ""
+		for _, entry := range entries {
+			result += entry.Name
+		}
+		return result
+	}
+	if names(setlist.Sets[0].Entries) != "BA" || names(setlist.Sets[1].Entries) != "CD" {
+		t.Errorf("Unexpected sets after moving: %s / %s", names(setlist.Sets[0].Entries), names(setlist.Sets[1].Entries))
+	}
+	if err := MoveSong(setlist, 0, 5, 1, 0); err == nil {
+		t.Errorf("Expected an error moving a song that doesn't exist")
+	}
+}
+
+func TestSameTitleDifferentArtists(t *testing.T) {
+	library := NewLibrary([]database.GetTracksWithSingersRow{
+		{ID: 1, Name: "Hello", Artist: "Adele", DurationInSeconds: 240, Singer: "Riley", SingerKey: "Fm"},
+		{ID: 2, Name: "Hello", Artist: "Lionel Richie", DurationInSeconds: 240, Singer: "Ty", SingerKey: "Am"},
+	})
+	setlist := &Setlist{
+		Params: BuildParams{Singers: []string{"Riley", "Ty"}, DoNotPlays: []string{"Hello"}, DoNotPlayIDs: []int32{1}},
+		Sets: []Set{{Entries: []SetEntry{
+			{Name: "Hello", Artist: "Lionel Richie", Singer: "Ty", Key: "Am", DurationInSeconds: 240},
+			{Name: "Hello", Artist: "Adele", Singer: "Riley", Key: "Fm", DurationInSeconds: 240},
+		}}},
+	}
+	violations := Validate(setlist, library)
+	if len(violations) != 1 || violations[0].Rule != RuleDoNotPlay || violations[0].Position != 1 {
+		t.Errorf("Expected only Adele's Hello to be a DNP and neither to be a repeat, got %v", violations)
+	}
+	setlist.Sets[0].Entries = setlist.Sets[0].Entries[:1]
+	if !setlist.contains("Hello", "Lionel Richie") || setlist.contains("Hello", "Adele") {
+		t.Error("Expected the setlist to contain Lionel Richie's Hello and not Adele's")
+	}
+	if violations := Validate(&Setlist{Params: setlist.Params, Sets: []Set{{Entries: []SetEntry{{Name: "hello", Singer: "Ty", Key: "Am"}}}}}, nil); len(violations) != 1 || violations[0].Rule != RuleDoNotPlay {
+		t.Errorf("Expected a song without an artist to fall back to matching the DNP by name, got %v", violations)
+	}
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SlowBPM and FastBPM split songs by tempo for time windows: songs under SlowBPM are slow and
+// songs at or over FastBPM are fast. Songs without a BPM are neither.
+const (
+	SlowBPM = 100
+	FastBPM = 120
+)
+
+var clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+
+// ParseClock reads a time of night like "20:30", "8pm" or "8:30 pm" and returns it as "HH:MM".
+func ParseClock(input string) (string, error) {
+	invalid := fmt.Errorf("invalid time %q, please use 24 hour time like 20:30, or 8:30pm", input)
+	match := clockPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(input)))
+	if match == nil || (match[2] == "" && match[3] == "") {
+		return "", invalid
+	}
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+	if match[3] != "" {
+		if hour < 1 || hour > 12 {
+			return "", invalid
+		}
+		hour %= 12
+		if match[3] == "pm" {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return "", invalid
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute), nil
+}
+
+// clockMinutes returns the minutes after midnight of a time from ParseClock.
+func clockMinutes(clock string) int {
+	hour, minute, _ := strings.Cut(clock, ":")
+	h, _ := strconv.Atoi(hour)
+	m, _ := strconv.Atoi(minute)
+	return h*60 + m
+}
+
+// TimeWindow limits a kind of song to part of the night: they can only start from From and
+// before Until, either of which can be left blank. Songs is "slow", "fast", "explicit" or a
+// genre bucket.
+type TimeWindow struct {
+	Songs string `json:"songs"`
+	From  string `json:"from,omitempty"`
+	Until string `json:"until,omitempty"`
+}
+
+// String writes the window the way ParseTimeWindows reads it.
+func (w TimeWindow) String() string {
+	switch {
+	case w.From == "":
+		return w.Songs + "<" + w.Until
+	case w.Until == "":
+		return w.Songs + ">" + w.From
+	}
+	return w.Songs + "=" + w.From + "-" + w.Until
+}
+
+// Rule describes the window in a sentence for rejections and violations.
+func (w TimeWindow) Rule() string {
+	switch {
+	case w.From == "":
+		return fmt.Sprintf("%s songs can only start before %s", w.Songs, w.Until)
+	case w.Until == "":
+		return fmt.Sprintf("%s songs can only start after %s", w.Songs, w.From)
+	}
+	return fmt.Sprintf("%s songs can only start between %s and %s", w.Songs, w.From, w.Until)
+}
+
+// ParseTimeWindows reads time windows written as "slow<20:00,fast>21:30,country=19:00-21:00",
+// meaning slow songs only before 20:00, fast songs only after 21:30 and country songs only
+// between 19:00 and 21:00.
+func ParseTimeWindows(input string) ([]TimeWindow, error) {
+	windows := []TimeWindow{}
+	for _, rule := range strings.Split(input, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		invalid := fmt.Errorf("invalid time window %q, please use songs<time, songs>time or songs=from-until, e.g. slow<20:00", rule)
+		at := strings.IndexAny(rule, "<>=")
+		if at < 0 {
+			return nil, invalid
+		}
+		window := TimeWindow{Songs: NormalizeGenre(rule[:at])}
+		times := strings.TrimSpace(rule[at+1:])
+		switch rule[at] {
+		case '<':
+			window.Until = times
+		case '>':
+			window.From = times
+		case '=':
+			from, until, found := strings.Cut(times, "-")
+			if !found {
+				return nil, invalid
+			}
+			window.From, window.Until = strings.TrimSpace(from), strings.TrimSpace(until)
+		}
+		if window.Songs == "" {
+			return nil, invalid
+		}
+		if checkErr := window.normalize(); checkErr != nil {
+			return nil, checkErr
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// normalize checks the window's times and writes them as "HH:MM".
+func (w *TimeWindow) normalize() error {
+	if w.From == "" && w.Until == "" {
+		return fmt.Errorf("time window for %s songs needs a from or until time", w.Songs)
+	}
+	for _, clock := range []*string{&w.From, &w.Until} {
+		if *clock == "" {
+			continue
+		}
+		parsed, parseErr := ParseClock(*clock)
+		if parseErr != nil {
+			return parseErr
+		}
+		*clock = parsed
+	}
+	return nil
+}
+
+// matches reports whether a song is the kind the window is for.
+func (w TimeWindow) matches(bpm int32, explicit bool, buckets []string) bool {
+	switch w.Songs {
+	case "slow":
+		return bpm > 0 && bpm < SlowBPM
+	case "fast":
+		return bpm >= FastBPM
+	case "explicit":
+		return explicit
+	}
+	return slices.Contains(buckets, w.Songs)
+}
+
+// CheckSchedule makes sure the start time, song gap, hard stop and time windows can be worked
+// out, and writes the times as "HH:MM".
+func (p *BuildParams) CheckSchedule() error {
+	if p.SongGap < 0 {
+		return fmt.Errorf("a gap of %d seconds between songs can't be negative", p.SongGap)
+	}
+	if p.StartTime == "" {
+		if p.HardStop != "" || len(p.TimeWindows) > 0 {
+			return fmt.Errorf("a start time is needed to check a hard stop or time windows")
+		}
+		return nil
+	}
+	for _, clock := range []*string{&p.StartTime, &p.HardStop} {
+		if *clock == "" {
+			continue
+		}
+		parsed, parseErr := ParseClock(*clock)
+		if parseErr != nil {
+			return parseErr
+		}
+		*clock = parsed
+	}
+	for i := range p.TimeWindows {
+		p.TimeWindows[i].Songs = NormalizeGenre(p.TimeWindows[i].Songs)
+		if windowErr := p.TimeWindows[i].normalize(); windowErr != nil {
+			return windowErr
+		}
+	}
+	return nil
+}
+
+// Clock returns the time of night a number of seconds after the start, or "" without a start
+// time.
+func (p *BuildParams) Clock(seconds int) string {
+	if p.StartTime == "" {
+		return ""
+	}
+	minutes := (clockMinutes(p.StartTime) + seconds/60) % (24 * 60)
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// offset returns how many seconds after the start a time of night is. Times up to 12 hours
+// before the start are earlier the same night, so "slow<20:00" at a 20:30 gig allows no slow
+// songs, and later ones are after midnight.
+func (p *BuildParams) offset(clock string) int {
+	minutes := clockMinutes(clock) - clockMinutes(p.StartTime)
+	if minutes < -12*60 {
+		minutes += 24 * 60
+	} else if minutes >= 12*60 {
+		minutes -= 24 * 60
+	}
+	return minutes * 60
+}
+
+// outsideWindow returns the first time window a song starting at start seconds breaks.
+func (p *BuildParams) outsideWindow(start int, bpm int32, explicit bool, buckets []string) (TimeWindow, bool) {
+	if p.StartTime == "" {
+		return TimeWindow{}, false
+	}
+	for _, window := range p.TimeWindows {
+		if !window.matches(bpm, explicit, buckets) {
+			continue
+		}
+		if (window.From != "" && start < p.offset(window.From)) || (window.Until != "" && start >= p.offset(window.Until)) {
+			return window, true
+		}
+	}
+	return TimeWindow{}, false
+}
+
+// SongTime is when a song starts and ends, in seconds after the start of the gig.
+type SongTime struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// breakSeconds is the length of each break, worked out from the number of sets for setlists
+// read from files.
+func (s *Setlist) breakSeconds() int {
+	if s.BreakMinutes > 0 {
+		return s.BreakMinutes * 60
+	}
+	return BreakMinutes(len(s.Sets)) * 60
+}
+
+// Schedule works out when each song in each set starts and ends, with the build's gap between
+// songs and the breaks between sets.
+func (s *Setlist) Schedule() [][]SongTime {
+	schedule := [][]SongTime{}
+	clock := 0
+	for i, set := range s.Sets {
+		if i > 0 {
+			clock += s.breakSeconds()
+		}
+		times := []SongTime{}
+		for j, entry := range set.Entries {
+			if j > 0 {
+				clock += s.Params.SongGap
+			}
+			times = append(times, SongTime{Start: clock, End: clock + int(entry.DurationInSeconds)})
+			clock += int(entry.DurationInSeconds)
+		}
+		schedule = append(schedule, times)
+	}
+	return schedule
+}
+
+// hardStopOverrun returns how many seconds a set ending at end runs past the hard stop.
+func (p *BuildParams) hardStopOverrun(end int) int {
+	if p.StartTime == "" || p.HardStop == "" {
+		return 0
+	}
+	return max(0, end-p.offset(p.HardStop))
+}
+
+// scheduleViolations returns the songs that start outside their time windows and the sets that
+// run past the hard stop.
+func scheduleViolations(setlist *Setlist, library *Library) []Violation {
+	params := setlist.Params
+	violations := []Violation{}
+	if params.StartTime == "" {
+		return violations
+	}
+	for i, times := range setlist.Schedule() {
+		for j, songTime := range times {
+			entry := setlist.Sets[i].Entries[j]
+			var bpm int32
+			var buckets []string
+			if library != nil {
+				if track, found := library.Track(entry.Name, entry.Artist); found {
+					bpm = track.Bpm
+					buckets = library.genres.Buckets(track.Genre)
+				}
+			}
+			if window, outside := params.outsideWindow(songTime.Start, bpm, entry.Explicit, buckets); outside {
+				message := fmt.Sprintf("%s starts at %s, but %s", entry.Name, params.Clock(songTime.Start), window.Rule())
+				violations = append(violations, Violation{Set: i, Position: j, Rule: RuleTimeWindow, Message: message})
+			}
+		}
+		if len(times) == 0 {
+			continue
+		}
+		if over := params.hardStopOverrun(times[len(times)-1].End); over > 0 {
+			message := fmt.Sprintf("set runs %d minutes and %d seconds past the %s hard stop", over/60, over%60, params.HardStop)
+			violations = append(violations, Violation{Set: i, Position: -1, Rule: RuleHardStop, Message: message})
+		}
+	}
+	return violations
+}
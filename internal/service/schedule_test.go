@@ -0,0 +1,110 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+func TestParseClock(t *testing.T) {
+	for input, expected := range map[string]string{"20:00": "20:00", "7:05": "07:05", "8pm": "20:00", "8:30 PM": "20:30", "12am": "00:00", "12:15pm": "12:15"} {
+		if clock, parseErr := ParseClock(input); parseErr != nil || clock != expected {
+			t.Errorf("Expected %q to be %s, got %s (%v)", input, expected, clock, parseErr)
+		}
+	}
+	for _, input := range []string{"20", "24:00", "13pm", "8:75", "tonight"} {
+		if _, parseErr := ParseClock(input); parseErr == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+}
+
+func TestParseTimeWindows(t *testing.T) {
+	windows, parseErr := ParseTimeWindows("slow<8pm, fast>21:30,Hip  Hop=19:00-21:00")
+	expected := []TimeWindow{
+		{Songs: "slow", Until: "20:00"},
+		{Songs: "fast", From: "21:30"},
+		{Songs: "hip hop", From: "19:00", Until: "21:00"},
+	}
+	if parseErr != nil || !reflect.DeepEqual(windows, expected) {
+		t.Errorf("Expected %v, got %v (%v)", expected, windows, parseErr)
+	}
+	if windows[2].String() != "hip hop=19:00-21:00" {
+		t.Errorf("Expected the window to be written the way it's read, got %s", windows[2])
+	}
+	for _, input := range []string{"slow", "slow<", "<20:00", "country=19:00"} {
+		if _, badErr := ParseTimeWindows(input); badErr == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	setlist := &Setlist{
+		Params: BuildParams{StartTime: "23:30", SongGap: 30},
+		Sets: []Set{
+			{Entries: []SetEntry{{Name: "A", DurationInSeconds: 240}, {Name: "B", DurationInSeconds: 180}}},
+			{Entries: []SetEntry{{Name: "C", DurationInSeconds: 200}}},
+		},
+	}
+	expected := [][]SongTime{{{0, 240}, {270, 450}}, {{1650, 1850}}}
+	if schedule := setlist.Schedule(); !reflect.DeepEqual(schedule, expected) {
+		t.Errorf("Expected a 30 second gap between songs and a 20 minute break, got %v", schedule)
+	}
+	if clock := setlist.Params.Clock(1650); clock != "23:57" {
+		t.Errorf("Expected the second set to start at 23:57, got %s", clock)
+	}
+	if clock := setlist.Params.Clock(1850); clock != "00:00" {
+		t.Errorf("Expected the clock to wrap past midnight, got %s", clock)
+	}
+	if offset := setlist.Params.offset("00:30"); offset != 3600 {
+		t.Errorf("Expected 00:30 to be an hour after a 23:30 start, got %d seconds", offset)
+	}
+	if offset := setlist.Params.offset("23:00"); offset != -1800 {
+		t.Errorf("Expected 23:00 to be before a 23:30 start, got %d seconds", offset)
+	}
+
+	params := BuildParams{HardStop: "23:00"}
+	if checkErr := params.CheckSchedule(); checkErr == nil {
+		t.Error("Expected a hard stop without a start time to be rejected")
+	}
+	params = BuildParams{StartTime: "7:30pm", HardStop: "11pm", TimeWindows: []TimeWindow{{Songs: "Slow", Until: "8pm"}}}
+	if checkErr := params.CheckSchedule(); checkErr != nil || params.StartTime != "19:30" || params.HardStop != "23:00" || params.TimeWindows[0] != (TimeWindow{Songs: "slow", Until: "20:00"}) {
+		t.Errorf("Expected the times to be normalized, got %+v (%v)", params, checkErr)
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	library := NewLibrary([]database.GetTracksWithSingersRow{
+		{Name: "Africa", Artist: "Toto", Bpm: 93, DurationInSeconds: 1200, Singer: "Riley", SingerKey: "B"},
+		{Name: "Mr. Brightside", Artist: "The Killers", Bpm: 148, DurationInSeconds: 1200, Singer: "Ty", SingerKey: "Db"},
+		{Name: "Wagon Wheel", Artist: "Darius Rucker", Genre: []string{"country"}, Bpm: 74, DurationInSeconds: 1200, Singer: "Riley", SingerKey: "A"},
+	})
+	library.SetGenreMap(testGenreMap())
+	entries := []SetEntry{
+		{Name: "Mr. Brightside", Artist: "The Killers", Singer: "Ty", Key: "Db", DurationInSeconds: 1200},
+		{Name: "Africa", Artist: "Toto", Singer: "Riley", Key: "B", DurationInSeconds: 1200},
+		{Name: "Wagon Wheel", Artist: "Darius Rucker", Singer: "Riley", Key: "A", DurationInSeconds: 1200},
+	}
+	rules := func(params BuildParams) []Violation {
+		params.Singers = []string{"Riley", "Ty"}
+		return Validate(&Setlist{Params: params, Sets: []Set{{Entries: entries}}}, library)
+	}
+
+	if violations := rules(BuildParams{TimeWindows: []TimeWindow{{Songs: "slow", Until: "20:00"}}}); len(violations) != 0 {
+		t.Errorf("Expected time windows to be ignored without a start time, got %v", violations)
+	}
+	violations := rules(BuildParams{StartTime: "19:30", TimeWindows: []TimeWindow{{Songs: "slow", Until: "20:00"}}})
+	if len(violations) != 1 || violations[0].Rule != RuleTimeWindow || violations[0].Position != 2 {
+		t.Errorf("Expected Wagon Wheel at 20:30 to be too late for a slow song, got %v", violations)
+	}
+	violations = rules(BuildParams{StartTime: "19:30", TimeWindows: []TimeWindow{{Songs: "fast", From: "20:00"}, {Songs: "country", From: "19:00", Until: "21:00"}}})
+	if len(violations) != 1 || violations[0].Rule != RuleTimeWindow || violations[0].Position != 0 {
+		t.Errorf("Expected Mr. Brightside at 19:30 to be too early for a fast song, got %v", violations)
+	}
+	violations = rules(BuildParams{StartTime: "22:00", HardStop: "22:45"})
+	if len(violations) != 1 || violations[0].Rule != RuleHardStop || violations[0].Position != -1 {
+		t.Errorf("Expected the set ending at 23:00 to run past the hard stop, got %v", violations)
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SetlistFile is a setlist read from a file, along with the line each set header and song came
+// from so problems can be reported against the file. Lines are 0 when the format has none.
+type SetlistFile struct {
+	Setlist    *Setlist
+	SetLines   []int
+	EntryLines [][]int
+	// HasParams is set when the file carried its own build parameters and set targets.
+	HasParams bool
+}
+
+// SetlistFormatFromPath picks the setlist format from a file extension, defaulting to the
+// plain text format setlists are printed in.
+func SetlistFormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".csv":
+		return "csv"
+	}
+	return "text"
+}
+
+func ReadSetlistFile(r io.Reader, format string) (*SetlistFile, error) {
+	switch format {
+	case "json":
+		return readSetlistJSON(r)
+	case "csv":
+		return readSetlistCSV(r)
+	case "text":
+		return readSetlistText(r)
+	}
+	return nil, fmt.Errorf("unsupported setlist format %s, please use csv, json or text", format)
+}
+
+func readSetlistJSON(r io.Reader) (*SetlistFile, error) {
+	var setlist Setlist
+	if decodeErr := json.NewDecoder(r).Decode(&setlist); decodeErr != nil {
+		return nil, fmt.Errorf("invalid JSON setlist: %v", decodeErr)
+	}
+	file := &SetlistFile{Setlist: &setlist, HasParams: true}
+	for _, set := range setlist.Sets {
+		file.SetLines = append(file.SetLines, 0)
+		file.EntryLines = append(file.EntryLines, make([]int, len(set.Entries)))
+	}
+	return file, nil
+}
+
+// readSetlistCSV reads set, song, singer and key columns, with an optional artist column. The
+// header row can be left off if the columns are in that order. Duets list their singers as
+// "Lead & Partner".
+func readSetlistCSV(r io.Reader) (*SetlistFile, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	columns := map[string]int{"set": 0, "song": 1, "singer": 2, "key": 3, "artist": -1}
+	file := &SetlistFile{Setlist: &Setlist{}}
+	for row := 0; ; row++ {
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		} else if readErr != nil {
+			return nil, fmt.Errorf("invalid CSV setlist: %v", readErr)
+		}
+		line, _ := reader.FieldPos(0)
+		if row == 0 && isSetlistHeader(record) {
+			for k := range columns {
+				columns[k] = -1
+			}
+			for i, name := range record {
+				switch strings.ToLower(strings.TrimSpace(name)) {
+				case "set":
+					columns["set"] = i
+				case "song", "name", "title":
+					columns["song"] = i
+				case "singer":
+					columns["singer"] = i
+				case "key":
+					columns["key"] = i
+				case "artist":
+					columns["artist"] = i
+				}
+			}
+			for _, required := range []string{"set", "song", "singer", "key"} {
+				if columns[required] < 0 {
+					return nil, fmt.Errorf("line %d: CSV setlist is missing a %s column", line, required)
+				}
+			}
+			continue
+		}
+		field := func(name string) string {
+			if columns[name] < 0 || columns[name] >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[columns[name]])
+		}
+		set, setErr := strconv.Atoi(field("set"))
+		if setErr != nil || set < 1 {
+			return nil, fmt.Errorf("line %d: invalid set number %q", line, field("set"))
+		}
+		for len(file.Setlist.Sets) < set {
+			file.Setlist.Sets = append(file.Setlist.Sets, Set{})
+			file.SetLines = append(file.SetLines, line)
+			file.EntryLines = append(file.EntryLines, nil)
+		}
+		entry := SetEntry{Name: field("song"), Artist: field("artist"), Key: field("key")}
+		entry.Singer, entry.Partners = ParseVocalists(field("singer"))
+		if entry.Name == "" {
+			return nil, fmt.Errorf("line %d: missing song name", line)
+		}
+		file.Setlist.Sets[set-1].Entries = append(file.Setlist.Sets[set-1].Entries, entry)
+		file.EntryLines[set-1] = append(file.EntryLines[set-1], line)
+	}
+	return file, nil
+}
+
+func isSetlistHeader(record []string) bool {
+	for _, name := range record {
+		if strings.EqualFold(strings.TrimSpace(name), "set") {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	setHeaderPattern = regexp.MustCompile(`^Set (\d+)\b.*:$`)
+	numberingPattern = regexp.MustCompile(`^\d+[:.)]\s*`)
+	songClockPattern = regexp.MustCompile(`^\[\d{1,2}:\d{2}\]\s*`)
+)
+
+// readSetlistText reads setlists the way they're printed: "Set N:" headers followed by
+// "N: Song - Singer - Key" lines, with duets as "N: Song - Lead & Partner - Key" and clean edits
+// followed by "(clean edit)". Start times like "[20:05]" are skipped, and numbering is
+// optional, and anything before the first song or after the "Requests Included" line is ignored.
+func readSetlistText(r io.Reader) (*SetlistFile, error) {
+	file := &SetlistFile{Setlist: &Setlist{}}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "⚠️") || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if strings.HasPrefix(text, "Requests Included") {
+			break
+		}
+		if match := setHeaderPattern.FindStringSubmatch(text); match != nil {
+			file.Setlist.Sets = append(file.Setlist.Sets, Set{})
+			file.SetLines = append(file.SetLines, line)
+			file.EntryLines = append(file.EntryLines, nil)
+			continue
+		}
+		text = numberingPattern.ReplaceAllString(text, "")
+		text = songClockPattern.ReplaceAllString(text, "")
+		text = strings.TrimSpace(strings.TrimSuffix(text, "🔒"))
+		text = strings.TrimSpace(strings.TrimSuffix(text, "(request)"))
+		clean := strings.HasSuffix(text, CleanEditMark)
+		text = strings.TrimSpace(strings.TrimSuffix(text, CleanEditMark))
+		parts := strings.Split(text, " - ")
+		if len(parts) < 3 && len(file.Setlist.Sets) == 0 {
+			// output before the first set, like "Setlist complete, printing..."
+			continue
+		}
+		if len(parts) < 3 {
+			return nil, fmt.Errorf("line %d: expected 'Song - Singer - Key', got %q", line, text)
+		}
+		if len(file.Setlist.Sets) == 0 {
+			file.Setlist.Sets = append(file.Setlist.Sets, Set{})
+			file.SetLines = append(file.SetLines, line)
+			file.EntryLines = append(file.EntryLines, nil)
+		}
+		// song names can contain " - ", so the singer and key are taken from the end
+		entry := SetEntry{
+			Name:  strings.TrimSpace(strings.Join(parts[:len(parts)-2], " - ")),
+			Key:   strings.TrimSpace(parts[len(parts)-1]),
+			Clean: clean,
+		}
+		entry.Singer, entry.Partners = ParseVocalists(parts[len(parts)-2])
+		last := len(file.Setlist.Sets) - 1
+		file.Setlist.Sets[last].Entries = append(file.Setlist.Sets[last].Entries, entry)
+		file.EntryLines[last] = append(file.EntryLines[last], line)
+	}
+	if scanErr := scanner.Err(); scanErr != nil {
+		return nil, scanErr
+	}
+	return file, nil
+}
+
+// Line returns the file line a violation points at, or 0 if the format has no lines.
+func (f *SetlistFile) Line(set, position int) int {
+	if set < 0 || set >= len(f.SetLines) {
+		return 0
+	}
+	if position < 0 || position >= len(f.EntryLines[set]) {
+		return f.SetLines[set]
+	}
+	return f.EntryLines[set][position]
+}
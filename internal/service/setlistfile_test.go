@@ -0,0 +1,82 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadSetlistFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		input    string
+		expected [][]string
+		lines    [][]int
+	}{
+		{
+			name:   "printed text",
+			format: "text",
+			input: "Setlist complete, printing...\n\nSet 1:\n1: Africa - Riley - A\n2: Don't Stop Me Now - 2011 Remaster - Ty - F\n\nSet 2 (4:00 of 40 minutes):\n1: Valerie - Riley - Eb 🔒\n  ⚠️ same key\n\n" +
+				"Requests Included: 1/2\nBreaks between sets:\n20 minutes\n",
+			expected: [][]string{{"Africa - Riley - A", "Don't Stop Me Now - 2011 Remaster - Ty - F"}, {"Valerie - Riley - Eb"}},
+			lines:    [][]int{{4, 5}, {8}},
+		},
+		{
+			name:     "clean edits and start times",
+			format:   "text",
+			input:    "Set 1 (19:30 - 19:38):\n1: [19:30] Sweet Caroline - Riley - B\n2: [19:34] Hey Ya! - Ty - G (clean edit) (request)\n",
+			expected: [][]string{{"Sweet Caroline - Riley - B", "Hey Ya! - Ty - G (clean edit)"}},
+			lines:    [][]int{{2, 3}},
+		},
+		{
+			name:     "csv with header",
+			format:   "csv",
+			input:    "set,song,artist,singer,key\n1,Africa,Toto,Riley,A\n2,Valerie,Amy Winehouse,Ty,Eb\n",
+			expected: [][]string{{"Africa - Riley - A"}, {"Valerie - Ty - Eb"}},
+			lines:    [][]int{{2}, {3}},
+		},
+		{
+			name:     "csv without header",
+			format:   "csv",
+			input:    "1,Africa,Riley,A\n1,Valerie,Ty,Eb\n",
+			expected: [][]string{{"Africa - Riley - A", "Valerie - Ty - Eb"}},
+			lines:    [][]int{{1, 2}},
+		},
+		{
+			name:     "json",
+			format:   "json",
+			input:    `{"sets":[{"target_minutes":40,"entries":[{"name":"Africa","artist":"Toto","singer":"Riley","key":"A"}]}]}`,
+			expected: [][]string{{"Africa - Riley - A"}},
+			lines:    [][]int{{0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := ReadSetlistFile(strings.NewReader(tt.input), tt.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(file.Setlist.Sets) != len(tt.expected) {
+				t.Fatalf("Expected %d sets, got %d", len(tt.expected), len(file.Setlist.Sets))
+			}
+			for i, set := range file.Setlist.Sets {
+				got := []string{}
+				for _, entry := range set.Entries {
+					got = append(got, entry.String())
+				}
+				if strings.Join(got, "|") != strings.Join(tt.expected[i], "|") {
+					t.Errorf("Expected set %d to be %v, got %v", i+1, tt.expected[i], got)
+				}
+				for j := range set.Entries {
+					if line := file.Line(i, j); line != tt.lines[i][j] {
+						t.Errorf("Expected set %d song %d on line %d, got %d", i+1, j+1, tt.lines[i][j], line)
+					}
+				}
+			}
+		})
+	}
+
+	if _, err := ReadSetlistFile(strings.NewReader("Set 1:\nAfrica by Toto\n"), "text"); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error on line 2, got %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+// RecordBuild adds a finished setlist to the build history that stats are worked out from.
+func RecordBuild(ctx context.Context, dbQueries *database.Queries, setlist *Setlist) error {
+	data, marshalErr := json.Marshal(setlist)
+	if marshalErr != nil {
+		return marshalErr
+	}
+	return dbQueries.CreateBuildHistory(ctx, data)
+}
+
+// LoadBuildHistory returns every recorded setlist, oldest first.
+func LoadBuildHistory(ctx context.Context, dbQueries *database.Queries) ([]*Setlist, error) {
+	rows, getErr := dbQueries.GetBuildHistory(ctx)
+	if getErr != nil {
+		return nil, getErr
+	}
+	setlists := []*Setlist{}
+	for _, row := range rows {
+		var setlist Setlist
+		if unmarshalErr := json.Unmarshal(row.Setlist, &setlist); unmarshalErr != nil {
+			return nil, fmt.Errorf("unable to read build %d: %v", row.ID, unmarshalErr)
+		}
+		setlists = append(setlists, &setlist)
+	}
+	return setlists, nil
+}
+
+type SongStat struct {
+	Name   string `json:"name"`
+	Artist string `json:"artist"`
+	Plays  int    `json:"plays"`
+}
+
+type SingerStat struct {
+	Singer  string `json:"singer"`
+	Gigs    int    `json:"gigs"`
+	Songs   int    `json:"songs"`
+	Seconds int    `json:"seconds"`
+	// Share is the percentage of all recorded airtime this singer sang.
+	Share float64 `json:"share"`
+}
+
+type RequestStat struct {
+	Name string `json:"name"`
+	// Artist is only known for requests that were matched to a library track.
+	Artist string `json:"artist,omitempty"`
+	// Requested counts the gigs it was asked for, and Played the gigs it made the setlist.
+	Requested int `json:"requested"`
+	Played    int `json:"played"`
+}
+
+type CountStat struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+type TrackRef struct {
+	Name   string `json:"name"`
+	Artist string `json:"artist"`
+}
+
+// Stats summarizes the recorded builds. Lists are sorted most common first.
+type Stats struct {
+	Builds      int           `json:"builds"`
+	Songs       []SongStat    `json:"songs"`
+	Singers     []SingerStat  `json:"singers"`
+	Requests    []RequestStat `json:"requests"`
+	Keys        []CountStat   `json:"keys"`
+	Bpms        []CountStat   `json:"bpms"`
+	NeverPlayed []TrackRef    `json:"never_played"`
+}
+
+// bpmBucket groups tempos into ranges of 10, e.g. "120-129".
+func bpmBucket(bpm int32) string {
+	if bpm <= 0 {
+		return "unknown"
+	}
+	low := bpm / 10 * 10
+	return fmt.Sprintf("%d-%d", low, low+9)
+}
+
+func sortedCounts(counts map[string]int) []CountStat {
+	stats := []CountStat{}
+	for value, count := range counts {
+		stats = append(stats, CountStat{Value: value, Count: count})
+	}
+	slices.SortFunc(stats, func(a, b CountStat) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+	return stats
+}
+
+// ComputeStats works out play counts, airtime and distributions from the recorded setlists.
+// tracks is the current library, used for BPMs and to find songs that have never been played.
+func ComputeStats(setlists []*Setlist, tracks []database.Track) *Stats {
+	stats := &Stats{
+		Builds:      len(setlists),
+		Songs:       []SongStat{},
+		Singers:     []SingerStat{},
+		Requests:    []RequestStat{},
+		NeverPlayed: []TrackRef{},
+	}
+	library := map[string]database.Track{}
+	byID := map[int32]database.Track{}
+	for _, track := range tracks {
+		library[trackID(track.Name, track.Artist)] = track
+		byID[track.ID] = track
+	}
+	songs := map[string]*SongStat{}
+	singers := map[string]*SingerStat{}
+	requests := map[string]*RequestStat{}
+	keys := map[string]int{}
+	bpms := map[string]int{}
+	totalSeconds := 0
+	for _, setlist := range setlists {
+		sangThisGig := map[string]bool{}
+		playedThisGig := map[string]bool{}
+		playedIDs := map[int32]bool{}
+		for _, set := range setlist.Sets {
+			for _, entry := range set.Entries {
+				id := trackID(entry.Name, entry.Artist)
+				song, ok := songs[id]
+				if !ok {
+					song = &SongStat{Name: entry.Name, Artist: entry.Artist}
+					songs[id] = song
+				}
+				song.Plays++
+				playedThisGig[strings.ToLower(entry.Name)] = true
+				if track, found := library[id]; found {
+					playedIDs[track.ID] = true
+				}
+
+				for _, vocalist := range entry.Vocalists() {
+					singer, ok := singers[vocalist]
+					if !ok {
+						singer = &SingerStat{Singer: vocalist}
+						singers[vocalist] = singer
+					}
+					singer.Songs++
+					singer.Seconds += entry.VocalSeconds()
+					totalSeconds += entry.VocalSeconds()
+					if !sangThisGig[vocalist] {
+						sangThisGig[vocalist] = true
+						singer.Gigs++
+					}
+				}
+
+				keys[entry.Key]++
+				bpms[bpmBucket(library[id].Bpm)]++
+			}
+		}
+		// requests are counted once per gig, however many lists they came in on. Requests matched
+		// to a library track are counted by its ID, so songs sharing a title stay apart.
+		requestedThisGig := map[string]bool{}
+		for i, name := range setlist.Params.Requests {
+			key := strings.ToLower(name)
+			var requestID int32
+			if i < len(setlist.Params.RequestIDs) {
+				requestID = setlist.Params.RequestIDs[i]
+			}
+			if requestID != 0 {
+				key = fmt.Sprintf("%s\x00%d", key, requestID)
+			}
+			if requestedThisGig[key] {
+				continue
+			}
+			requestedThisGig[key] = true
+			request, ok := requests[key]
+			if !ok {
+				request = &RequestStat{Name: name}
+				if track, found := byID[requestID]; found && requestID != 0 {
+					request.Name, request.Artist = track.Name, track.Artist
+				}
+				requests[key] = request
+			}
+			request.Requested++
+			if (requestID != 0 && playedIDs[requestID]) || (requestID == 0 && playedThisGig[strings.ToLower(name)]) {
+				request.Played++
+			}
+		}
+	}
+
+	for _, song := range songs {
+		stats.Songs = append(stats.Songs, *song)
+	}
+	slices.SortFunc(stats.Songs, func(a, b SongStat) int {
+		if a.Plays != b.Plays {
+			return b.Plays - a.Plays
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, singer := range singers {
+		if totalSeconds > 0 {
+			singer.Share = float64(singer.Seconds) * 100 / float64(totalSeconds)
+		}
+		stats.Singers = append(stats.Singers, *singer)
+	}
+	slices.SortFunc(stats.Singers, func(a, b SingerStat) int {
+		if a.Seconds != b.Seconds {
+			return b.Seconds - a.Seconds
+		}
+		return strings.Compare(a.Singer, b.Singer)
+	})
+	for _, request := range requests {
+		stats.Requests = append(stats.Requests, *request)
+	}
+	slices.SortFunc(stats.Requests, func(a, b RequestStat) int {
+		if a.Requested != b.Requested {
+			return b.Requested - a.Requested
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	stats.Keys = sortedCounts(keys)
+	stats.Bpms = sortedCounts(bpms)
+	for _, track := range tracks {
+		if _, played := songs[trackID(track.Name, track.Artist)]; !played {
+			stats.NeverPlayed = append(stats.NeverPlayed, TrackRef{Name: track.Name, Artist: track.Artist})
+		}
+	}
+	slices.SortFunc(stats.NeverPlayed, func(a, b TrackRef) int { return strings.Compare(a.Name, b.Name) })
+	return stats
+}
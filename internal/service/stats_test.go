@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+func TestComputeStats(t *testing.T) {
+	entry := func(name, artist, singer, key string) SetEntry {
+		return SetEntry{Name: name, Artist: artist, Singer: singer, Key: key, DurationInSeconds: 240}
+	}
+	setlists := []*Setlist{
+		{
+			Params: BuildParams{Requests: []string{"Africa", "Valerie"}},
+			Sets: []Set{{Entries: []SetEntry{
+				entry("Africa", "Toto", "Riley", "A"),
+				entry("Mr. Brightside", "The Killers", "Ty", "Db"),
+				entry("Dreams", "Fleetwood Mac", "Riley", "A"),
+			}}},
+		},
+		{
+			Params: BuildParams{Requests: []string{"africa"}},
+			Sets: []Set{{Entries: []SetEntry{
+				entry("Africa", "Toto", "Riley", "A"),
+			}}},
+		},
+	}
+	tracks := []database.Track{
+		{Name: "Africa", Artist: "Toto", Bpm: 93},
+		{Name: "Mr. Brightside", Artist: "The Killers", Bpm: 148},
+		{Name: "Dreams", Artist: "Fleetwood Mac", Bpm: 0},
+		{Name: "Valerie", Artist: "Amy Winehouse", Bpm: 124},
+	}
+	stats := ComputeStats(setlists, tracks)
+
+	if stats.Builds != 2 {
+		t.Errorf("Expected 2 builds, got %d", stats.Builds)
+	}
+	if len(stats.Songs) != 3 || stats.Songs[0].Name != "Africa" || stats.Songs[0].Plays != 2 {
+		t.Errorf("Expected Africa to be played most, got %v", stats.Songs)
+	}
+	if len(stats.Singers) != 2 {
+		t.Fatalf("Expected 2 singers, got %v", stats.Singers)
+	}
+	riley := stats.Singers[0]
+	if riley.Singer != "Riley" || riley.Gigs != 2 || riley.Songs != 3 || riley.Share != 75 {
+		t.Errorf("Expected Riley to sing 3 songs over 2 gigs for 75%% of the airtime, got %+v", riley)
+	}
+	if len(stats.Requests) != 2 || stats.Requests[0].Requested != 2 || stats.Requests[0].Played != 2 {
+		t.Errorf("Expected Africa to be requested and played twice, got %v", stats.Requests)
+	}
+	if stats.Requests[1].Name != "Valerie" || stats.Requests[1].Played != 0 {
+		t.Errorf("Expected Valerie to be requested but never played, got %v", stats.Requests[1])
+	}
+	if stats.Keys[0] != (CountStat{Value: "A", Count: 3}) {
+		t.Errorf("Expected A to be the most played key, got %v", stats.Keys)
+	}
+	if stats.Bpms[0] != (CountStat{Value: "90-99", Count: 2}) {
+		t.Errorf("Expected 90-99 to be the most played tempo, got %v", stats.Bpms)
+	}
+	if len(stats.NeverPlayed) != 1 || stats.NeverPlayed[0].Name != "Valerie" {
+		t.Errorf("Expected Valerie to be the only song never played, got %v", stats.NeverPlayed)
+	}
+}
+
+func TestRequestStatsByTrackID(t *testing.T) {
+	setlists := []*Setlist{{
+		Params: BuildParams{Requests: []string{"Hallelujah"}, RequestIDs: []int32{2}},
+		Sets:   []Set{{Entries: []SetEntry{{Name: "Hallelujah", Artist: "Leonard Cohen", Singer: "Riley", Key: "C", DurationInSeconds: 240}}}},
+	}}
+	tracks := []database.Track{
+		{ID: 1, Name: "Hallelujah", Artist: "Leonard Cohen"},
+		{ID: 2, Name: "Hallelujah", Artist: "Jeff Buckley"},
+	}
+	stats := ComputeStats(setlists, tracks)
+	if len(stats.Requests) != 1 || stats.Requests[0].Artist != "Jeff Buckley" || stats.Requests[0].Played != 0 {
+		t.Errorf("Expected the Jeff Buckley request not to count as played, got %v", stats.Requests)
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+// Rejection is a track that was considered for a slot and the rule that kept it out.
+type Rejection struct {
+	Name   string `json:"name"`
+	Artist string `json:"artist"`
+	Rule   string `json:"rule"`
+	Reason string `json:"reason"`
+}
+
+// SlotTrace records one attempt to fill the next song of a set. Chosen is nil when every
+// candidate was rejected.
+type SlotTrace struct {
+	Set        int         `json:"set"`
+	Position   int         `json:"position"`
+	Requests   bool        `json:"requests"`
+	Considered []Rejection `json:"considered"`
+	Chosen     *SetEntry   `json:"chosen"`
+}
+
+// Trace is the record of how a build picked its songs, slot by slot.
+type Trace struct {
+	Params BuildParams `json:"params"`
+	Slots  []SlotTrace `json:"slots"`
+}
+
+func (s *SlotTrace) reject(name, artist, rule, reason string) {
+	if s == nil {
+		return
+	}
+	s.Considered = append(s.Considered, Rejection{Name: name, Artist: artist, Rule: rule, Reason: reason})
+}
+
+// saveTrace keeps the trace of the last build so it can be queried later. Only the most recent
+// build is kept, and the old one is only cleared if the new one is saved.
+func saveTrace(ctx context.Context, db *sql.DB, setlist *Setlist, trace *Trace) error {
+	setlistData, setlistErr := json.Marshal(setlist)
+	if setlistErr != nil {
+		return setlistErr
+	}
+	traceData, traceErr := json.Marshal(trace)
+	if traceErr != nil {
+		return traceErr
+	}
+	tx, txErr := db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	defer tx.Rollback()
+	txQueries := database.New(db).WithTx(tx)
+	if clearErr := txQueries.ClearBuildTraces(ctx); clearErr != nil {
+		return clearErr
+	}
+	params := database.CreateBuildTraceParams{
+		Setlist: setlistData,
+		Trace:   traceData,
+	}
+	if createErr := txQueries.CreateBuildTrace(ctx, params); createErr != nil {
+		return createErr
+	}
+	return tx.Commit()
+}
+
+// LoadLastTrace returns the setlist and trace of the most recent build.
+func LoadLastTrace(ctx context.Context, dbQueries *database.Queries) (*Setlist, *Trace, error) {
+	row, getErr := dbQueries.GetLastBuildTrace(ctx)
+	if getErr != nil {
+		return nil, nil, getErr
+	}
+	var setlist Setlist
+	if unmarshalErr := json.Unmarshal(row.Setlist, &setlist); unmarshalErr != nil {
+		return nil, nil, fmt.Errorf("unable to read last setlist: %v", unmarshalErr)
+	}
+	var trace Trace
+	if unmarshalErr := json.Unmarshal(row.Trace, &trace); unmarshalErr != nil {
+		return nil, nil, fmt.Errorf("unable to read last build trace: %v", unmarshalErr)
+	}
+	return &setlist, &trace, nil
+}
+
+// Why explains what happened to a song in a build. The track and its singers come from the
+// library; track is nil when the song isn't in it.
+func Why(setlist *Setlist, trace *Trace, name string, track *database.Track, singers []database.Singer) []string {
+	var id int32
+	if track != nil {
+		name = track.Name
+		id = track.ID
+	}
+	lines := []string{}
+	isRequest := trace.Params.isRequest(name, id)
+	if isRequest {
+		lines = append(lines, fmt.Sprintf("%s was a request.", name))
+	}
+	for i, set := range setlist.Sets {
+		for j, entry := range set.Entries {
+			if track != nil && !sameSong(entry.Name, entry.Artist, track.Name, track.Artist) {
+				continue
+			}
+			if strings.EqualFold(entry.Name, name) {
+				return append(lines, fmt.Sprintf("%s made it: Set %d, song %d, sung by %s in %s.", entry.Name, i+1, j+1, strings.Join(entry.Vocalists(), " & "), entry.Key))
+			}
+		}
+	}
+
+	if track == nil {
+		return append(lines, fmt.Sprintf("%s is not in the library, so the band can't play it.", name))
+	}
+	if trace.Params.isDoNotPlay(track.Name, track.ID) {
+		return append(lines, fmt.Sprintf("%s was on the 'Do Not Play' list.", name))
+	}
+	for _, exclusion := range setlist.LineupExcluded {
+		if exclusion.Name == track.Name && exclusion.Artist == track.Artist {
+			return append(lines, fmt.Sprintf("%s needs %s, who weren't on this gig.", name, strings.Join(exclusion.Missing, " & ")))
+		}
+	}
+	for _, exclusion := range setlist.GenreExcluded {
+		if exclusion.Name == track.Name && exclusion.Artist == track.Artist {
+			return append(lines, fmt.Sprintf("%s is %s, which was excluded from this gig.", name, strings.Join(exclusion.Genres, " & ")))
+		}
+	}
+	if policy := trace.Params.Explicit(); !ExplicitAllowed(policy, track.Explicit, track.CleanVersion) {
+		if policy == ExplicitClean {
+			return append(lines, fmt.Sprintf("%s has explicit lyrics and the band has no clean version of it.", name))
+		}
+		return append(lines, fmt.Sprintf("%s has explicit lyrics and explicit songs weren't allowed.", name))
+	}
+	available := []string{}
+	for _, singer := range singers {
+		if slices.Contains(trace.Params.Singers, singer.Singer) {
+			available = append(available, singer.Singer)
+		}
+	}
+	if len(available) == 0 {
+		return append(lines, fmt.Sprintf("None of the singers for this gig (%s) have a key for %s.", strings.Join(trace.Params.Singers, ", "), name))
+	}
+
+	reasons := map[string]int{}
+	order := []string{}
+	considered := 0
+	for _, slot := range trace.Slots {
+		for _, rejection := range slot.Considered {
+			if rejection.Name != track.Name || rejection.Artist != track.Artist {
+				continue
+			}
+			considered++
+			if reasons[rejection.Reason] == 0 {
+				order = append(order, rejection.Reason)
+			}
+			reasons[rejection.Reason]++
+		}
+	}
+	if considered == 0 {
+		if isRequest {
+			return append(lines, fmt.Sprintf("%s was never tried, the sets filled up before its turn as a request came around.", name))
+		}
+		return append(lines, fmt.Sprintf("%s was never tried, the sets filled up before it came up in the shuffle.", name))
+	}
+	lines = append(lines, fmt.Sprintf("%s was considered %d times and rejected each time:", name, considered))
+	for _, reason := range order {
+		lines = append(lines, fmt.Sprintf(" - %dx %s", reasons[reason], reason))
+	}
+	return lines
+}
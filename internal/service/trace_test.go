@@ -0,0 +1,44 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+func TestWhy(t *testing.T) {
+	chosen := SetEntry{Name: "Africa", Artist: "Toto", Singer: "Riley", Key: "A"}
+	setlist := &Setlist{Sets: []Set{{Entries: []SetEntry{chosen}}}}
+	trace := &Trace{
+		Params: BuildParams{Singers: []string{"Riley"}, Requests: []string{"Valerie"}, DoNotPlays: []string{"Kiss"}},
+		Slots: []SlotTrace{
+			{Considered: []Rejection{{Name: "Valerie", Artist: "Amy Winehouse", Rule: RuleRepeatKey, Reason: "same key"}}, Chosen: &chosen},
+			{Considered: []Rejection{{Name: "Valerie", Artist: "Amy Winehouse", Rule: RuleRepeatKey, Reason: "same key"}}},
+		},
+	}
+	riley := []database.Singer{{Singer: "Riley"}}
+	tests := []struct {
+		name     string
+		song     string
+		track    *database.Track
+		singers  []database.Singer
+		expected string
+	}{
+		{name: "chosen", song: "africa", track: &database.Track{Name: "Africa", Artist: "Toto"}, singers: riley, expected: "Set 1, song 1, sung by Riley in A"},
+		{name: "not in library", song: "Jolene", expected: "not in the library"},
+		{name: "dnp", song: "Kiss", track: &database.Track{Name: "Kiss", Artist: "Prince"}, singers: riley, expected: "'Do Not Play' list"},
+		{name: "explicit", song: "Gold Digger", track: &database.Track{Name: "Gold Digger", Artist: "Kanye West", Explicit: true}, singers: riley, expected: "explicit lyrics"},
+		{name: "no singers", song: "Zombie", track: &database.Track{Name: "Zombie", Artist: "The Cranberries"}, singers: []database.Singer{{Singer: "Ty"}}, expected: "have a key for Zombie"},
+		{name: "rejected", song: "Valerie", track: &database.Track{Name: "Valerie", Artist: "Amy Winehouse"}, singers: riley, expected: "2x same key"},
+		{name: "never tried", song: "Hello", track: &database.Track{Name: "Hello", Artist: "Adele"}, singers: riley, expected: "never tried"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := strings.Join(Why(setlist, trace, tt.song, tt.track, tt.singers), "\n")
+			if !strings.Contains(lines, tt.expected) {
+				t.Errorf("Expected %q in:\n%s", tt.expected, lines)
+			}
+		})
+	}
+}
@@ -0,0 +1,397 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+// DuplicateDurationTolerance is how far apart, in seconds, two versions of a song can run and
+// still count as duplicates. Live versions usually run longer than this.
+const DuplicateDurationTolerance = 10
+
+var (
+	// Spotify puts version tags after a dash ("Song - Remastered 2011").
+	titleSuffixPattern = regexp.MustCompile(`\s+-\s+.*$`)
+	// Other sources put them in brackets ("Song (feat. Someone)", "Song [Radio Edit]").
+	titleTagPattern     = regexp.MustCompile(`(?i)\s*[(\[][^)\]]*\b(remaster(ed)?|version|edit|mix|mono|stereo|feat\.?|ft\.?|featuring|with)\b[^)\]]*[)\]]`)
+	artistFeatPattern   = regexp.MustCompile(`(?i)\s+(feat\.?|ft\.?|featuring|with|x)\s+.*$`)
+	nonAlphanumeric     = regexp.MustCompile(`[^a-z0-9]+`)
+	leadingTheArticle   = regexp.MustCompile(`^the `)
+	ampersandSeparators = strings.NewReplacer("&", " and ", "+", " and ")
+)
+
+// NormalizeTitle reduces a title to the song itself, dropping version tags, featured artists,
+// punctuation and case, so "Shut Up and Dance - Remastered" matches "Shut up & Dance".
+func NormalizeTitle(title string) string {
+	title = titleSuffixPattern.ReplaceAllString(title, "")
+	title = titleTagPattern.ReplaceAllString(title, "")
+	return normalizeWords(title)
+}
+
+// NormalizeArtist reduces an artist to the main one, dropping featured artists and a leading
+// "The".
+func NormalizeArtist(artist string) string {
+	artist = artistFeatPattern.ReplaceAllString(artist, "")
+	return leadingTheArticle.ReplaceAllString(normalizeWords(artist), "")
+}
+
+func normalizeWords(s string) string {
+	s = ampersandSeparators.Replace(strings.ToLower(s))
+	return strings.TrimSpace(nonAlphanumeric.ReplaceAllString(s, " "))
+}
+
+// FindDuplicates groups tracks that look like versions of the same song: the same normalized
+// title and artist, with lengths within DuplicateDurationTolerance of each other. Each group
+// is sorted by ID so the original record comes first.
+func FindDuplicates(tracks []database.Track) [][]database.Track {
+	byKey := map[string][]database.Track{}
+	keys := []string{}
+	for _, track := range tracks {
+		key := NormalizeTitle(track.Name) + "|" + NormalizeArtist(track.Artist)
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], track)
+	}
+	slices.Sort(keys)
+	groups := [][]database.Track{}
+	for _, key := range keys {
+		candidates := byKey[key]
+		slices.SortFunc(candidates, func(a, b database.Track) int { return int(a.DurationInSeconds - b.DurationInSeconds) })
+		group := []database.Track{candidates[0]}
+		for _, track := range candidates[1:] {
+			if track.DurationInSeconds-group[len(group)-1].DurationInSeconds > DuplicateDurationTolerance {
+				groups = appendDuplicateGroup(groups, group)
+				group = []database.Track{}
+			}
+			group = append(group, track)
+		}
+		groups = appendDuplicateGroup(groups, group)
+	}
+	return groups
+}
+
+func appendDuplicateGroup(groups [][]database.Track, group []database.Track) [][]database.Track {
+	if len(group) < 2 {
+		return groups
+	}
+	slices.SortFunc(group, func(a, b database.Track) int { return int(a.ID - b.ID) })
+	return append(groups, group)
+}
+
+// MergedTrack fills in anything keep is missing with what duplicate has, so merging never loses
+// a key, range, clean version or Spotify link that only the duplicate had.
+func MergedTrack(keep, duplicate database.Track) database.Track {
+	if !keep.SpotifyID.Valid {
+		keep.SpotifyID = duplicate.SpotifyID
+	}
+	if !keep.Isrc.Valid {
+		keep.Isrc = duplicate.Isrc
+	}
+	if len(keep.Genre) == 0 {
+		keep.Genre = duplicate.Genre
+	}
+	if keep.Year == "" {
+		keep.Year = duplicate.Year
+	}
+	if keep.Bpm == 0 {
+		keep.Bpm = duplicate.Bpm
+	}
+	if keep.OriginalKey == "" {
+		keep.OriginalKey = duplicate.OriginalKey
+	}
+	if keep.MelodyLow == "" && keep.MelodyHigh == "" {
+		keep.MelodyLow = duplicate.MelodyLow
+		keep.MelodyHigh = duplicate.MelodyHigh
+	}
+	if !keep.CleanVersion {
+		keep.CleanVersion = duplicate.CleanVersion
+	}
+	return keep
+}
+
+// MergeTracks folds duplicate into keep: singer assignments move over (keep's key wins where
+// both have the same singer), missing details are filled in, saved setlists and build traces
+// are pointed at keep, and duplicate is deleted. Run it on transaction queries so a failure
+// part way leaves the library untouched. It returns the merged track and the number of saved
+// setlists changed.
+func MergeTracks(ctx context.Context, dbQueries *database.Queries, duplicate, keep database.Track) (database.Track, int, error) {
+	if duplicate.ID == keep.ID {
+		return keep, 0, fmt.Errorf("can't merge %s - %s into itself", keep.Name, keep.Artist)
+	}
+	moveParams := database.MoveSingersParams{
+		ToTrackID:   keep.ID,
+		FromTrackID: duplicate.ID,
+	}
+	if moveErr := dbQueries.MoveSingers(ctx, moveParams); moveErr != nil {
+		return keep, 0, fmt.Errorf("unable to move singers: %v", moveErr)
+	}
+	partsParams := database.MoveSingerPartsParams{
+		ToTrackID:   keep.ID,
+		FromTrackID: duplicate.ID,
+	}
+	if moveErr := dbQueries.MoveSingerParts(ctx, partsParams); moveErr != nil {
+		return keep, 0, fmt.Errorf("unable to move duet parts: %v", moveErr)
+	}
+	requirementsParams := database.MoveTrackRequirementsParams{
+		ToTrackID:   keep.ID,
+		FromTrackID: duplicate.ID,
+	}
+	if moveErr := dbQueries.MoveTrackRequirements(ctx, requirementsParams); moveErr != nil {
+		return keep, 0, fmt.Errorf("unable to move track requirements: %v", moveErr)
+	}
+	// the duplicate has to go before its Spotify ID and ISRC can move to keep
+	if deleteErr := dbQueries.DeleteTrack(ctx, duplicate.ID); deleteErr != nil {
+		return keep, 0, fmt.Errorf("unable to delete %s - %s: %v", duplicate.Name, duplicate.Artist, deleteErr)
+	}
+	merged := MergedTrack(keep, duplicate)
+	if updateErr := UpdateTrack(ctx, dbQueries, merged); updateErr != nil {
+		return keep, 0, fmt.Errorf("unable to update %s - %s: %v", keep.Name, keep.Artist, updateErr)
+	}
+	changed, historyErr := replaceTrackInHistory(ctx, dbQueries, duplicate, merged)
+	return merged, changed, historyErr
+}
+
+// RenameTrack changes a track's name and artist, along with the saved setlists and build
+// traces that mention it. It returns the renamed track and the number of saved setlists
+// changed.
+func RenameTrack(ctx context.Context, dbQueries *database.Queries, track database.Track, name, artist string) (database.Track, int, error) {
+	existing, getErr := dbQueries.GetTrack(ctx, database.GetTrackParams{Name: name, Artist: artist})
+	if getErr == nil && existing.ID != track.ID {
+		return track, 0, fmt.Errorf("%s - %s is already in the library, use tracks merge to combine them", name, artist)
+	}
+	renamed := track
+	renamed.Name = name
+	renamed.Artist = artist
+	if updateErr := UpdateTrack(ctx, dbQueries, renamed); updateErr != nil {
+		return track, 0, fmt.Errorf("unable to rename %s - %s: %v", track.Name, track.Artist, updateErr)
+	}
+	changed, historyErr := replaceTrackInHistory(ctx, dbQueries, track, renamed)
+	return renamed, changed, historyErr
+}
+
+// UpdateTrack saves every column of track.
+func UpdateTrack(ctx context.Context, dbQueries *database.Queries, track database.Track) error {
+	params := database.UpdateTrackParams{
+		SpotifyID:         track.SpotifyID,
+		Isrc:              track.Isrc,
+		Name:              track.Name,
+		Artist:            track.Artist,
+		Genre:             track.Genre,
+		DurationInSeconds: track.DurationInSeconds,
+		Year:              track.Year,
+		Explicit:          track.Explicit,
+		Bpm:               track.Bpm,
+		OriginalKey:       track.OriginalKey,
+		MelodyLow:         track.MelodyLow,
+		MelodyHigh:        track.MelodyHigh,
+		CleanVersion:      track.CleanVersion,
+		ID:                track.ID,
+	}
+	return dbQueries.UpdateTrack(ctx, params)
+}
+
+// replaceTrackInHistory points saved setlists, build history and build traces that mention
+// from at to.
+func replaceTrackInHistory(ctx context.Context, dbQueries *database.Queries, from, to database.Track) (int, error) {
+	setlists, getErr := dbQueries.GetAllSetlists(ctx)
+	if getErr != nil {
+		return 0, fmt.Errorf("unable to get saved setlists: %v", getErr)
+	}
+	changed := 0
+	for _, saved := range setlists {
+		var setlist Setlist
+		if unmarshalErr := json.Unmarshal(saved.Data, &setlist); unmarshalErr != nil {
+			return changed, fmt.Errorf("unable to read setlist %s: %v", saved.Name, unmarshalErr)
+		}
+		if !setlist.ReplaceTrack(from, to) {
+			continue
+		}
+		data, marshalErr := json.Marshal(setlist)
+		if marshalErr != nil {
+			return changed, marshalErr
+		}
+		params := database.UpdateSetlistDataParams{
+			Data: data,
+			ID:   saved.ID,
+		}
+		if updateErr := dbQueries.UpdateSetlistData(ctx, params); updateErr != nil {
+			return changed, fmt.Errorf("unable to update setlist %s: %v", saved.Name, updateErr)
+		}
+		changed++
+	}
+
+	history, historyErr := dbQueries.GetBuildHistory(ctx)
+	if historyErr != nil {
+		return changed, fmt.Errorf("unable to get build history: %v", historyErr)
+	}
+	for _, row := range history {
+		var setlist Setlist
+		if unmarshalErr := json.Unmarshal(row.Setlist, &setlist); unmarshalErr != nil {
+			return changed, fmt.Errorf("unable to read build %d: %v", row.ID, unmarshalErr)
+		}
+		if !setlist.ReplaceTrack(from, to) {
+			continue
+		}
+		data, marshalErr := json.Marshal(setlist)
+		if marshalErr != nil {
+			return changed, marshalErr
+		}
+		params := database.UpdateBuildHistoryParams{
+			Setlist: data,
+			ID:      row.ID,
+		}
+		if updateErr := dbQueries.UpdateBuildHistory(ctx, params); updateErr != nil {
+			return changed, fmt.Errorf("unable to update build %d: %v", row.ID, updateErr)
+		}
+	}
+
+	traces, tracesErr := dbQueries.GetAllBuildTraces(ctx)
+	if tracesErr != nil {
+		return changed, fmt.Errorf("unable to get build traces: %v", tracesErr)
+	}
+	for _, row := range traces {
+		var setlist Setlist
+		if unmarshalErr := json.Unmarshal(row.Setlist, &setlist); unmarshalErr != nil {
+			return changed, fmt.Errorf("unable to read last setlist: %v", unmarshalErr)
+		}
+		var trace Trace
+		if unmarshalErr := json.Unmarshal(row.Trace, &trace); unmarshalErr != nil {
+			return changed, fmt.Errorf("unable to read last build trace: %v", unmarshalErr)
+		}
+		setlistChanged := setlist.ReplaceTrack(from, to)
+		if !trace.ReplaceTrack(from, to) && !setlistChanged {
+			continue
+		}
+		setlistData, setlistErr := json.Marshal(setlist)
+		if setlistErr != nil {
+			return changed, setlistErr
+		}
+		traceData, traceErr := json.Marshal(trace)
+		if traceErr != nil {
+			return changed, traceErr
+		}
+		params := database.UpdateBuildTraceParams{
+			Setlist: setlistData,
+			Trace:   traceData,
+			ID:      row.ID,
+		}
+		if updateErr := dbQueries.UpdateBuildTrace(ctx, params); updateErr != nil {
+			return changed, fmt.Errorf("unable to update build trace: %v", updateErr)
+		}
+	}
+	return changed, nil
+}
+
+// ReplaceTrack points every entry and request of from at to, reporting whether anything
+// changed. Entries keep their singer, key and length, since those describe how it was played.
+func (s *Setlist) ReplaceTrack(from, to database.Track) bool {
+	changed := s.Params.replaceTrack(from, to)
+	for i := range s.Sets {
+		for j := range s.Sets[i].Entries {
+			entry := &s.Sets[i].Entries[j]
+			if entry.Name == from.Name && entry.Artist == from.Artist {
+				entry.Name = to.Name
+				entry.Artist = to.Artist
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// ReplaceTrack points every rejection and pick of from at to, reporting whether anything
+// changed.
+func (t *Trace) ReplaceTrack(from, to database.Track) bool {
+	changed := t.Params.replaceTrack(from, to)
+	for i := range t.Slots {
+		slot := &t.Slots[i]
+		for j := range slot.Considered {
+			rejection := &slot.Considered[j]
+			if rejection.Name == from.Name && rejection.Artist == from.Artist {
+				rejection.Name = to.Name
+				rejection.Artist = to.Artist
+				changed = true
+			}
+		}
+		if slot.Chosen != nil && slot.Chosen.Name == from.Name && slot.Chosen.Artist == from.Artist {
+			slot.Chosen.Name = to.Name
+			slot.Chosen.Artist = to.Artist
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (p *BuildParams) replaceTrack(from, to database.Track) bool {
+	changed := false
+	for i, id := range p.RequestIDs {
+		if id == from.ID {
+			p.RequestIDs[i] = to.ID
+			if i < len(p.Requests) {
+				p.Requests[i] = to.Name
+			}
+			changed = true
+		}
+	}
+	for i, id := range p.DoNotPlayIDs {
+		if id == from.ID {
+			p.DoNotPlayIDs[i] = to.ID
+			if i < len(p.DoNotPlays) {
+				p.DoNotPlays[i] = to.Name
+			}
+			changed = true
+		}
+	}
+	return changed
+}
+
+// AmbiguousTrackError is returned when a title matches songs by more than one artist, so the
+// track to use can't be told from the title alone.
+type AmbiguousTrackError struct {
+	Name   string
+	Tracks []database.Track
+}
+
+func (e *AmbiguousTrackError) Error() string {
+	matches := []string{}
+	for _, track := range e.Tracks {
+		matches = append(matches, fmt.Sprintf("#%d %s - %s", track.ID, track.Artist, track.Name))
+	}
+	return fmt.Sprintf("%s matches more than one song (%s), please use the track ID or Artist - Title", e.Name, strings.Join(matches, ", "))
+}
+
+// TrackFromName finds the library track with an exact title, ignoring case. When the title isn't
+// found or is shared by more than one artist, input written as "Artist - Title" is tried too.
+// It returns sql.ErrNoRows when nothing matches and an *AmbiguousTrackError instead of picking
+// one of several songs with the same title.
+func TrackFromName(ctx context.Context, dbQueries *database.Queries, input string) (database.Track, error) {
+	tracks, getErr := dbQueries.GetTracksFromName(ctx, input)
+	if getErr != nil {
+		return database.Track{}, getErr
+	}
+	if len(tracks) == 1 {
+		return tracks[0], nil
+	}
+	if artist, title, found := strings.Cut(input, " - "); found {
+		params := database.FindTrackParams{
+			Name:   strings.TrimSpace(title),
+			Artist: strings.TrimSpace(artist),
+		}
+		track, findErr := dbQueries.FindTrack(ctx, params)
+		if findErr != sql.ErrNoRows {
+			return track, findErr
+		}
+	}
+	if len(tracks) == 0 {
+		return database.Track{}, sql.ErrNoRows
+	}
+	return database.Track{}, &AmbiguousTrackError{Name: input, Tracks: tracks}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/rjfeeney/setlist_builder/internal/database"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{title: "Shut Up and Dance", expected: "shut up and dance"},
+		{title: "Shut Up and Dance - Remastered 2014", expected: "shut up and dance"},
+		{title: "Shut Up & Dance", expected: "shut up and dance"},
+		{title: "Uptown Funk (feat. Bruno Mars)", expected: "uptown funk"},
+		{title: "Mr. Brightside [Radio Edit]", expected: "mr brightside"},
+		{title: "Don't Stop Me Now", expected: "don t stop me now"},
+		{title: "Hey Ya! (Live)", expected: "hey ya live"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := NormalizeTitle(tt.title); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeArtist(t *testing.T) {
+	tests := []struct {
+		artist   string
+		expected string
+	}{
+		{artist: "The Killers", expected: "killers"},
+		{artist: "Mark Ronson feat. Bruno Mars", expected: "mark ronson"},
+		{artist: "Earth, Wind & Fire", expected: "earth wind and fire"},
+		{artist: "Lil Nas X", expected: "lil nas x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.artist, func(t *testing.T) {
+			if got := NormalizeArtist(tt.artist); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	tracks := []database.Track{
+		{ID: 3, Name: "Shut Up and Dance - Remastered", Artist: "WALK THE MOON", DurationInSeconds: 200},
+		{ID: 1, Name: "Shut Up and Dance", Artist: "Walk the Moon", DurationInSeconds: 199},
+		{ID: 2, Name: "Valerie", Artist: "Amy Winehouse", DurationInSeconds: 220},
+		{ID: 4, Name: "Valerie - Live", Artist: "Amy Winehouse", DurationInSeconds: 300},
+		{ID: 5, Name: "Africa", Artist: "Toto", DurationInSeconds: 295},
+	}
+	groups := FindDuplicates(tracks)
+	if len(groups) != 1 {
+		t.Fatalf("Expected 1 duplicate group, got %d: %v", len(groups), groups)
+	}
+	if len(groups[0]) != 2 || groups[0][0].ID != 1 || groups[0][1].ID != 3 {
+		t.Errorf("Expected tracks 1 and 3 oldest first, got %v", groups[0])
+	}
+}
+
+func TestMergedTrack(t *testing.T) {
+	keep := database.Track{ID: 1, Name: "Valerie", Artist: "Amy Winehouse", OriginalKey: "Eb"}
+	duplicate := database.Track{
+		ID:           2,
+		SpotifyID:    sql.NullString{String: "abc", Valid: true},
+		Name:         "Valerie - Remastered",
+		Artist:       "Amy Winehouse",
+		OriginalKey:  "C",
+		MelodyLow:    "G3",
+		MelodyHigh:   "C5",
+		CleanVersion: true,
+	}
+	merged := MergedTrack(keep, duplicate)
+	if merged.ID != 1 || merged.Name != "Valerie" || merged.OriginalKey != "Eb" {
+		t.Errorf("Expected keep's own details to win, got %+v", merged)
+	}
+	if merged.SpotifyID.String != "abc" || merged.MelodyLow != "G3" || merged.MelodyHigh != "C5" {
+		t.Errorf("Expected missing details to come from the duplicate, got %+v", merged)
+	}
+	if !merged.CleanVersion {
+		t.Errorf("Expected the duplicate's clean version to be kept, got %+v", merged)
+	}
+	if merged := MergedTrack(duplicate, keep); !merged.CleanVersion {
+		t.Errorf("Expected keep's clean version to stay, got %+v", merged)
+	}
+}
+
+func TestAmbiguousTrackError(t *testing.T) {
+	ambiguous := &AmbiguousTrackError{Name: "Hallelujah", Tracks: []database.Track{
+		{ID: 3, Name: "Hallelujah", Artist: "Leonard Cohen"},
+		{ID: 7, Name: "Hallelujah", Artist: "Jeff Buckley"},
+	}}
+	expected := "Hallelujah matches more than one song (#3 Leonard Cohen - Hallelujah, #7 Jeff Buckley - Hallelujah), please use the track ID or Artist - Title"
+	if ambiguous.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, ambiguous.Error())
+	}
+}
+
+func TestSetlistReplaceTrack(t *testing.T) {
+	from := database.Track{ID: 2, Name: "Valerie - Remastered", Artist: "Amy Winehouse"}
+	to := database.Track{ID: 1, Name: "Valerie", Artist: "Amy Winehouse"}
+	setlist := Setlist{
+		Params: BuildParams{Requests: []string{"Valerie - Remastered"}, RequestIDs: []int32{2}},
+		Sets: []Set{{Entries: []SetEntry{
+			{Name: "Africa", Artist: "Toto", Singer: "Riley", Key: "A"},
+			{Name: "Valerie - Remastered", Artist: "Amy Winehouse", Singer: "Ty", Key: "Eb"},
+		}}},
+	}
+	if !setlist.ReplaceTrack(from, to) {
+		t.Fatal("Expected the setlist to change")
+	}
+	entry := setlist.Sets[0].Entries[1]
+	if entry.Name != "Valerie" || entry.Singer != "Ty" || entry.Key != "Eb" {
+		t.Errorf("Expected the entry to be renamed and keep its singer and key, got %+v", entry)
+	}
+	if setlist.Params.RequestIDs[0] != 1 || setlist.Params.Requests[0] != "Valerie" {
+		t.Errorf("Expected the request to point at the kept track, got %v %v", setlist.Params.Requests, setlist.Params.RequestIDs)
+	}
+	if setlist.ReplaceTrack(from, to) {
+		t.Error("Expected nothing left to change")
+	}
+}
@@ -0,0 +1,182 @@
+package sources
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var csvTitleColumns = []string{"track name", "title", "name", "song", "track"}
+var csvArtistColumns = []string{"artist name(s)", "artist name", "artist", "artists", "creator"}
+var csvExplicitColumns = []string{"explicit"}
+
+// CSVSource reads playlist exports from other apps. The title and artist columns are found by
+// header name, and files without a recognised header are read as artist, title rows.
+type CSVSource struct {
+	Path string
+}
+
+func (s *CSVSource) Description() string {
+	return "CSV file " + s.Path
+}
+
+func (s *CSVSource) Candidates() ([]Candidate, error) {
+	file, openErr := os.Open(s.Path)
+	if openErr != nil {
+		return nil, fmt.Errorf("unable to open %s: %v", s.Path, openErr)
+	}
+	defer file.Close()
+	return readCSVCandidates(file)
+}
+
+func readCSVCandidates(r io.Reader) ([]Candidate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, readErr := reader.ReadAll()
+	if readErr != nil {
+		return nil, fmt.Errorf("unable to read CSV: %v", readErr)
+	}
+	candidates := []Candidate{}
+	if len(records) == 0 {
+		return candidates, nil
+	}
+	header := records[0]
+	titleColumn := findColumn(header, csvTitleColumns)
+	artistColumn := findColumn(header, csvArtistColumns)
+	explicitColumn := findColumn(header, csvExplicitColumns)
+	if titleColumn == -1 {
+		titleColumn, artistColumn, explicitColumn = 1, 0, -1
+	} else {
+		records = records[1:]
+	}
+	for _, record := range records {
+		if titleColumn >= len(record) {
+			continue
+		}
+		candidate := Candidate{Name: strings.TrimSpace(record[titleColumn])}
+		if candidate.Name == "" {
+			continue
+		}
+		if artistColumn != -1 && artistColumn < len(record) {
+			// Multi-artist exports separate artists with commas, the first one is the
+			// primary artist stored in the library.
+			candidate.Artist = strings.TrimSpace(strings.Split(record[artistColumn], ",")[0])
+		}
+		if explicitColumn != -1 && explicitColumn < len(record) {
+			explicit, parseErr := strconv.ParseBool(strings.TrimSpace(record[explicitColumn]))
+			if parseErr == nil {
+				candidate.Explicit = explicit
+				candidate.ExplicitKnown = true
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+func findColumn(header []string, names []string) int {
+	for _, name := range names {
+		for i, column := range header {
+			if strings.TrimSpace(strings.ToLower(column)) == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// M3USource reads extended M3U playlists using the "#EXTINF:duration,Artist - Title" lines,
+// falling back to "Artist - Title" file names for plain M3U files.
+type M3USource struct {
+	Path string
+}
+
+func (s *M3USource) Description() string {
+	return "M3U file " + s.Path
+}
+
+func (s *M3USource) Candidates() ([]Candidate, error) {
+	data, readErr := os.ReadFile(s.Path)
+	if readErr != nil {
+		return nil, fmt.Errorf("unable to read %s: %v", s.Path, readErr)
+	}
+	return parseM3U(string(data)), nil
+}
+
+func parseM3U(data string) []Candidate {
+	candidates := []Candidate{}
+	pendingInfo := ""
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#EXTINF:") {
+			_, info, found := strings.Cut(line, ",")
+			if found {
+				pendingInfo = strings.TrimSpace(info)
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		info := pendingInfo
+		pendingInfo = ""
+		if info == "" {
+			base := filepath.Base(strings.ReplaceAll(line, "\\", "/"))
+			info = strings.TrimSuffix(base, filepath.Ext(base))
+		}
+		artist, title := splitArtistTitle(info)
+		if title != "" {
+			candidates = append(candidates, Candidate{Name: title, Artist: artist})
+		}
+	}
+	return candidates
+}
+
+// XSPFSource reads XML Shareable Playlist Format files.
+type XSPFSource struct {
+	Path string
+}
+
+type xspfPlaylist struct {
+	Tracks []struct {
+		Title   string `xml:"title"`
+		Creator string `xml:"creator"`
+	} `xml:"trackList>track"`
+}
+
+func (s *XSPFSource) Description() string {
+	return "XSPF file " + s.Path
+}
+
+func (s *XSPFSource) Candidates() ([]Candidate, error) {
+	file, openErr := os.Open(s.Path)
+	if openErr != nil {
+		return nil, fmt.Errorf("unable to open %s: %v", s.Path, openErr)
+	}
+	defer file.Close()
+	return readXSPF(file)
+}
+
+func readXSPF(r io.Reader) ([]Candidate, error) {
+	var playlist xspfPlaylist
+	if err := xml.NewDecoder(r).Decode(&playlist); err != nil {
+		return nil, fmt.Errorf("unable to read XSPF: %v", err)
+	}
+	candidates := []Candidate{}
+	for _, track := range playlist.Tracks {
+		title := strings.TrimSpace(track.Title)
+		if title == "" {
+			continue
+		}
+		candidates = append(candidates, Candidate{Name: title, Artist: strings.TrimSpace(track.Creator)})
+	}
+	return candidates, nil
+}
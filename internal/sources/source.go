@@ -0,0 +1,68 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Candidate is a song pulled from a request or 'Do Not Play' list before it has been matched
+// against the library. ExplicitKnown is false for sources that don't carry explicit info.
+type Candidate struct {
+	Name          string `json:"name"`
+	Artist        string `json:"artist"`
+	Explicit      bool   `json:"explicit"`
+	ExplicitKnown bool   `json:"-"`
+}
+
+type RequestSource interface {
+	Description() string
+	Candidates() ([]Candidate, error)
+}
+
+var FileExtensions = []string{".csv", ".m3u", ".m3u8", ".xspf", ".txt"}
+
+// FromInput picks a source for a line typed at the requests/DNP prompts: a Spotify playlist URL
+// or a path to a CSV, M3U, XSPF or text file.
+func FromInput(input string, spotify SpotifySource) (RequestSource, error) {
+	input = strings.TrimSpace(input)
+	if strings.Contains(input, "open.spotify.com/playlist") {
+		spotify.PlaylistURL = strings.Split(input, "?")[0]
+		return &spotify, nil
+	}
+	if strings.Contains(input, "music.apple.com") || strings.Contains(input, "music.youtube.com") || strings.Contains(input, "youtube.com/playlist") {
+		return nil, fmt.Errorf("Apple Music and YouTube Music links can't be read directly, please export the playlist to a CSV/M3U/XSPF file or paste it as a text list")
+	}
+	path := strings.Trim(input, "'\"")
+	ext := strings.ToLower(filepath.Ext(path))
+	info, statErr := os.Stat(path)
+	if statErr != nil || info.IsDir() {
+		return nil, fmt.Errorf("%s is not a Spotify playlist link or a readable file", input)
+	}
+	switch ext {
+	case ".csv":
+		return &CSVSource{Path: path}, nil
+	case ".m3u", ".m3u8":
+		return &M3USource{Path: path}, nil
+	case ".xspf":
+		return &XSPFSource{Path: path}, nil
+	case ".txt":
+		data, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return nil, fmt.Errorf("unable to read %s: %v", path, readErr)
+		}
+		return &TextListSource{Text: string(data)}, nil
+	}
+	return nil, fmt.Errorf("unsupported file type %s, please use one of: %s", ext, strings.Join(FileExtensions, ", "))
+}
+
+// splitArtistTitle splits an "Artist - Title" line. Lines without a separator are treated as a
+// title only.
+func splitArtistTitle(line string) (artist, title string) {
+	artist, title, found := strings.Cut(line, " - ")
+	if !found {
+		return "", strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(artist), strings.TrimSpace(title)
+}
@@ -0,0 +1,96 @@
+package sources
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextListSource(t *testing.T) {
+	text := "# wedding requests\n1. Journey - Don't Stop Believin'\n\n2) Bruno Mars - Uptown Funk\nSeptember\n"
+	source := TextListSource{Text: text}
+	candidates, err := source.Candidates()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Candidate{
+		{Name: "Don't Stop Believin'", Artist: "Journey"},
+		{Name: "Uptown Funk", Artist: "Bruno Mars"},
+		{Name: "September", Artist: ""},
+	}
+	checkCandidates(t, candidates, expected)
+}
+
+func TestReadCSVCandidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		csv      string
+		expected []Candidate
+	}{
+		{
+			name: "exportify header",
+			csv:  "Track URI,Track Name,Artist Name(s),Explicit\nspotify:track:1,Mr. Brightside,\"The Killers,Someone Else\",false\n",
+			expected: []Candidate{
+				{Name: "Mr. Brightside", Artist: "The Killers", Explicit: false, ExplicitKnown: true},
+			},
+		},
+		{
+			name: "title and artist header",
+			csv:  "artist,title\nABBA,Dancing Queen\n",
+			expected: []Candidate{
+				{Name: "Dancing Queen", Artist: "ABBA"},
+			},
+		},
+		{
+			name: "no header",
+			csv:  "Whitney Houston,I Wanna Dance with Somebody\n",
+			expected: []Candidate{
+				{Name: "I Wanna Dance with Somebody", Artist: "Whitney Houston"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates, err := readCSVCandidates(strings.NewReader(tt.csv))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkCandidates(t, candidates, tt.expected)
+		})
+	}
+}
+
+func TestParseM3U(t *testing.T) {
+	m3u := "#EXTM3U\n#EXTINF:215,Toto - Africa\nmusic/africa.mp3\n/home/band/Prince - Kiss.flac\n"
+	expected := []Candidate{
+		{Name: "Africa", Artist: "Toto"},
+		{Name: "Kiss", Artist: "Prince"},
+	}
+	checkCandidates(t, parseM3U(m3u), expected)
+}
+
+func TestReadXSPF(t *testing.T) {
+	xspf := `<?xml version="1.0" encoding="UTF-8"?>
+<playlist version="1" xmlns="http://xspf.org/ns/0/">
+  <trackList>
+    <track><title>Valerie</title><creator>Amy Winehouse</creator></track>
+    <track><title></title><creator>Nobody</creator></track>
+  </trackList>
+</playlist>`
+	candidates, err := readXSPF(strings.NewReader(xspf))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkCandidates(t, candidates, []Candidate{{Name: "Valerie", Artist: "Amy Winehouse"}})
+}
+
+func checkCandidates(t *testing.T, got, expected []Candidate) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d candidates, got %d: %+v", len(expected), len(got), got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Expected candidate %d to be %+v, got %+v", i, expected[i], got[i])
+		}
+	}
+}
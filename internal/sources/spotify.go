@@ -0,0 +1,57 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rjfeeney/setlist_builder/extract"
+)
+
+// SpotifySource reads a Spotify playlist through spotdl. The playlist data is saved to a temp
+// directory inside Dir that is removed once the candidates have been read.
+type SpotifySource struct {
+	ClientID     string
+	ClientSecret string
+	Dir          string
+	PlaylistURL  string
+}
+
+func (s *SpotifySource) Description() string {
+	return "Spotify playlist " + s.PlaylistURL
+}
+
+func (s *SpotifySource) Candidates() ([]Candidate, error) {
+	tempDir, tempErr := os.MkdirTemp(s.Dir, "requests")
+	if tempErr != nil {
+		return nil, fmt.Errorf("couldn't create temp directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	config := extract.SpotifyConfig{
+		ClientID:     s.ClientID,
+		ClientSecret: s.ClientSecret,
+		TempDir:      tempDir,
+		PlaylistURL:  s.PlaylistURL,
+		DB:           nil,
+	}
+
+	extractor := extract.NewExtractor(config)
+
+	if err := extractor.ExtractMetaDataSpotdl(); err != nil {
+		return nil, err
+	}
+
+	tracks, err := extractor.ReadSpotdlData()
+	if err != nil {
+		return nil, err
+	}
+	candidates := []Candidate{}
+	for _, track := range *tracks {
+		candidates = append(candidates, Candidate{
+			Name:          track.Name,
+			Artist:        track.Artist,
+			Explicit:      track.Explicit,
+			ExplicitKnown: true,
+		})
+	}
+	return candidates, nil
+}
@@ -0,0 +1,46 @@
+package sources
+
+import (
+	"bufio"
+	"strings"
+	"unicode"
+)
+
+// TextListSource reads a pasted list with one "Artist - Title" per line. Blank lines, lines
+// starting with '#' and list numbering such as "1." or "2)" are ignored.
+type TextListSource struct {
+	Text string
+}
+
+func (s *TextListSource) Description() string {
+	return "text list"
+}
+
+func (s *TextListSource) Candidates() ([]Candidate, error) {
+	candidates := []Candidate{}
+	scanner := bufio.NewScanner(strings.NewReader(s.Text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = trimNumbering(line)
+		artist, title := splitArtistTitle(line)
+		if title == "" {
+			continue
+		}
+		candidates = append(candidates, Candidate{Name: title, Artist: artist})
+	}
+	return candidates, scanner.Err()
+}
+
+func trimNumbering(line string) string {
+	i := 0
+	for i < len(line) && unicode.IsDigit(rune(line[i])) {
+		i++
+	}
+	if i == 0 || i == len(line) || (line[i] != '.' && line[i] != ')') {
+		return line
+	}
+	return strings.TrimSpace(line[i+1:])
+}
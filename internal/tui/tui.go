@@ -0,0 +1,694 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rjfeeney/setlist_builder/internal/constants"
+	"github.com/rjfeeney/setlist_builder/internal/database"
+	"github.com/rjfeeney/setlist_builder/internal/service"
+	"github.com/rjfeeney/setlist_builder/internal/sources"
+)
+
+type pane int
+
+const (
+	paramsPane pane = iota
+	libraryPane
+	setsPane
+)
+
+const (
+	durationField = iota
+	singersField
+	explicitField
+	requestsField
+	dnpField
+)
+
+var (
+	paneStyle      = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	focusedStyle   = paneStyle.BorderForeground(lipgloss.Color("63"))
+	titleStyle     = lipgloss.NewStyle().Bold(true)
+	cursorStyle    = lipgloss.NewStyle().Reverse(true)
+	violationStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	dimStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+)
+
+type field struct {
+	label string
+	value string
+	hint  string
+}
+
+type libraryLoadedMsg struct {
+	library *service.Library
+	err     error
+}
+
+type builtMsg struct {
+	setlist *service.Setlist
+	err     error
+}
+
+type Model struct {
+	db      *sql.DB
+	library *service.Library
+	focus   pane
+	width   int
+	height  int
+
+	fields      []field
+	fieldCursor int
+	editing     bool
+	editValue   string
+
+	search       string
+	searching    bool
+	singerFilter string
+	genreFilter  string
+	keyFilter    string
+	libCursor    int
+
+	setlist    *service.Setlist
+	violations []service.Violation
+	setCursor  int
+	songCursor int
+
+	status    string
+	statusErr bool
+	building  bool
+}
+
+func New(db *sql.DB) Model {
+	return Model{
+		db: db,
+		fields: []field{
+			durationField: {label: "Duration", value: "120", hint: "minutes, up to 180"},
+			singersField:  {label: "Singers", value: "", hint: "comma separated, e.g. riley, ty"},
+			explicitField: {label: "Explicit", value: "no", hint: "enter to change: no, clean edits only or yes"},
+			requestsField: {label: "Requests", value: "", hint: "file, Spotify link, or Artist - Title; ..."},
+			dnpField:      {label: "DNPs", value: "", hint: "file, Spotify link, or Artist - Title; ..."},
+		},
+		status: "Loading library...",
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.loadLibrary
+}
+
+func (m Model) loadLibrary() tea.Msg {
+	library, loadErr := service.LoadLibrary(context.Background(), database.New(m.db))
+	return libraryLoadedMsg{library: library, err: loadErr}
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case libraryLoadedMsg:
+		if msg.err != nil {
+			m.setStatus(msg.err.Error(), true)
+			return m, nil
+		}
+		m.library = msg.library
+		m.setStatus(fmt.Sprintf("Loaded %d tracks with singers. Fill in the parameters and press ctrl+b to build.", len(m.library.Tracks)), false)
+		return m, nil
+	case builtMsg:
+		m.building = false
+		if msg.err != nil {
+			m.setStatus(fmt.Sprintf("build failed: %v", msg.err), true)
+			return m, nil
+		}
+		m.setlist = msg.setlist
+		m.setCursor, m.songCursor = 0, 0
+		m.focus = setsPane
+		m.validate()
+		m.setStatus(fmt.Sprintf("Built %d sets with %d/%d requests.", len(m.setlist.Sets), m.setlist.RequestsIncluded, m.setlist.RequestsTotal), false)
+		return m, nil
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *Model) setStatus(status string, isErr bool) {
+	m.status = status
+	m.statusErr = isErr
+}
+
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+	if m.editing {
+		return m.handleEditKey(msg), nil
+	}
+	if m.searching {
+		return m.handleSearchKey(msg), nil
+	}
+	switch key {
+	case "q":
+		return m, tea.Quit
+	case "tab":
+		m.focus = (m.focus + 1) % 3
+		return m, nil
+	case "shift+tab":
+		m.focus = (m.focus + 2) % 3
+		return m, nil
+	case "ctrl+b":
+		return m.startBuild()
+	}
+	switch m.focus {
+	case paramsPane:
+		return m.handleParamsKey(key)
+	case libraryPane:
+		return m.handleLibraryKey(key), nil
+	default:
+		return m.handleSetsKey(key), nil
+	}
+}
+
+func (m Model) handleEditKey(msg tea.KeyMsg) Model {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.fields[m.fieldCursor].value = strings.TrimSpace(m.editValue)
+		m.editing = false
+	case tea.KeyEsc:
+		m.editing = false
+	case tea.KeyBackspace:
+		if len(m.editValue) > 0 {
+			runes := []rune(m.editValue)
+			m.editValue = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		m.editValue += " "
+	case tea.KeyRunes:
+		m.editValue += string(msg.Runes)
+	}
+	return m
+}
+
+func (m Model) handleSearchKey(msg tea.KeyMsg) Model {
+	switch msg.Type {
+	case tea.KeyEnter, tea.KeyEsc:
+		m.searching = false
+	case tea.KeyBackspace:
+		if len(m.search) > 0 {
+			runes := []rune(m.search)
+			m.search = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		m.search += " "
+	case tea.KeyRunes:
+		m.search += string(msg.Runes)
+	}
+	m.libCursor = 0
+	return m
+}
+
+func (m Model) handleParamsKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "up", "k":
+		m.fieldCursor = max(0, m.fieldCursor-1)
+	case "down", "j":
+		m.fieldCursor = min(len(m.fields)-1, m.fieldCursor+1)
+	case "enter", " ":
+		if m.fieldCursor == explicitField {
+			switch m.fields[explicitField].value {
+			case "no":
+				m.fields[explicitField].value = "clean"
+			case "clean":
+				m.fields[explicitField].value = "yes"
+			default:
+				m.fields[explicitField].value = "no"
+			}
+			return m, nil
+		}
+		m.editing = true
+		m.editValue = m.fields[m.fieldCursor].value
+	}
+	return m, nil
+}
+
+func (m Model) startBuild() (tea.Model, tea.Cmd) {
+	if m.building {
+		return m, nil
+	}
+	input, inputErr := m.buildInput()
+	if inputErr != nil {
+		m.setStatus(inputErr.Error(), true)
+		return m, nil
+	}
+	m.building = true
+	m.setStatus("Building...", false)
+	db := m.db
+	requests, dnps := m.fields[requestsField].value, m.fields[dnpField].value
+	// playlist links can mean network calls and downloads, so the sources are read with the build
+	// instead of holding up the UI
+	return m, func() tea.Msg {
+		var sourceErr error
+		input.Requests, sourceErr = readSourceField(requests)
+		if sourceErr != nil {
+			return builtMsg{err: fmt.Errorf("requests: %v", sourceErr)}
+		}
+		input.DoNotPlays, sourceErr = readSourceField(dnps)
+		if sourceErr != nil {
+			return builtMsg{err: fmt.Errorf("DNPs: %v", sourceErr)}
+		}
+		setlist, buildErr := service.NewBuilder(db, io.Discard).BuildFromInput(context.Background(), input)
+		return builtMsg{setlist: setlist, err: buildErr}
+	}
+}
+
+func (m Model) buildInput() (service.BuildInput, error) {
+	input := service.BuildInput{ExplicitPolicy: service.ExplicitExclude}
+	switch m.fields[explicitField].value {
+	case "yes":
+		input.ExplicitPolicy = service.ExplicitAllow
+	case "clean":
+		input.ExplicitPolicy = service.ExplicitClean
+	}
+	duration, durationErr := strconv.Atoi(m.fields[durationField].value)
+	if durationErr != nil || duration <= 0 {
+		return input, fmt.Errorf("duration must be a positive number of minutes")
+	}
+	input.Duration = int32(duration)
+	for _, singer := range strings.Split(m.fields[singersField].value, ",") {
+		if singer = strings.TrimSpace(singer); singer != "" {
+			input.Singers = append(input.Singers, singer)
+		}
+	}
+	return input, nil
+}
+
+// readSourceField reads a requests or DNP field. Links and file paths are read the same way as
+// the build prompts, anything else is a list of "Artist - Title" songs separated by semicolons.
+func readSourceField(value string) ([]sources.Candidate, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var source sources.RequestSource
+	if _, statErr := os.Stat(strings.Trim(value, "'\"")); statErr == nil || strings.Contains(value, "://") {
+		wd, _ := os.Getwd()
+		spotify := sources.SpotifySource{
+			ClientID:     os.Getenv("SPOTIFY_ID"),
+			ClientSecret: os.Getenv("SPOTIFY_SECRET"),
+			Dir:          wd,
+		}
+		var sourceErr error
+		source, sourceErr = sources.FromInput(value, spotify)
+		if sourceErr != nil {
+			return nil, sourceErr
+		}
+	} else {
+		source = &sources.TextListSource{Text: strings.ReplaceAll(value, ";", "\n")}
+	}
+	return source.Candidates()
+}
+
+// filteredTracks returns the library tracks matching the search and filters.
+func (m Model) filteredTracks() []*service.LibraryTrack {
+	if m.library == nil {
+		return nil
+	}
+	search := strings.ToLower(m.search)
+	var tracks []*service.LibraryTrack
+	for _, track := range m.library.Tracks {
+		if search != "" && !strings.Contains(strings.ToLower(track.Name+" "+track.Artist), search) {
+			continue
+		}
+		if m.singerFilter != "" && track.Keys[m.singerFilter] == "" {
+			continue
+		}
+		if m.genreFilter != "" && !slices.Contains(track.Genre, m.genreFilter) {
+			continue
+		}
+		if m.keyFilter != "" {
+			keys := []string{track.OriginalKey}
+			if m.singerFilter != "" {
+				keys = []string{track.Keys[m.singerFilter]}
+			} else {
+				for _, key := range track.Keys {
+					keys = append(keys, key)
+				}
+			}
+			if !slices.ContainsFunc(keys, func(key string) bool { return strings.EqualFold(key, m.keyFilter) }) {
+				continue
+			}
+		}
+		tracks = append(tracks, track)
+	}
+	return tracks
+}
+
+func (m Model) genres() []string {
+	genres := []string{}
+	if m.library == nil {
+		return genres
+	}
+	for _, track := range m.library.Tracks {
+		for _, genre := range track.Genre {
+			if !slices.Contains(genres, genre) {
+				genres = append(genres, genre)
+			}
+		}
+	}
+	slices.Sort(genres)
+	return genres
+}
+
+// cycle returns the option after current, going back to no filter after the last one.
+func cycle(options []string, current string) string {
+	i := slices.Index(options, current)
+	if i == len(options)-1 {
+		return ""
+	}
+	return options[i+1]
+}
+
+func capitalized(list []string) []string {
+	result := []string{}
+	for _, item := range list {
+		result = append(result, constants.Capitalize(item))
+	}
+	return result
+}
+
+func (m Model) handleLibraryKey(key string) Model {
+	tracks := m.filteredTracks()
+	switch key {
+	case "up", "k":
+		m.libCursor = max(0, m.libCursor-1)
+	case "down", "j":
+		m.libCursor = max(0, min(len(tracks)-1, m.libCursor+1))
+	case "/":
+		m.searching = true
+	case "s":
+		m.singerFilter = cycle(capitalized(constants.ValidSingers), m.singerFilter)
+		m.libCursor = 0
+	case "g":
+		m.genreFilter = cycle(m.genres(), m.genreFilter)
+		m.libCursor = 0
+	case "K":
+		m.keyFilter = cycle(capitalized(constants.ValidKeys), m.keyFilter)
+		m.libCursor = 0
+	case "c":
+		m.search, m.singerFilter, m.genreFilter, m.keyFilter = "", "", "", ""
+		m.libCursor = 0
+	case "enter", "a":
+		if m.libCursor >= len(tracks) || m.setlist == nil {
+			m.setStatus("Build a setlist first, then pick a song in the sets pane to replace", true)
+			return m
+		}
+		m.useTrack(tracks[m.libCursor], key == "a")
+	}
+	return m
+}
+
+// useTrack replaces the selected song with a library track, or adds it after the selected song.
+func (m *Model) useTrack(track *service.LibraryTrack, insert bool) {
+	set := &m.setlist.Sets[m.setCursor]
+	if insert {
+		set.Entries = slices.Insert(set.Entries, min(m.songCursor+1, len(set.Entries)), service.SetEntry{})
+		if len(set.Entries) > 1 {
+			m.songCursor = min(m.songCursor+1, len(set.Entries)-1)
+		}
+	}
+	if len(set.Entries) == 0 {
+		m.setStatus("The selected set is empty, add a song with 'a' instead", true)
+		return
+	}
+	entry, entryErr := service.BestEntry(m.setlist, m.library, m.setCursor, m.songCursor, track)
+	if entryErr == nil {
+		entryErr = service.ReplaceSong(m.setlist, m.setCursor, m.songCursor, entry)
+	}
+	if entryErr != nil {
+		if insert {
+			set.Entries = slices.Delete(set.Entries, m.songCursor, m.songCursor+1)
+			m.songCursor = max(0, m.songCursor-1)
+		}
+		m.setStatus(entryErr.Error(), true)
+		return
+	}
+	m.validate()
+	m.setStatus(fmt.Sprintf("Set %d, song %d is now %s", m.setCursor+1, m.songCursor+1, entry), false)
+}
+
+func (m Model) handleSetsKey(key string) Model {
+	if m.setlist == nil {
+		return m
+	}
+	entries := m.setlist.Sets[m.setCursor].Entries
+	switch key {
+	case "up", "k":
+		m.songCursor = max(0, m.songCursor-1)
+	case "down", "j":
+		m.songCursor = max(0, min(len(entries)-1, m.songCursor+1))
+	case "left", "h":
+		m.setCursor = max(0, m.setCursor-1)
+		m.songCursor = 0
+	case "right", "l":
+		m.setCursor = min(len(m.setlist.Sets)-1, m.setCursor+1)
+		m.songCursor = 0
+	case "K", "J":
+		target := m.songCursor - 1
+		if key == "J" {
+			target = m.songCursor + 1
+		}
+		if len(entries) == 0 || target < 0 || target >= len(entries) {
+			return m
+		}
+		m.move(m.setCursor, target)
+	case "[", "]":
+		target := m.setCursor - 1
+		if key == "]" {
+			target = m.setCursor + 1
+		}
+		if len(entries) == 0 || target < 0 || target >= len(m.setlist.Sets) {
+			return m
+		}
+		m.move(target, len(m.setlist.Sets[target].Entries))
+	case " ", "L":
+		if len(entries) == 0 {
+			return m
+		}
+		entries[m.songCursor].Locked = !entries[m.songCursor].Locked
+	case "s":
+		if len(entries) == 0 {
+			return m
+		}
+		entry, swapErr := service.SwapSong(m.setlist, m.library, m.setCursor, m.songCursor)
+		if swapErr != nil {
+			m.setStatus(swapErr.Error(), true)
+			return m
+		}
+		m.validate()
+		m.setStatus(fmt.Sprintf("Swapped in %s", entry), false)
+	case "r":
+		m.regenerate([]int{m.setCursor})
+	case "R":
+		sets := []int{}
+		for i := range m.setlist.Sets {
+			sets = append(sets, i)
+		}
+		m.regenerate(sets)
+	}
+	return m
+}
+
+func (m *Model) move(toSet, toPosition int) {
+	if moveErr := service.MoveSong(m.setlist, m.setCursor, m.songCursor, toSet, toPosition); moveErr != nil {
+		m.setStatus(moveErr.Error(), true)
+		return
+	}
+	m.setCursor = toSet
+	m.songCursor = min(toPosition, len(m.setlist.Sets[toSet].Entries)-1)
+	m.validate()
+}
+
+func (m *Model) regenerate(sets []int) {
+	warnings := len(m.setlist.Warnings)
+	for _, set := range sets {
+		if regenErr := service.RegenerateSet(m.setlist, m.library, set); regenErr != nil {
+			m.setStatus(regenErr.Error(), true)
+			return
+		}
+	}
+	m.songCursor = min(m.songCursor, max(0, len(m.setlist.Sets[m.setCursor].Entries)-1))
+	m.validate()
+	if len(m.setlist.Warnings) > warnings {
+		m.setStatus(strings.Join(m.setlist.Warnings[warnings:], " "), true)
+		return
+	}
+	m.setStatus("Regenerated unlocked songs", false)
+}
+
+func (m *Model) validate() {
+	m.violations = service.Validate(m.setlist, m.library)
+}
+
+func (m Model) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+	paneHeight := max(5, m.height-4)
+	leftWidth := max(30, m.width/4)
+	middleWidth := max(30, m.width/3)
+	rightWidth := max(30, m.width-leftWidth-middleWidth-6)
+
+	panes := []string{
+		m.renderPane(paramsPane, "Parameters", m.paramsLines(), leftWidth, paneHeight),
+		m.renderPane(libraryPane, "Library", m.libraryLines(paneHeight-3), middleWidth, paneHeight),
+		m.renderPane(setsPane, "Sets", m.setsLines(paneHeight-3), rightWidth, paneHeight),
+	}
+	status := m.status
+	if m.statusErr {
+		status = violationStyle.Render(status)
+	}
+	help := dimStyle.Render("tab: switch pane · ctrl+b: build · q: quit · " + m.paneHelp())
+	return lipgloss.JoinVertical(lipgloss.Left, lipgloss.JoinHorizontal(lipgloss.Top, panes...), status, help)
+}
+
+func (m Model) paneHelp() string {
+	switch m.focus {
+	case paramsPane:
+		return "enter: edit field"
+	case libraryPane:
+		return "/: search · s/g/K: filter singer/genre/key · c: clear · enter: replace song · a: add after song"
+	default:
+		return "←→: set · J/K: move · [/]: move to set · s: swap · space: lock · r: regenerate set · R: regenerate all"
+	}
+}
+
+func (m Model) renderPane(p pane, title string, lines []string, width, height int) string {
+	style := paneStyle
+	if m.focus == p {
+		style = focusedStyle
+	}
+	content := titleStyle.Render(title) + "\n" + strings.Join(lines, "\n")
+	return style.Width(width).Height(height).MaxHeight(height + 2).Render(content)
+}
+
+func (m Model) paramsLines() []string {
+	lines := []string{}
+	for i, f := range m.fields {
+		value := f.value
+		if m.editing && i == m.fieldCursor {
+			value = m.editValue + "▏"
+		}
+		line := fmt.Sprintf("%s: %s", f.label, value)
+		if i == m.fieldCursor && m.focus == paramsPane {
+			line = cursorStyle.Render(line)
+		}
+		lines = append(lines, line, dimStyle.Render("  "+f.hint))
+	}
+	if m.building {
+		lines = append(lines, "", "Building...")
+	}
+	return lines
+}
+
+func (m Model) libraryLines(height int) []string {
+	filters := []string{}
+	if m.search != "" || m.searching {
+		filters = append(filters, "search: "+m.search)
+	}
+	if m.singerFilter != "" {
+		filters = append(filters, "singer: "+m.singerFilter)
+	}
+	if m.genreFilter != "" {
+		filters = append(filters, "genre: "+m.genreFilter)
+	}
+	if m.keyFilter != "" {
+		filters = append(filters, "key: "+m.keyFilter)
+	}
+	header := dimStyle.Render("no filters")
+	if len(filters) > 0 {
+		header = strings.Join(filters, " · ")
+	}
+
+	tracks := m.filteredTracks()
+	lines := []string{}
+	for i, track := range tracks {
+		singers := []string{}
+		for _, singer := range capitalized(constants.ValidSingers) {
+			if key, found := track.Keys[singer]; found {
+				singers = append(singers, singer+" "+key)
+			}
+		}
+		line := fmt.Sprintf("%s - %s (%s)", track.Name, track.Artist, strings.Join(singers, ", "))
+		if i == m.libCursor && m.focus == libraryPane {
+			line = cursorStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return append([]string{header}, window(lines, m.libCursor, height-1)...)
+}
+
+func (m Model) setsLines(height int) []string {
+	if m.setlist == nil {
+		return []string{dimStyle.Render("No setlist yet, press ctrl+b to build one")}
+	}
+	problems := map[[2]int][]string{}
+	for _, violation := range m.violations {
+		id := [2]int{violation.Set, violation.Position}
+		problems[id] = append(problems[id], violation.Message)
+	}
+	lines := []string{}
+	cursorLine := 0
+	for i, set := range m.setlist.Sets {
+		duration := set.DurationInSeconds()
+		lines = append(lines, titleStyle.Render(fmt.Sprintf("Set %d (%d:%02d / %d:00)", i+1, duration/60, duration%60, set.TargetMinutes)))
+		for _, message := range problems[[2]int{i, -1}] {
+			lines = append(lines, violationStyle.Render("  ! "+message))
+		}
+		for j, entry := range set.Entries {
+			marker := "  "
+			if entry.Locked {
+				marker = "🔒"
+			}
+			line := fmt.Sprintf("%s%d: %s", marker, j+1, entry)
+			if entry.Request {
+				line += " (request)"
+			}
+			messages := problems[[2]int{i, j}]
+			if len(messages) > 0 {
+				line = violationStyle.Render(line)
+			}
+			if i == m.setCursor && j == m.songCursor {
+				cursorLine = len(lines)
+				if m.focus == setsPane {
+					line = cursorStyle.Render(line)
+				}
+			}
+			lines = append(lines, line)
+			for _, message := range messages {
+				lines = append(lines, violationStyle.Render("    ! "+message))
+			}
+		}
+		lines = append(lines, "")
+	}
+	lines = append(lines, fmt.Sprintf("Requests Included: %d/%d · Violations: %d", m.setlist.RequestsIncluded, m.setlist.RequestsTotal, len(m.violations)))
+	return window(lines, cursorLine, height)
+}
+
+// window returns the lines that fit in height, scrolled to keep the cursor line visible.
+func window(lines []string, cursor, height int) []string {
+	if height <= 0 || len(lines) <= height {
+		return lines
+	}
+	start := max(0, min(cursor-height/2, len(lines)-height))
+	return lines[start : start+height]
+}
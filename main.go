@@ -2,6 +2,7 @@ package main
 
 import (
 	"database/sql"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 	"github.com/rjfeeney/setlist_builder/internal/cli"
+	"github.com/rjfeeney/setlist_builder/internal/service"
 )
 
 func main() {
@@ -35,10 +37,26 @@ func main() {
 	}
 	defer db.Close()
 
+	// help, migrate and manual database access have to work on an old schema to fix it
+	if command != "help" && command != "migrate" && command != "database" {
+		if err := cli.CheckSchema(db); err != nil {
+			log.Fatalf("Database schema check failed: %v", err)
+		}
+	}
+
 	switch command {
 	case "help":
 		cli.RunHelp()
 
+	case "migrate":
+		if len(args) != 1 {
+			log.Fatal("Usage: ./setlist migrate [up|down|status]")
+		}
+		err := cli.RunMigrate(db, args[0])
+		if err != nil {
+			log.Fatalf("migrate failed: %v", err)
+		}
+
 	case "extract":
 		if len(args) < 1 {
 			log.Fatal("Usage: ./setlist extract <spotify_playlist_url>\nPlease input a Spotify playlist URL")
@@ -51,12 +69,167 @@ func main() {
 			log.Fatalf("extract failed: %v", err)
 		}
 
+	case "import-audio":
+		if len(args) != 1 {
+			log.Fatal("Usage: ./setlist import-audio [directory]\nPlease input a folder of MP3, FLAC or WAV files")
+		}
+		err := cli.RunImportAudio(db, args[0])
+		if err != nil {
+			log.Fatalf("audio import failed: %v", err)
+		}
+
+	case "library":
+		if len(args) < 1 {
+			log.Fatal("Usage: ./setlist library export {--format csv|json} {--output file}\n       ./setlist library import [file] {--dry-run}")
+		}
+		switch args[0] {
+		case "export":
+			flags := flag.NewFlagSet("library export", flag.ExitOnError)
+			format := flags.String("format", "csv", "export format: csv or json")
+			output := flags.String("output", "", "file to write to (defaults to the terminal)")
+			parseFlags(flags, args[1:])
+			err := cli.RunLibraryExport(db, *format, *output)
+			if err != nil {
+				log.Fatalf("library export failed: %v", err)
+			}
+		case "import":
+			flags := flag.NewFlagSet("library import", flag.ExitOnError)
+			dryRun := flags.Bool("dry-run", false, "show the changes without saving them")
+			files := parseFlags(flags, args[1:])
+			if len(files) != 1 {
+				log.Fatal("Usage: ./setlist library import [file] {--dry-run}")
+			}
+			err := cli.RunLibraryImport(db, files[0], *dryRun)
+			if err != nil {
+				log.Fatalf("library import failed: %v", err)
+			}
+		default:
+			log.Fatal("Usage: ./setlist library export {--format csv|json} {--output file}\n       ./setlist library import [file] {--dry-run}")
+		}
+
 	case "list":
-		err := cli.RunList(db)
+		flags := flag.NewFlagSet("list", flag.ExitOnError)
+		options := cli.ListOptions{}
+		flags.StringVar(&options.Singer, "singer", "", "only songs this singer has a key for")
+		flags.StringVar(&options.Key, "key", "", "only songs in this original key")
+		flags.StringVar(&options.Genre, "genre", "", "only songs with a genre containing this")
+		flags.StringVar(&options.Bpm, "bpm", "", "BPM range, e.g. 100-130")
+		flags.StringVar(&options.Year, "year", "", "year range, e.g. 1980-1989")
+		flags.Bool("explicit", false, "only explicit songs, or with --explicit=false only clean songs")
+		flags.BoolVar(&options.MissingKey, "missing-key", false, "only songs with no original key")
+		flags.BoolVar(&options.NoSinger, "no-singer", false, "only songs with no singers")
+		flags.StringVar(&options.Sort, "sort", "name", "id, name, artist, duration, year, bpm or key")
+		flags.BoolVar(&options.Descending, "desc", false, "sort in descending order")
+		flags.StringVar(&options.Columns, "columns", "name,artist", "comma separated columns to show")
+		flags.StringVar(&options.Format, "format", "table", "table, csv or json")
+		options.Text = strings.Join(parseFlags(flags, args), " ")
+		flags.Visit(func(f *flag.Flag) {
+			if f.Name == "explicit" {
+				options.Explicit = f.Value.String()
+			}
+		})
+		err := cli.RunList(db, options)
 		if err != nil {
 			log.Fatalf("list failed: %v", err)
 		}
 
+	case "tracks":
+		tracksUsage := "Usage: ./setlist tracks show [song]\n       ./setlist tracks edit [song] {--duration 4:05} {--year 1985} {--genre a,b} {--explicit=false} {--clean} {--bpm 124} {--key Eb}\n       ./setlist tracks dedupe\n       ./setlist tracks merge [duplicate] [keep]\n       ./setlist tracks rename [track] [new name] {--artist name}\n       ./setlist tracks requires [song] {--add horns,keys} {--remove keys}"
+		if len(args) == 0 {
+			log.Fatal(tracksUsage)
+		}
+		switch args[0] {
+		case "show":
+			if len(args) != 2 {
+				log.Fatal(tracksUsage)
+			}
+			err := cli.RunTracksShow(db, args[1])
+			if err != nil {
+				log.Fatalf("error showing track: %v", err)
+			}
+		case "edit":
+			flags := flag.NewFlagSet("tracks edit", flag.ExitOnError)
+			flags.String("duration", "", "length as seconds or minutes:seconds")
+			flags.String("year", "", "release year")
+			flags.String("genre", "", "comma separated genres, replacing the current ones")
+			flags.Bool("explicit", false, "whether the song is explicit")
+			flags.Bool("clean", false, "whether the band has a clean version of an explicit song")
+			flags.String("bpm", "", "tempo in beats per minute")
+			flags.String("key", "", "original key")
+			positional := parseFlags(flags, args[1:])
+			if len(positional) != 1 {
+				log.Fatal(tracksUsage)
+			}
+			// only the flags given are changed, with none given every field is prompted for
+			edits := map[string]string{}
+			flags.Visit(func(f *flag.Flag) {
+				edits[f.Name] = f.Value.String()
+			})
+			err := cli.RunTracksEdit(db, positional[0], edits)
+			if err != nil {
+				log.Fatalf("error editing track: %v", err)
+			}
+		case "dedupe":
+			err := cli.RunTracksDedupe(db)
+			if err != nil {
+				log.Fatalf("error finding duplicates: %v", err)
+			}
+		case "merge":
+			if len(args) != 3 {
+				log.Fatal(tracksUsage)
+			}
+			err := cli.RunTracksMerge(db, args[1], args[2])
+			if err != nil {
+				log.Fatalf("error merging tracks: %v", err)
+			}
+		case "rename":
+			flags := flag.NewFlagSet("tracks rename", flag.ExitOnError)
+			artist := flags.String("artist", "", "new artist, defaults to the current one")
+			positional := parseFlags(flags, args[1:])
+			if len(positional) != 2 {
+				log.Fatal(tracksUsage)
+			}
+			err := cli.RunTracksRename(db, positional[0], positional[1], *artist)
+			if err != nil {
+				log.Fatalf("error renaming track: %v", err)
+			}
+		case "requires":
+			flags := flag.NewFlagSet("tracks requires", flag.ExitOnError)
+			add := flags.String("add", "", "comma separated instruments or players the song needs")
+			remove := flags.String("remove", "", "comma separated instruments or players the song no longer needs")
+			positional := parseFlags(flags, args[1:])
+			if len(positional) != 1 {
+				log.Fatal(tracksUsage)
+			}
+			err := cli.RunTracksRequires(db, positional[0], service.ParseInstruments(*add), service.ParseInstruments(*remove))
+			if err != nil {
+				log.Fatalf("error updating track requirements: %v", err)
+			}
+		default:
+			log.Fatal(tracksUsage)
+		}
+
+	case "doctor":
+		if len(args) != 0 {
+			fmt.Println("No additional arguments needed for doctor, command will execute regardless")
+		}
+		err := cli.RunDoctor(db)
+		if err != nil {
+			log.Fatalf("doctor failed: %v", err)
+		}
+
+	case "stats":
+		flags := flag.NewFlagSet("stats", flag.ExitOnError)
+		format := flags.String("format", "text", "output format: text or json")
+		top := flags.Int("top", 10, "how many rows of each list to print as text")
+		if len(parseFlags(flags, args)) != 0 {
+			log.Fatal("Usage: ./setlist stats {--format text|json} {--top 10}")
+		}
+		err := cli.RunStats(db, *format, *top)
+		if err != nil {
+			log.Fatalf("stats failed: %v", err)
+		}
+
 	case "reset":
 		if len(args) != 0 {
 			fmt.Println("No additional arguments needed for Reset, command will execute regardless")
@@ -94,14 +267,113 @@ func main() {
 		}
 
 	case "build":
-		if len(args) != 0 {
-			fmt.Println("No additional arguments needed for manual database access, command will execute regardless")
+		flags := flag.NewFlagSet("build", flag.ExitOnError)
+		explain := flags.Bool("explain", false, "print how each song was picked instead of the progress output")
+		explainJSON := flags.Bool("explain-json", false, "print the build trace as JSON")
+		targets := flags.String("targets", "", "airtime target per singer as a percentage, e.g. Riley=40,Ty=40,Bos=20")
+		tolerance := flags.Float64("tolerance", service.DefaultTargetTolerance, "percentage points each singer's airtime can be off their target")
+		lineup := flags.String("lineup", "", "comma separated instruments and players on the gig, e.g. guitar,bass,drums,keys")
+		minDecade := flags.String("min-decade", "", "smallest share of the night per decade as a percentage, e.g. 2000s=30")
+		maxGenre := flags.String("max-genre", "", "most songs per set from a genre, e.g. country=2")
+		noRepeatGenre := flags.Bool("no-repeat-genre", false, "keep songs sharing a genre from playing back to back")
+		excludeGenres := flags.String("exclude-genres", "", "comma separated genres to leave out, e.g. country,metal")
+		minFast := flags.String("min-fast", "", "energy target, the smallest share of the night's songs that are fast, e.g. 40")
+		profileName := flags.String("profile", "", "event profile to start from, e.g. wedding")
+		sets := flags.Int("sets", 0, "number of sets to split the gig into, 1 to 3")
+		requestSpacing := flags.Int("request-spacing", 0, "songs played between requests")
+		start := flags.String("start", "", "when the gig starts, e.g. 19:30, to print each song's start time")
+		gap := flags.Int("gap", 0, "seconds between songs for the printed start times")
+		hardStop := flags.String("hard-stop", "", "time the band has to finish by, e.g. 23:00")
+		timeWindows := flags.String("time-window", "", "when kinds of songs can play, e.g. slow<20:00,fast>21:30,country=19:00-21:00")
+		if len(parseFlags(flags, args)) != 0 {
+			fmt.Println("No additional arguments needed for build, command will execute regardless")
+		}
+		explainFormat := ""
+		if *explain {
+			explainFormat = "text"
 		}
-		requestsList, dnpList, singersList, duration, requestCount, explicit, err := cli.RunBuildQuestions(db)
+		if *explainJSON {
+			explainFormat = "json"
+		}
+		var profile *service.Profile
+		if *profileName != "" {
+			loaded, profileErr := cli.LoadProfile(db, *profileName)
+			if profileErr != nil {
+				log.Fatalf("build failed: %v", profileErr)
+			}
+			profile = loaded
+		}
+		params, err := cli.RunBuildQuestions(db, profile)
 		if err != nil {
 			log.Fatalf("build questions failed: %v", err)
 		}
-		buildErr := cli.RunBuild(db, requestsList, dnpList, singersList, duration, requestCount, explicit)
+		if *targets != "" {
+			singerTargets, targetsErr := service.ParseSingerTargets(*targets)
+			if targetsErr != nil {
+				log.Fatalf("build failed: %v", targetsErr)
+			}
+			params.SingerTargets = singerTargets
+			params.TargetTolerance = *tolerance
+			if checkErr := params.CheckTargets(); checkErr != nil {
+				log.Fatalf("build failed: %v", checkErr)
+			}
+		}
+		if *lineup != "" {
+			params.Lineup = service.ParseInstruments(*lineup)
+		}
+		if *minDecade != "" {
+			shares, sharesErr := service.ParseDecadeShares(*minDecade)
+			if sharesErr != nil {
+				log.Fatalf("build failed: %v", sharesErr)
+			}
+			params.Mix.MinDecadeShare = shares
+		}
+		if *maxGenre != "" {
+			limits, limitsErr := service.ParseGenreLimits(*maxGenre)
+			if limitsErr != nil {
+				log.Fatalf("build failed: %v", limitsErr)
+			}
+			params.Mix.MaxGenrePerSet = limits
+		}
+		if *noRepeatGenre {
+			params.Mix.NoRepeatGenre = true
+		}
+		if *excludeGenres != "" {
+			params.Mix.ExcludeGenres = service.ParseGenres(*excludeGenres)
+		}
+		if *minFast != "" {
+			share, shareErr := service.ParseFastShare(*minFast)
+			if shareErr != nil {
+				log.Fatalf("build failed: %v", shareErr)
+			}
+			params.Mix.MinFastShare = share
+		}
+		if checkErr := params.Mix.Check(); checkErr != nil {
+			log.Fatalf("build failed: %v", checkErr)
+		}
+		if *sets != 0 {
+			params.Sets = *sets
+		}
+		if *requestSpacing != 0 {
+			params.RequestSpacing = *requestSpacing
+		}
+		if checkErr := params.CheckSets(); checkErr != nil {
+			log.Fatalf("build failed: %v", checkErr)
+		}
+		params.StartTime = *start
+		params.SongGap = *gap
+		params.HardStop = *hardStop
+		if *timeWindows != "" {
+			windows, windowsErr := service.ParseTimeWindows(*timeWindows)
+			if windowsErr != nil {
+				log.Fatalf("build failed: %v", windowsErr)
+			}
+			params.TimeWindows = windows
+		}
+		if checkErr := params.CheckSchedule(); checkErr != nil {
+			log.Fatalf("build failed: %v", checkErr)
+		}
+		buildErr := cli.RunBuild(db, params, explainFormat)
 		if buildErr != nil {
 			log.Fatalf("build function failed: %v", buildErr)
 			err := cli.RunClear(db, "working")
@@ -110,13 +382,249 @@ func main() {
 			}
 		}
 
-	case "singers":
+	case "serve":
+		flags := flag.NewFlagSet("serve", flag.ExitOnError)
+		addr := flags.String("addr", ":8080", "address to listen on")
+		if len(parseFlags(flags, args)) != 0 {
+			log.Fatal("Usage: ./setlist serve {--addr :8080}")
+		}
+		err := cli.RunServe(db, *addr)
+		if err != nil {
+			log.Fatalf("serve failed: %v", err)
+		}
+
+	case "why":
+		if len(args) == 0 {
+			log.Fatal("Usage: ./setlist why [song]")
+		}
+		err := cli.RunWhy(db, strings.Join(args, " "))
+		if err != nil {
+			log.Fatalf("why failed: %v", err)
+		}
+
+	case "lint":
+		flags := flag.NewFlagSet("lint", flag.ExitOnError)
+		duration := flags.Int("duration", 0, "gig length in minutes, to check set lengths")
+		singers := flags.String("singers", "", "comma separated singers for the gig, defaults to the singers in the file")
+		allowExplicit := flags.Bool("allow-explicit", false, "allow explicit songs, the same as --explicit allow")
+		explicit := flags.String("explicit", "", "explicit lyrics policy: allow, clean or exclude")
+		dnp := flags.String("dnp", "", "'Do Not Play' list as a file or link")
+		files := parseFlags(flags, args)
+		if len(files) != 1 {
+			log.Fatal("Usage: ./setlist lint [file] {--duration minutes} {--singers a,b} {--explicit allow|clean|exclude} {--dnp file}")
+		}
+		if *allowExplicit && *explicit == "" {
+			*explicit = service.ExplicitAllow
+		}
+		count, err := cli.RunLint(db, files[0], *duration, *singers, *explicit, *dnp)
+		if err != nil {
+			log.Printf("lint failed: %v", err)
+			os.Exit(2)
+		}
+		if count > 0 {
+			os.Exit(1)
+		}
+
+	case "tui":
 		if len(args) != 0 {
-			fmt.Println("No additional arguments needed for manual database access, command will execute regardless")
+			fmt.Println("No additional arguments needed for the terminal UI, command will execute regardless")
+		}
+		err := cli.RunTUI(db)
+		if err != nil {
+			log.Fatalf("tui failed: %v", err)
 		}
-		err := cli.RunAddSingers(db)
+
+	case "ui":
+		flags := flag.NewFlagSet("ui", flag.ExitOnError)
+		addr := flags.String("addr", ":8080", "address to listen on")
+		if len(parseFlags(flags, args)) != 0 {
+			log.Fatal("Usage: ./setlist ui {--addr :8080}")
+		}
+		err := cli.RunUI(db, *addr)
 		if err != nil {
-			log.Fatalf("error adding singers: %v", err)
+			log.Fatalf("ui failed: %v", err)
+		}
+
+	case "singers":
+		singersUsage := "Usage: ./setlist singers\n       ./setlist singers edit [song]\n       ./setlist singers remove [song] [singer]\n       ./setlist singers list {--singer name} {--missing}\n       ./setlist singers show [song]\n       ./setlist singers suggest\n       ./setlist singers duet add [song] [lead] [singer] {--role duet}\n       ./setlist singers duet remove [song] [lead] [singer]"
+		if len(args) == 0 {
+			err := cli.RunAddSingers(db)
+			if err != nil {
+				log.Fatalf("error adding singers: %v", err)
+			}
+			break
+		}
+		switch args[0] {
+		case "edit":
+			if len(args) != 2 {
+				log.Fatal(singersUsage)
+			}
+			err := cli.RunEditSingers(db, args[1])
+			if err != nil {
+				log.Fatalf("error editing singers: %v", err)
+			}
+		case "remove":
+			if len(args) != 3 {
+				log.Fatal(singersUsage)
+			}
+			err := cli.RunRemoveSinger(db, args[1], args[2])
+			if err != nil {
+				log.Fatalf("error removing singer: %v", err)
+			}
+		case "list":
+			flags := flag.NewFlagSet("singers list", flag.ExitOnError)
+			singer := flags.String("singer", "", "only list songs for this singer")
+			missing := flags.Bool("missing", false, "list songs with no singers (or no key for --singer)")
+			if len(parseFlags(flags, args[1:])) != 0 {
+				log.Fatal(singersUsage)
+			}
+			err := cli.RunListSingers(db, *singer, *missing)
+			if err != nil {
+				log.Fatalf("error listing singers: %v", err)
+			}
+		case "suggest":
+			err := cli.RunSuggestKeys(db)
+			if err != nil {
+				log.Fatalf("error suggesting keys: %v", err)
+			}
+		case "show":
+			if len(args) != 2 {
+				log.Fatal(singersUsage)
+			}
+			err := cli.RunShowSingers(db, args[1])
+			if err != nil {
+				log.Fatalf("error showing singers: %v", err)
+			}
+		case "duet":
+			if len(args) < 2 {
+				log.Fatal(singersUsage)
+			}
+			switch args[1] {
+			case "add":
+				flags := flag.NewFlagSet("singers duet add", flag.ExitOnError)
+				role := flags.String("role", service.DefaultRole, "the partner's part, e.g. duet, harmony or second verse")
+				duetArgs := parseFlags(flags, args[2:])
+				if len(duetArgs) != 3 {
+					log.Fatal(singersUsage)
+				}
+				err := cli.RunAddDuetPart(db, duetArgs[0], duetArgs[1], duetArgs[2], *role)
+				if err != nil {
+					log.Fatalf("error adding duet part: %v", err)
+				}
+			case "remove":
+				if len(args) != 5 {
+					log.Fatal(singersUsage)
+				}
+				err := cli.RunRemoveDuetPart(db, args[2], args[3], args[4])
+				if err != nil {
+					log.Fatalf("error removing duet part: %v", err)
+				}
+			default:
+				log.Fatal(singersUsage)
+			}
+		default:
+			log.Fatal(singersUsage)
+		}
+
+	case "ranges":
+		rangesUsage := "Usage: ./setlist ranges\n       ./setlist ranges singer [singer] [lowest note] [highest note]\n       ./setlist ranges track [song] [lowest note] [highest note]"
+		if len(args) == 0 {
+			err := cli.RunListRanges(db)
+			if err != nil {
+				log.Fatalf("error listing ranges: %v", err)
+			}
+		} else if len(args) == 4 && args[0] == "singer" {
+			err := cli.RunSetSingerRange(db, args[1], args[2], args[3])
+			if err != nil {
+				log.Fatalf("error setting vocal range: %v", err)
+			}
+		} else if len(args) == 4 && args[0] == "track" {
+			err := cli.RunSetMelodyRange(db, args[1], args[2], args[3])
+			if err != nil {
+				log.Fatalf("error setting melody range: %v", err)
+			}
+		} else {
+			log.Fatal(rangesUsage)
+		}
+
+	case "profiles":
+		profilesUsage := "Usage: ./setlist profiles\n       ./setlist profiles show [name]\n       ./setlist profiles edit [name] {--setting value ...}\n       ./setlist profiles remove [name]"
+		if len(args) == 0 {
+			err := cli.RunListProfiles(db)
+			if err != nil {
+				log.Fatalf("error listing profiles: %v", err)
+			}
+			break
+		}
+		switch args[0] {
+		case "show":
+			if len(args) != 2 {
+				log.Fatal(profilesUsage)
+			}
+			err := cli.RunShowProfile(db, args[1])
+			if err != nil {
+				log.Fatalf("error showing profile: %v", err)
+			}
+		case "edit":
+			flags := flag.NewFlagSet("profiles edit", flag.ExitOnError)
+			flags.String("description", "", "what the profile is for")
+			flags.String("duration", "", "default gig length in minutes")
+			flags.String("sets", "", "number of sets, 1 to 3")
+			flags.String("explicit", "", "allow, clean or exclude, blank to ask at each build")
+			flags.String("singers", "", "comma separated default singers")
+			flags.String("targets", "", "airtime target per singer, e.g. Riley=40,Ty=40,Bos=20")
+			flags.String("tolerance", "", "percentage points each singer's airtime can be off their target")
+			flags.String("lineup", "", "comma separated instruments and players")
+			flags.String("request-spacing", "", "songs played between requests")
+			flags.String("min-decade", "", "smallest share of the night per decade, e.g. 2000s=30")
+			flags.String("max-genre", "", "most songs per set from a genre, e.g. country=2")
+			flags.String("no-repeat-genre", "", "true to keep a genre from playing twice in a row")
+			flags.String("exclude-genres", "", "comma separated genres to leave out")
+			flags.String("min-fast", "", "smallest share of the night's songs that are fast, e.g. 40")
+			positional := parseFlags(flags, args[1:])
+			if len(positional) != 1 {
+				log.Fatal(profilesUsage)
+			}
+			// only the flags given are changed, with none given every setting is prompted for
+			edits := map[string]string{}
+			flags.Visit(func(f *flag.Flag) {
+				edits[f.Name] = f.Value.String()
+			})
+			err := cli.RunEditProfile(db, positional[0], edits)
+			if err != nil {
+				log.Fatalf("error editing profile: %v", err)
+			}
+		case "remove":
+			if len(args) != 2 {
+				log.Fatal(profilesUsage)
+			}
+			err := cli.RunRemoveProfile(db, args[1])
+			if err != nil {
+				log.Fatalf("error removing profile: %v", err)
+			}
+		default:
+			log.Fatal(profilesUsage)
+		}
+
+	case "genres":
+		genresUsage := "Usage: ./setlist genres\n       ./setlist genres map [genre] [bucket]\n       ./setlist genres unmap [genre]"
+		if len(args) == 0 {
+			err := cli.RunListGenres(db)
+			if err != nil {
+				log.Fatalf("error listing genres: %v", err)
+			}
+		} else if len(args) == 3 && args[0] == "map" {
+			err := cli.RunMapGenre(db, args[1], args[2])
+			if err != nil {
+				log.Fatalf("error mapping genre: %v", err)
+			}
+		} else if len(args) == 2 && args[0] == "unmap" {
+			err := cli.RunUnmapGenre(db, args[1])
+			if err != nil {
+				log.Fatalf("error unmapping genre: %v", err)
+			}
+		} else {
+			log.Fatal(genresUsage)
 		}
 
 	case "keys":
@@ -139,3 +647,17 @@ func main() {
 		fmt.Println("Please use the help command ('./setlist help') to see a list of all available commands")
 	}
 }
+
+// parseFlags parses flags given before or after positional arguments and returns the positional arguments.
+func parseFlags(flags *flag.FlagSet, args []string) []string {
+	positional := []string{}
+	for {
+		flags.Parse(args)
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
@@ -1,5 +1,5 @@
 -- name: CreateTrack :exec
-INSERT INTO tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key)
+INSERT INTO tracks (spotify_id, isrc, name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key)
 VALUES (
     $1,
     $2,
@@ -8,17 +8,20 @@ VALUES (
     $5,
     $6,
     $7,
-    $8
+    $8,
+    $9,
+    $10
 );
 
 -- name: AddOriginalKey :exec
 UPDATE tracks
 SET
     original_key = $1
-WHERE name = $2 AND artist = $3;
+WHERE id = $2;
 
 -- name: GetTracksWithSingers :many
 SELECT
+    t.id,
     t.name,
     t.artist,
     t.genre,
@@ -27,22 +30,23 @@ SELECT
     t.explicit,
     t.bpm,
     t.original_key,
+    t.clean_version,
     s.singer,
     s.key AS singer_key
 FROM
     tracks t
 JOIN
-    singers s ON t.name = s.song AND t.artist = s.artist;
+    singers s ON s.track_id = t.id;
 
 -- name: AddSingerToWorking :exec
 UPDATE working
 SET 
     singer = $1,
     singer_key = $2
-WHERE name = $3 AND artist = $4;
+WHERE track_id = $3;
 
 -- name: AddTrackToWorking :exec
-INSERT INTO working (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key)
+INSERT INTO working (track_id, name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, clean_version)
 VALUES (
     $1,
     $2,
@@ -51,18 +55,22 @@ VALUES (
     $5,
     $6,
     $7,
-    $8
+    $8,
+    $9,
+    $10
 );
 
 -- name: SumDurationForSinger :many
 SELECT
   s.singer,
-  SUM(t.duration_in_seconds) AS total_duration
+  COUNT(*) AS song_count,
+  SUM(t.duration_in_seconds) AS total_duration,
+  COALESCE(SUM(t.duration_in_seconds) FILTER (WHERE NOT t.explicit OR t.clean_version), 0)::bigint AS clean_duration
 FROM
   singers s
 JOIN
   tracks t
-  ON s.song = t.name AND s.artist = t.artist
+  ON s.track_id = t.id
 WHERE
   s.singer = ANY($1::text[])
 GROUP BY
@@ -70,19 +78,19 @@ GROUP BY
 ORDER BY
   total_duration DESC;
 
--- name: GetTrackFromName :one
-SELECT * FROM tracks WHERE name ILIKE $1;
+-- name: GetTracksFromName :many
+SELECT * FROM tracks WHERE lower(name) = lower($1) ORDER BY id;
 
 -- name: GetSingerCombos :many
-SELECT singer, key from singers WHERE song = $1 and artist = $2 AND singer = ANY($3::text[]);
+SELECT singer, key from singers WHERE track_id = $1 AND singer = ANY($2::text[]);
 
 -- name: CheckSingers :one
 SELECT NOT EXISTS (
-  SELECT 1 FROM singers WHERE song = $1 AND artist = $2
+  SELECT 1 FROM singers WHERE track_id = $1
 );
 
 -- name: CheckKeys :many
-SELECT name, artist FROM tracks WHERE original_key = '' OR original_key IS NULL;
+SELECT id, name, artist FROM tracks WHERE original_key = '' OR original_key IS NULL;
 
 -- name: CountSingers :one
 SELECT COUNT(DISTINCT singer)
@@ -93,22 +101,21 @@ SELECT singer FROM singers;
 
 
 -- name: AddToSingers :exec
-INSERT INTO singers (song, artist, singer, key)
+INSERT INTO singers (track_id, singer, key)
 VALUES (
     $1,
     $2,
-    $3,
-    $4
+    $3
 );
 
 -- name: RemoveFromWorking :exec
-DELETE FROM working WHERE working.name = $1;
+DELETE FROM working WHERE working.track_id = $1;
 
 -- name: GetTrack :one
 SELECT * FROM tracks WHERE tracks.name = $1 AND tracks.artist = $2;
 
 -- name: GetWorking :one
-SELECT * FROM working WHERE working.name = $1;
+SELECT * FROM working WHERE working.track_id = $1;
 
 -- name: GetAllTracks :many
 SELECT * FROM tracks;
@@ -117,7 +124,7 @@ SELECT * FROM tracks;
 SELECT * FROM working;
 
 -- name: DeleteTrack :exec
-DELETE FROM tracks WHERE tracks.name = $1 AND tracks.artist = $2;
+DELETE FROM tracks WHERE tracks.id = $1;
 
 -- name: CleanTracks :exec
 DELETE FROM tracks WHERE tracks.original_key = '';
@@ -126,4 +133,283 @@ DELETE FROM tracks WHERE tracks.original_key = '';
 DELETE FROM singers WHERE singer = '';
 
 -- name: ClearWorking :exec
-DELETE FROM working;
\ No newline at end of file
+DELETE FROM working;
+
+-- name: UpsertTrack :one
+INSERT INTO tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, clean_version, spotify_id, isrc)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11
+)
+ON CONFLICT (name, artist) DO UPDATE
+SET
+    genre = EXCLUDED.genre,
+    duration_in_seconds = EXCLUDED.duration_in_seconds,
+    year = EXCLUDED.year,
+    explicit = EXCLUDED.explicit,
+    bpm = EXCLUDED.bpm,
+    original_key = EXCLUDED.original_key,
+    clean_version = EXCLUDED.clean_version,
+    spotify_id = COALESCE(EXCLUDED.spotify_id, tracks.spotify_id),
+    isrc = COALESCE(EXCLUDED.isrc, tracks.isrc)
+RETURNING id;
+
+-- name: UpsertSinger :exec
+INSERT INTO singers (track_id, singer, key)
+VALUES (
+    $1,
+    $2,
+    $3
+)
+ON CONFLICT (track_id, singer) DO UPDATE
+SET
+    key = EXCLUDED.key;
+
+-- name: GetAllSingers :many
+SELECT s.track_id, t.name, t.artist, s.singer, s.key
+FROM singers s
+JOIN tracks t ON t.id = s.track_id
+ORDER BY t.name, t.artist, s.singer;
+
+-- name: FindTrack :one
+SELECT * FROM tracks WHERE lower(name) = lower(sqlc.arg(name)) AND lower(artist) = lower(sqlc.arg(artist));
+
+-- name: UpdateSingerKey :exec
+UPDATE singers
+SET
+    key = $1
+WHERE track_id = $2 AND singer = $3;
+
+-- name: RemoveSinger :execrows
+DELETE FROM singers WHERE track_id = $1 AND singer = $2;
+
+-- name: GetSingersForTrack :many
+SELECT * FROM singers WHERE track_id = $1 ORDER BY singer;
+
+-- name: GetSingerAssignments :many
+SELECT s.track_id, t.name, t.artist, s.singer, s.key
+FROM singers s
+JOIN tracks t ON t.id = s.track_id
+WHERE s.singer = $1
+ORDER BY t.name, t.artist;
+
+-- name: GetTracksWithoutSingers :many
+SELECT * FROM tracks
+WHERE NOT EXISTS (
+  SELECT 1 FROM singers WHERE singers.track_id = tracks.id
+)
+ORDER BY name, artist;
+
+-- name: SetSingerRange :exec
+INSERT INTO singer_ranges (singer, low_note, high_note)
+VALUES (
+    $1,
+    $2,
+    $3
+)
+ON CONFLICT (singer) DO UPDATE
+SET
+    low_note = EXCLUDED.low_note,
+    high_note = EXCLUDED.high_note;
+
+-- name: GetSingerRange :one
+SELECT * FROM singer_ranges WHERE singer = $1;
+
+-- name: GetAllSingerRanges :many
+SELECT * FROM singer_ranges ORDER BY singer;
+
+-- name: SetMelodyRange :exec
+UPDATE tracks
+SET
+    melody_low = $1,
+    melody_high = $2
+WHERE id = $3;
+
+-- name: CreateSetlist :one
+INSERT INTO setlists (name, data)
+VALUES (
+    $1,
+    $2
+)
+RETURNING *;
+
+-- name: GetSetlist :one
+SELECT * FROM setlists WHERE id = $1;
+
+-- name: GetAllSetlists :many
+SELECT * FROM setlists ORDER BY created_at DESC;
+
+-- name: DeleteSetlist :execrows
+DELETE FROM setlists WHERE id = $1;
+
+-- name: ClearBuildTraces :exec
+DELETE FROM build_traces;
+
+-- name: CreateBuildTrace :exec
+INSERT INTO build_traces (setlist, trace)
+VALUES (
+    $1,
+    $2
+);
+
+-- name: GetLastBuildTrace :one
+SELECT * FROM build_traces ORDER BY created_at DESC, id DESC LIMIT 1;
+
+-- name: GetTrackByID :one
+SELECT * FROM tracks WHERE id = $1;
+
+-- name: GetTrackBySpotifyID :one
+SELECT * FROM tracks WHERE spotify_id = $1;
+
+-- name: UpdateTrack :exec
+UPDATE tracks
+SET
+    spotify_id = $1,
+    isrc = $2,
+    name = $3,
+    artist = $4,
+    genre = $5,
+    duration_in_seconds = $6,
+    year = $7,
+    explicit = $8,
+    bpm = $9,
+    original_key = $10,
+    melody_low = $11,
+    melody_high = $12,
+    clean_version = $13
+WHERE id = $14;
+
+-- name: MoveSingers :exec
+INSERT INTO singers (track_id, singer, key)
+SELECT sqlc.arg(to_track_id)::int, singer, key FROM singers
+WHERE track_id = sqlc.arg(from_track_id)
+ON CONFLICT (track_id, singer) DO NOTHING;
+
+-- name: UpdateSetlistData :exec
+UPDATE setlists SET data = $1 WHERE id = $2;
+
+-- name: GetAllBuildTraces :many
+SELECT * FROM build_traces ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateBuildTrace :exec
+UPDATE build_traces
+SET
+    setlist = $1,
+    trace = $2
+WHERE id = $3;
+
+-- name: CreateBuildHistory :exec
+INSERT INTO build_history (setlist)
+VALUES (
+    $1
+);
+
+-- name: GetBuildHistory :many
+SELECT * FROM build_history ORDER BY created_at, id;
+
+-- name: UpdateBuildHistory :exec
+UPDATE build_history SET setlist = $1 WHERE id = $2;
+
+-- name: AddSingerPart :exec
+INSERT INTO singer_parts (track_id, lead, singer, role)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4
+)
+ON CONFLICT (track_id, lead, singer) DO UPDATE
+SET
+    role = EXCLUDED.role;
+
+-- name: RemoveSingerPart :execrows
+DELETE FROM singer_parts WHERE track_id = $1 AND lead = $2 AND singer = $3;
+
+-- name: GetSingerParts :many
+SELECT * FROM singer_parts WHERE track_id = $1 ORDER BY lead, singer;
+
+-- name: GetAllSingerParts :many
+SELECT p.track_id, t.name, t.artist, p.lead, p.singer, p.role
+FROM singer_parts p
+JOIN tracks t ON t.id = p.track_id
+ORDER BY t.name, t.artist, p.lead, p.singer;
+
+-- name: MoveSingerParts :exec
+INSERT INTO singer_parts (track_id, lead, singer, role)
+SELECT sqlc.arg(to_track_id)::int, p.lead, p.singer, p.role FROM singer_parts p
+WHERE p.track_id = sqlc.arg(from_track_id)
+AND NOT EXISTS (
+  SELECT 1 FROM singer_parts kept WHERE kept.track_id = sqlc.arg(to_track_id)::int AND kept.lead = p.lead
+)
+ON CONFLICT (track_id, lead, singer) DO NOTHING;
+
+-- name: AddTrackRequirement :exec
+INSERT INTO track_requirements (track_id, instrument)
+VALUES (
+    $1,
+    $2
+)
+ON CONFLICT (track_id, instrument) DO NOTHING;
+
+-- name: RemoveTrackRequirement :execrows
+DELETE FROM track_requirements WHERE track_id = $1 AND instrument = $2;
+
+-- name: GetTrackRequirements :many
+SELECT instrument FROM track_requirements WHERE track_id = $1 ORDER BY instrument;
+
+-- name: GetAllTrackRequirements :many
+SELECT r.track_id, t.name, t.artist, r.instrument
+FROM track_requirements r
+JOIN tracks t ON t.id = r.track_id
+ORDER BY t.name, t.artist, r.instrument;
+
+-- name: MoveTrackRequirements :exec
+INSERT INTO track_requirements (track_id, instrument)
+SELECT sqlc.arg(to_track_id)::int, instrument FROM track_requirements
+WHERE track_id = sqlc.arg(from_track_id)
+ON CONFLICT (track_id, instrument) DO NOTHING;
+
+-- name: GetGenreBuckets :many
+SELECT * FROM genre_buckets ORDER BY genre;
+
+-- name: SetGenreBucket :exec
+INSERT INTO genre_buckets (genre, bucket)
+VALUES (
+    $1,
+    $2
+)
+ON CONFLICT (genre) DO UPDATE
+SET
+    bucket = EXCLUDED.bucket;
+
+-- name: RemoveGenreBucket :execrows
+DELETE FROM genre_buckets WHERE genre = $1;
+
+-- name: GetProfile :one
+SELECT * FROM profiles WHERE name = $1;
+
+-- name: GetAllProfiles :many
+SELECT * FROM profiles ORDER BY name;
+
+-- name: UpsertProfile :exec
+INSERT INTO profiles (name, settings)
+VALUES (
+    $1,
+    $2
+)
+ON CONFLICT (name) DO UPDATE
+SET
+    settings = EXCLUDED.settings,
+    updated_at = NOW();
+
+-- name: DeleteProfile :execrows
+DELETE FROM profiles WHERE name = $1;
@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE tracks
+    ADD COLUMN melody_low TEXT NOT NULL DEFAULT '',
+    ADD COLUMN melody_high TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE singer_ranges (
+    singer TEXT NOT NULL,
+    low_note TEXT NOT NULL,
+    high_note TEXT NOT NULL,
+    CONSTRAINT PK_singer_ranges PRIMARY KEY(singer)
+);
+
+-- +goose Down
+DROP TABLE singer_ranges;
+ALTER TABLE tracks
+    DROP COLUMN melody_low,
+    DROP COLUMN melody_high;
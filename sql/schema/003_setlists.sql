@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE setlists (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    data JSONB NOT NULL
+);
+
+-- +goose Down
+DROP TABLE setlists;
@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE build_traces (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    setlist JSONB NOT NULL,
+    trace JSONB NOT NULL
+);
+
+-- +goose Down
+DROP TABLE build_traces;
@@ -0,0 +1,124 @@
+-- +goose Up
+-- tracks, singers and working are rebuilt rather than altered so the id comes first
+ALTER TABLE tracks RENAME TO tracks_old;
+
+CREATE TABLE tracks (
+    id SERIAL PRIMARY KEY,
+    spotify_id TEXT,
+    isrc TEXT,
+    name TEXT NOT NULL,
+    artist TEXT NOT NULL,
+    genre TEXT[],
+    duration_in_seconds INT NOT NULL,
+    year TEXT NOT NULL,
+    explicit BOOL NOT NULL DEFAULT false,
+    bpm INT NOT NULL DEFAULT 0,
+    original_key TEXT NOT NULL DEFAULT '',
+    melody_low TEXT NOT NULL DEFAULT '',
+    melody_high TEXT NOT NULL DEFAULT '',
+    CONSTRAINT UQ_tracks_name_artist UNIQUE(name, artist),
+    CONSTRAINT UQ_tracks_spotify_id UNIQUE(spotify_id),
+    CONSTRAINT UQ_tracks_isrc UNIQUE(isrc)
+);
+
+INSERT INTO tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, melody_low, melody_high)
+SELECT name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, melody_low, melody_high
+FROM tracks_old
+ORDER BY name, artist;
+
+CREATE TABLE singers_new (
+    track_id INT NOT NULL,
+    singer TEXT NOT NULL,
+    key TEXT NOT NULL,
+    CONSTRAINT PK_singers_new PRIMARY KEY(track_id, singer),
+    CONSTRAINT FK_singers_tracks FOREIGN KEY (track_id)
+        REFERENCES tracks(id)
+        ON DELETE CASCADE
+);
+
+INSERT INTO singers_new (track_id, singer, key)
+SELECT t.id, s.singer, s.key
+FROM singers s
+JOIN tracks t ON t.name = s.song AND t.artist = s.artist;
+
+DROP TABLE singers;
+ALTER TABLE singers_new RENAME TO singers;
+ALTER TABLE singers RENAME CONSTRAINT PK_singers_new TO PK_singers;
+DROP TABLE tracks_old;
+
+-- working only holds a build in progress, so it's recreated empty
+DROP TABLE working;
+CREATE TABLE working (
+    track_id INT NOT NULL,
+    name TEXT NOT NULL,
+    artist TEXT NOT NULL,
+    genre TEXT[],
+    duration_in_seconds INT NOT NULL,
+    year TEXT NOT NULL,
+    explicit BOOL NOT NULL DEFAULT false,
+    bpm INT NOT NULL,
+    original_key TEXT NOT NULL,
+    singer TEXT,
+    singer_key TEXT,
+    CONSTRAINT PK_working PRIMARY KEY(track_id),
+    CONSTRAINT FK_working_tracks FOREIGN KEY (track_id)
+        REFERENCES tracks(id)
+        ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE working;
+CREATE TABLE working (
+    name TEXT NOT NULL,
+    artist TEXT NOT NULL,
+    genre TEXT[],
+    duration_in_seconds INT NOT NULL,
+    year TEXT NOT NULL,
+    explicit BOOL NOT NULL DEFAULT false,
+    bpm INT NOT NULL,
+    original_key TEXT NOT NULL,
+    singer TEXT,
+    singer_key TEXT,
+    CONSTRAINT PK_working PRIMARY KEY(name,artist)
+);
+
+ALTER TABLE singers RENAME TO singers_new;
+ALTER TABLE singers_new RENAME CONSTRAINT PK_singers TO PK_singers_new;
+ALTER TABLE tracks RENAME TO tracks_new;
+
+CREATE TABLE tracks (
+    name TEXT NOT NULL,
+    artist TEXT NOT NULL,
+    genre TEXT[],
+    duration_in_seconds INT NOT NULL,
+    year TEXT NOT NULL,
+    explicit BOOL NOT NULL DEFAULT false,
+    bpm INT NOT NULL DEFAULT 0,
+    original_key TEXT NOT NULL DEFAULT '',
+    melody_low TEXT NOT NULL DEFAULT '',
+    melody_high TEXT NOT NULL DEFAULT '',
+    CONSTRAINT PK_name_artist PRIMARY KEY(name,artist)
+);
+
+INSERT INTO tracks (name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, melody_low, melody_high)
+SELECT name, artist, genre, duration_in_seconds, year, explicit, bpm, original_key, melody_low, melody_high
+FROM tracks_new;
+
+CREATE TABLE singers (
+    song TEXT NOT NULL,
+    artist TEXT NOT NULL,
+    singer TEXT NOT NULL,
+    key TEXT NOT NULL,
+    CONSTRAINT PK_singers PRIMARY KEY(song, artist, singer),
+    CONSTRAINT FK_singers_tracks FOREIGN KEY (song, artist)
+        REFERENCES tracks(name, artist)
+        ON DELETE CASCADE
+);
+
+INSERT INTO singers (song, artist, singer, key)
+SELECT t.name, t.artist, s.singer, s.key
+FROM singers_new s
+JOIN tracks_new t ON t.id = s.track_id;
+
+DROP TABLE singers_new;
+DROP TABLE tracks_new;
@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE build_history (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    setlist JSONB NOT NULL
+);
+
+-- +goose Down
+DROP TABLE build_history;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- the other vocalists on a singer's arrangement of a song, the singers row being the lead
+CREATE TABLE singer_parts (
+    track_id INT NOT NULL,
+    lead TEXT NOT NULL,
+    singer TEXT NOT NULL,
+    role TEXT NOT NULL DEFAULT 'duet',
+    CONSTRAINT PK_singer_parts PRIMARY KEY(track_id, lead, singer),
+    CONSTRAINT FK_singer_parts_singers FOREIGN KEY (track_id, lead)
+        REFERENCES singers(track_id, singer)
+        ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE singer_parts;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- the instruments or players a song can't be played without, e.g. horns or keys
+CREATE TABLE track_requirements (
+    track_id INT NOT NULL,
+    instrument TEXT NOT NULL,
+    CONSTRAINT PK_track_requirements PRIMARY KEY(track_id, instrument),
+    CONSTRAINT FK_track_requirements_tracks FOREIGN KEY (track_id)
+        REFERENCES tracks(id)
+        ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE track_requirements;
@@ -0,0 +1,33 @@
+-- +goose Up
+-- maps Spotify's genres onto the band's own buckets, see service.GenreMap for how they match
+CREATE TABLE genre_buckets (
+    genre TEXT PRIMARY KEY,
+    bucket TEXT NOT NULL
+);
+
+INSERT INTO genre_buckets (genre, bucket) VALUES
+    ('pop', 'pop'),
+    ('dance pop', 'pop'),
+    ('post-teen pop', 'pop'),
+    ('rock', 'rock'),
+    ('metal', 'rock'),
+    ('punk', 'rock'),
+    ('country', 'country'),
+    ('hip hop', 'hip hop'),
+    ('rap', 'hip hop'),
+    ('r&b', 'r&b'),
+    ('soul', 'r&b'),
+    ('motown', 'r&b'),
+    ('funk', 'funk'),
+    ('disco', 'disco'),
+    ('dance', 'dance'),
+    ('edm', 'dance'),
+    ('house', 'dance'),
+    ('latin', 'latin'),
+    ('reggaeton', 'latin'),
+    ('folk', 'folk'),
+    ('jazz', 'jazz'),
+    ('blues', 'blues');
+
+-- +goose Down
+DROP TABLE genre_buckets;
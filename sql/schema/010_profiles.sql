@@ -0,0 +1,15 @@
+-- +goose Up
+-- named build presets, settings holds a service.Profile as JSON
+CREATE TABLE profiles (
+    name TEXT PRIMARY KEY,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    settings JSONB NOT NULL
+);
+
+INSERT INTO profiles (name, settings) VALUES
+    ('bar', '{"description": "Bar gigs: explicit lyrics allowed, in fewer and longer sets", "allow_explicit": true, "sets": 2}'),
+    ('corporate', '{"description": "Corporate events: always clean", "allow_explicit": false}'),
+    ('wedding', '{"description": "Weddings: clean, with requests every other song and no genre twice in a row", "allow_explicit": false, "request_spacing": 1, "mix": {"no_repeat_genre": true}}');
+
+-- +goose Down
+DROP TABLE profiles;
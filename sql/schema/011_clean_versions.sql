@@ -0,0 +1,8 @@
+-- +goose Up
+-- marks explicit tracks the band can perform as a clean (radio) edit
+ALTER TABLE tracks ADD COLUMN clean_version BOOL NOT NULL DEFAULT false;
+ALTER TABLE working ADD COLUMN clean_version BOOL NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE working DROP COLUMN clean_version;
+ALTER TABLE tracks DROP COLUMN clean_version;
@@ -0,0 +1,7 @@
+// Package schema embeds the goose migrations so the binary can migrate the database itself.
+package schema
+
+import "embed"
+
+//go:embed *.sql
+var Migrations embed.FS